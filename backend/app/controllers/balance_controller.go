@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/gofiber/fiber/v2"
+)
+
+type BalanceController struct {
+	balanceService services.BalanceService
+}
+
+func NewBalanceController(s services.BalanceService) *BalanceController {
+	return &BalanceController{s}
+}
+
+// GetBalances godoc
+// @Summary Get on-chain wallet balances
+// @Description Return the native balance of every Ethereum address of the wallet, read from the configured node.
+// @Description Balances are in base units (wei) as strings, and are cached until the next block.
+// @Tags Wallet
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} core.ApiResponse{data=[]dto.BalanceRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 502 {object} core.ApiResponse "Node request failed"
+// @Failure 503 {object} core.ApiResponse "No node configured for the wallet network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/balances [get]
+func (ctl *BalanceController) GetBalances(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.balanceService.GetBalances(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Erc20TokenController struct {
+	tokenService services.Erc20TokenService
+}
+
+func NewErc20TokenController(s services.Erc20TokenService) *Erc20TokenController {
+	return &Erc20TokenController{s}
+}
+
+// ListTokens godoc
+// @Summary List registered ERC-20 tokens
+// @Description Return the ERC-20 tokens the wallet tracks on an Ethereum network (default mainnet).
+// @Tags Token
+// @Produce json
+// @Param network query string false "Ethereum network" Enums(mainnet, sepolia, holesky, dev)
+// @Success 200 {object} core.ApiResponse{data=[]dto.Erc20TokenRes}
+// @Failure 400 {object} core.ApiResponse "Invalid network"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/tokens [get]
+func (ctl *Erc20TokenController) ListTokens(c *fiber.Ctx) error {
+	var req dto.ListErc20TokensReq
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid query", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.tokenService.ListTokens(c.Context(), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// CreateToken godoc
+// @Summary Register an ERC-20 token
+// @Description Add an ERC-20 contract to the registry of an Ethereum network. Requires the token:manage credential.
+// @Tags Token
+// @Accept json
+// @Produce json
+// @Param data body dto.CreateErc20TokenReq true "Token to register"
+// @Success 201 {object} core.ApiResponse{data=dto.Erc20TokenRes}
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 409 {object} core.ApiResponse "Contract or symbol already registered"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/tokens [post]
+func (ctl *Erc20TokenController) CreateToken(c *fiber.Ctx) error {
+	var req dto.CreateErc20TokenReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.tokenService.CreateToken(c.Context(), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
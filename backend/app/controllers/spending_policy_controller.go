@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SpendingPolicyController struct {
+	policyService services.SpendingPolicyService
+}
+
+func NewSpendingPolicyController(s services.SpendingPolicyService) *SpendingPolicyController {
+	return &SpendingPolicyController{s}
+}
+
+// ListWalletPolicies godoc
+// @Summary List the spending policies of a wallet
+// @Description Return the policies that bind signing with the wallet: its own and those over all of the owner's
+// @Description wallets. Only administrators can change them.
+// @Tags Policy
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} core.ApiResponse{data=[]dto.SpendingPolicyRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/policies [get]
+func (ctl *SpendingPolicyController) ListWalletPolicies(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.policyService.ListWalletPolicies(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ListPolicies godoc
+// @Summary List the spending policies of a user
+// @Description Return a user's policies, those over all of their wallets first. Requires the policy:manage
+// @Description credential.
+// @Tags Policy
+// @Produce json
+// @Param user_id query string true "User ID"
+// @Success 200 {object} core.ApiResponse{data=[]dto.SpendingPolicyRes}
+// @Failure 400 {object} core.ApiResponse "Invalid query"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/policies [get]
+func (ctl *SpendingPolicyController) ListPolicies(c *fiber.Ctx) error {
+	var req dto.ListSpendingPoliciesReq
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid query", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.policyService.ListPolicies(c.Context(), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// CreatePolicy godoc
+// @Summary Create a spending policy
+// @Description Limit what can be signed for one of a user's wallets, or for all of them together: a maximum per
+// @Description transaction, rolling daily and weekly caps of an asset, and allowed hours. Token allowances and
+// @Description permits count as transfers; while amounts are limited, unregistered tokens and calls that cannot be
+// @Description decoded are refused. A signing request that breaks a rule is refused with 403 and the rule it hit.
+// @Description The owner is notified. Requires the policy:manage credential.
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param data body dto.CreateSpendingPolicyReq true "Policy to create"
+// @Success 201 {object} core.ApiResponse{data=dto.SpendingPolicyRes}
+// @Failure 400 {object} core.ApiResponse "Invalid limits"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 404 {object} core.ApiResponse "User or wallet not found"
+// @Failure 409 {object} core.ApiResponse "Policy already exists for this scope and asset"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/policies [post]
+func (ctl *SpendingPolicyController) CreatePolicy(c *fiber.Ctx) error {
+	adminId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.CreateSpendingPolicyReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.policyService.CreatePolicy(c.Context(), adminId, &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// UpdatePolicy godoc
+// @Summary Replace the limits of a spending policy
+// @Description Limits left out are switched off. The owner is notified. Requires the policy:manage credential.
+// @Tags Policy
+// @Accept json
+// @Produce json
+// @Param policyId path string true "Spending policy ID"
+// @Param data body dto.UpdateSpendingPolicyReq true "New limits"
+// @Success 200 {object} core.ApiResponse{data=dto.SpendingPolicyRes}
+// @Failure 400 {object} core.ApiResponse "Invalid limits"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 404 {object} core.ApiResponse "Spending policy not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/policies/{policyId} [put]
+func (ctl *SpendingPolicyController) UpdatePolicy(c *fiber.Ctx) error {
+	adminId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.UpdateSpendingPolicyReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.policyService.UpdatePolicy(c.Context(), adminId, c.Params("policyId"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// DeletePolicy godoc
+// @Summary Delete a spending policy
+// @Description The owner is notified. Requires the policy:manage credential.
+// @Tags Policy
+// @Produce json
+// @Param policyId path string true "Spending policy ID"
+// @Success 200 {object} core.ApiResponse
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 404 {object} core.ApiResponse "Spending policy not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/policies/{policyId} [delete]
+func (ctl *SpendingPolicyController) DeletePolicy(c *fiber.Ctx) error {
+	adminId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.policyService.DeletePolicy(c.Context(), adminId, c.Params("policyId"), requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TransactionController struct {
+	transactionService services.TransactionService
+}
+
+func NewTransactionController(s services.TransactionService) *TransactionController {
+	return &TransactionController{s}
+}
+
+// RecordTransaction godoc
+// @Summary Record a wallet transaction
+// @Description Add a transaction to the wallet ledger. The wallet's side of the transfer (from_address when outgoing,
+// @Description to_address when incoming) must be one of its addresses. Status defaults to pending; a transaction
+// @Description already sent may be recorded as broadcast with its tx_hash.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.RecordTransactionReq true "Transaction to record"
+// @Success 201 {object} core.ApiResponse{data=dto.TransactionRes}
+// @Failure 400 {object} core.ApiResponse "Invalid request"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/transactions [post]
+func (ctl *TransactionController) RecordTransaction(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.RecordTransactionReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.transactionService.RecordTransaction(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ListTransactions godoc
+// @Summary List wallet transactions
+// @Description Return the wallet's transactions, newest first. Pass meta.next_cursor back as cursor to get the next page.
+// @Tags Transaction
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param status query string false "Status" Enums(pending, broadcast, confirmed, failed, replaced)
+// @Param direction query string false "Direction" Enums(incoming, outgoing)
+// @Param address query string false "Sender or recipient address"
+// @Param from query string false "Earliest transaction date (RFC 3339, inclusive)"
+// @Param to query string false "Latest transaction date (RFC 3339, inclusive)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Success 200 {object} core.ApiResponse{data=[]dto.TransactionRes,meta=dto.CursorMeta}
+// @Failure 400 {object} core.ApiResponse "Invalid filter or cursor"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/transactions [get]
+func (ctl *TransactionController) ListTransactions(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ListTransactionsReq
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid query", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.transactionService.ListTransactions(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// GetTransaction godoc
+// @Summary Get a wallet transaction
+// @Description Return a single transaction of the wallet.
+// @Tags Transaction
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param txId path string true "Transaction ID"
+// @Success 200 {object} core.ApiResponse{data=dto.TransactionRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or transaction not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/transactions/{txId} [get]
+func (ctl *TransactionController) GetTransaction(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.transactionService.GetTransaction(c.Context(), userId, c.Params("id"), c.Params("txId"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// UpdateTransactionStatus godoc
+// @Summary Change a transaction status
+// @Description Move a transaction along pending → broadcast → confirmed, failed or replaced; a pending transaction may also fail.
+// @Description Marking a transaction broadcast needs its tx_hash. Any other change is rejected with 409.
+// @Description Deposits found on chain by the deposit watcher are confirmed or rolled back by the watcher only.
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param txId path string true "Transaction ID"
+// @Param data body dto.UpdateTransactionStatusReq true "New status"
+// @Success 200 {object} core.ApiResponse{data=dto.TransactionRes}
+// @Failure 400 {object} core.ApiResponse "Invalid request"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or transaction not found"
+// @Failure 409 {object} core.ApiResponse "Status change not allowed"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/transactions/{txId}/status [put]
+func (ctl *TransactionController) UpdateTransactionStatus(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.UpdateTransactionStatusReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.transactionService.UpdateTransactionStatus(c.Context(), userId, c.Params("id"), c.Params("txId"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
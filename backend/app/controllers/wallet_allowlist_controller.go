@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAllowlist godoc
+// @Summary Get the withdrawal allowlist of a wallet
+// @Description Return whether allowlist mode is on and the wallet's approved destinations. Addresses become
+// @Description active once their cooling-off period has passed.
+// @Tags Wallet
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} core.ApiResponse{data=dto.WithdrawalAllowlistRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/allowlist [get]
+func (ctl *WalletController) GetAllowlist(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.GetAllowlist(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// AddWithdrawalAddress godoc
+// @Summary Add a withdrawal address
+// @Description Add a labelled destination to the wallet's allowlist. It can only be paid to after the cooling-off
+// @Description period (WITHDRAWAL_ADDRESS_DELAY), and the owner is notified. The wallet passphrase is required when
+// @Description the wallet was protected with one.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.AddWithdrawalAddressReq true "Address to approve"
+// @Success 201 {object} core.ApiResponse{data=dto.WithdrawalAddressRes}
+// @Failure 400 {object} core.ApiResponse "Invalid address or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 409 {object} core.ApiResponse "Address already in allowlist"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/allowlist [post]
+func (ctl *WalletController) AddWithdrawalAddress(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.AddWithdrawalAddressReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.AddWithdrawalAddress(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// RemoveWithdrawalAddress godoc
+// @Summary Remove a withdrawal address
+// @Description Remove a destination from the wallet's allowlist. Removal is immediate.
+// @Tags Wallet
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param addressId path string true "Withdrawal address ID"
+// @Success 200 {object} core.ApiResponse
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or withdrawal address not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/allowlist/{addressId} [delete]
+func (ctl *WalletController) RemoveWithdrawalAddress(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.RemoveWithdrawalAddress(c.Context(), userId, c.Params("id"), c.Params("addressId"), requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// SetAllowlistMode godoc
+// @Summary Switch allowlist mode
+// @Description While allowlist mode is on, transactions and PSBTs paying anywhere but active withdrawal addresses
+// @Description or the wallet's own addresses are refused. Switching on is immediate. Switching off needs the wallet
+// @Description passphrase, notifies the owner and only takes effect after the cooling-off period.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SetAllowlistModeReq true "Allowlist mode"
+// @Success 200 {object} core.ApiResponse{data=dto.WithdrawalAllowlistRes}
+// @Failure 400 {object} core.ApiResponse "Invalid body or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/allowlist/mode [put]
+func (ctl *WalletController) SetAllowlistMode(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SetAllowlistModeReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SetAllowlistMode(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
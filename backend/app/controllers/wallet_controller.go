@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"strings"
+
 	"github.com/create-go-app/fiber-go-template/app/dto"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
 	"github.com/create-go-app/fiber-go-template/pkg/core"
@@ -18,17 +20,24 @@ func NewWalletController(s services.WalletService) *WalletController {
 
 // CreateWallet godoc
 // @Summary Create a new wallet
-// @Description Create a new crypto wallet with generated mnemonic and first blockchain address
+// @Description Create a new crypto wallet with generated mnemonic and the first address of every requested chain (ETH by default)
 // @Tags Wallet
 // @Accept json
 // @Produce json
 // @Param data body dto.CreateWalletReq true "Create wallet payload"
 // @Success 201 {object} dto.CreateWalletRes
 // @Failure 400 {object} core.ApiResponse "Invalid request"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
 // @Failure 500 {object} core.ApiResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /v1/wallet [post]
 func (c *WalletController) CreateWallet(ctx *fiber.Ctx) error {
+	userId, err := currentUserId(ctx)
+	if err != nil {
+		return ctx.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
 
 	req := new(dto.CreateWalletReq)
 	if err := ctx.BodyParser(req); err != nil {
@@ -37,8 +46,16 @@ func (c *WalletController) CreateWallet(ctx *fiber.Ctx) error {
 		)
 	}
 
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return ctx.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
 	res, err := c.walletService.CreateWallet(
 		ctx.Context(),
+		userId,
 		req,
 	)
 	if err != nil {
@@ -56,17 +73,33 @@ func (c *WalletController) CreateWallet(ctx *fiber.Ctx) error {
 // @Summary Restore / Access existing wallet
 // @Description Restore access to an existing wallet using secret phrase and optional passphrase.
 // @Description If the wallet was protected with a passphrase, the correct passphrase must be provided.
+// @Description The optional seed passphrase is the BIP39 "25th word"; it changes the derived keys and is never stored.
+// @Description The phrase is normalized (NFKD, whitespace, case) and checked against the BIP39 wordlists.
+// @Description A malformed phrase is rejected with error code invalid_word_count, unknown_word or invalid_checksum;
+// @Description unknown words come with their position and the closest wordlist entries.
+// @Description With import set, a valid phrase that matches no wallet is stored as a new wallet
+// @Description encrypted under the given passphrase, with the first address of each requested chain.
 // @Description On success, returns wallet identifier and associated blockchain addresses.
 // @Tags Wallet
 // @Accept json
 // @Produce json
 // @Param data body dto.RestoreWalletReq true "Restore wallet payload (secret phrase and optional passphrase)"
 // @Success 200 {object} core.ApiResponse{data=dto.RestoreWalletRes} "Wallet restored successfully"
+// @Success 201 {object} core.ApiResponse{data=dto.RestoreWalletRes} "Wallet imported successfully"
 // @Failure 400 {object} core.ApiResponse "Invalid secret phrase or passphrase"
+// @Failure 409 {object} core.ApiResponse "Wallet already exists"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
 // @Failure 500 {object} core.ApiResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /v1/wallet/restore [post]
 func (ctl *WalletController) RestoreWallet(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
 	var req dto.RestoreWalletReq
 
 	if err := c.BodyParser(&req); err != nil {
@@ -82,7 +115,549 @@ func (ctl *WalletController) RestoreWallet(c *fiber.Ctx) error {
 		)
 	}
 
-	resp, err := ctl.walletService.RestoreWallet(c.Context(), &req)
+	resp, err := ctl.walletService.RestoreWallet(c.Context(), userId, &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ImportWatchOnlyWallet godoc
+// @Summary Import a watch-only wallet
+// @Description Register an account-level extended public key as a wallet without any mnemonic, for auditing and
+// @Description cold-storage monitoring. ETH accepts xpub keys (m/44'/60'/account'); BTC accepts xpub, ypub or zpub
+// @Description (tpub, upub or vpub on test networks), which derive BIP44 legacy, BIP49 P2SH-SegWit or BIP84 native
+// @Description SegWit addresses. Addresses, balances and deposits work as for any wallet, but signing is refused.
+// @Description Private extended keys are rejected.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param data body dto.ImportWatchOnlyWalletReq true "Extended public key to watch"
+// @Success 201 {object} core.ApiResponse{data=dto.WalletRes}
+// @Failure 400 {object} core.ApiResponse "Invalid extended public key"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 409 {object} core.ApiResponse "Wallet already exists"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallet/watch-only [post]
+func (ctl *WalletController) ImportWatchOnlyWallet(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ImportWatchOnlyWalletReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ImportWatchOnlyWallet(c.Context(), userId, &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// GetWallets godoc
+// @Summary List wallets of the current user
+// @Description Return every wallet owned by the authenticated user together with its blockchain addresses.
+// @Tags Wallet
+// @Produce json
+// @Success 200 {object} core.ApiResponse{data=[]dto.WalletRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets [get]
+func (ctl *WalletController) GetWallets(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.GetWallets(c.Context(), userId)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// GetWallet godoc
+// @Summary Get a wallet of the current user
+// @Description Return a single wallet owned by the authenticated user.
+// @Tags Wallet
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} core.ApiResponse{data=dto.WalletRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id} [get]
+func (ctl *WalletController) GetWallet(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.GetWallet(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// DeriveAddress godoc
+// @Summary Derive a new wallet address
+// @Description Derive the next unused address index under the given chain, address type, account and change levels.
+// @Description Bitcoin addresses default to BIP84 native SegWit; BIP49 (p2sh-p2wpkh) and BIP44 (p2pkh) are also supported.
+// @Description The wallet passphrase is required when the wallet was protected with one.
+// @Description Watch-only wallets derive from their extended public key without a passphrase, on its chain, address
+// @Description type and account only.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.DeriveAddressReq true "Derive address payload"
+// @Success 201 {object} core.ApiResponse{data=dto.AddressRes}
+// @Failure 400 {object} core.ApiResponse "Invalid request or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/addresses [post]
+func (ctl *WalletController) DeriveAddress(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.DeriveAddressReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.DeriveAddress(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ChangePassphrase godoc
+// @Summary Change or add the wallet passphrase
+// @Description Verify the current passphrase, then re-encrypt the secret phrase under the new one.
+// @Description Leave current_passphrase empty to add a passphrase to a wallet created without one.
+// @Description Every attempt, successful or not, is written to the audit log.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.ChangePassphraseReq true "Change passphrase payload"
+// @Success 200 {object} core.ApiResponse "Passphrase changed"
+// @Failure 400 {object} core.ApiResponse "Invalid request or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/passphrase [put]
+func (ctl *WalletController) ChangePassphrase(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ChangePassphraseReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ChangePassphrase(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// SignMessage godoc
+// @Summary Sign an EIP-191 personal message
+// @Description Sign a message with personal_sign semantics ("\x19Ethereum Signed Message:\n" + length prefix)
+// @Description using the key of one of the wallet's Ethereum addresses. Set encoding to hex for binary messages.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SignMessageReq true "Message to sign"
+// @Success 200 {object} core.ApiResponse{data=dto.MessageSignatureRes}
+// @Failure 400 {object} core.ApiResponse "Invalid message or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/sign/message [post]
+func (ctl *WalletController) SignMessage(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SignMessageReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SignMessage(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// SignTypedData godoc
+// @Summary Sign EIP-712 typed data
+// @Description Sign typed data with eth_signTypedData_v4 semantics using the key of one of the wallet's Ethereum addresses.
+// @Description When the domain sets chainId it must match the wallet's Ethereum network.
+// @Description With withdrawal approvals on, permits above the approval threshold and any other typed data need an
+// @Description approved withdrawal request of kind typed_data.
+// @Description With the withdrawal allowlist on, the permit spender, or else the verifying contract and any spender the
+// @Description message names, must be on it; typed data naming neither is refused.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SignTypedDataReq true "Typed data to sign"
+// @Success 200 {object} core.ApiResponse{data=dto.MessageSignatureRes}
+// @Failure 400 {object} core.ApiResponse "Invalid typed data or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/sign/typed-data [post]
+func (ctl *WalletController) SignTypedData(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SignTypedDataReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SignTypedData(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// VerifySignature godoc
+// @Summary Verify an Ethereum message signature
+// @Description Recover the signer of a personal message or EIP-712 typed data signature and compare it with the claimed address.
+// @Tags Signature
+// @Accept json
+// @Produce json
+// @Param data body dto.VerifySignatureReq true "Signature to verify"
+// @Success 200 {object} core.ApiResponse{data=dto.VerifySignatureRes}
+// @Failure 400 {object} core.ApiResponse "Invalid request or signature"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Router /v1/signatures/verify [post]
+func (ctl *WalletController) VerifySignature(c *fiber.Ctx) error {
+	var req dto.VerifySignatureReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.VerifySignature(c.Context(), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ReleaseNonce godoc
+// @Summary Release a reserved nonce
+// @Description Give back a nonce reserved while signing (reserve_nonce) when the transaction will not be
+// @Description broadcast, so it is handed out again before any higher nonce.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.ReleaseNonceReq true "Nonce to release"
+// @Success 200 {object} core.ApiResponse
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 409 {object} core.ApiResponse "Nonce is not reserved"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 503 {object} core.ApiResponse "No nonce allocator for the wallet's network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/eth/nonces/release [post]
+func (ctl *WalletController) ReleaseNonce(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ReleaseNonceReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ReleaseNonce(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// CommitNonce godoc
+// @Summary Commit a reserved nonce
+// @Description End the lease of a nonce reserved while signing (reserve_nonce) once the transaction was
+// @Description broadcast, so the nonce is not handed out again when the lease runs out.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.CommitNonceReq true "Nonce to commit"
+// @Success 200 {object} core.ApiResponse
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 409 {object} core.ApiResponse "Nonce is not reserved"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 503 {object} core.ApiResponse "No nonce allocator for the wallet's network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/eth/nonces/commit [post]
+func (ctl *WalletController) CommitNonce(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.CommitNonceReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.CommitNonce(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ResyncNonce godoc
+// @Summary Resync an address's nonces
+// @Description Realign the nonce allocator of an address with the chain's pending nonce and return the next
+// @Description nonce to hand out. With no reservation outstanding, nonces that never reached the node are reused.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.ResyncNonceReq true "Address to resync"
+// @Success 200 {object} core.ApiResponse{data=dto.NonceRes}
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 503 {object} core.ApiResponse "No nonce allocator for the wallet's network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/eth/nonces/resync [post]
+func (ctl *WalletController) ResyncNonce(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ResyncNonceReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ResyncNonce(c.Context(), userId, c.Params("id"), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// SignBtcPSBT godoc
+// @Summary Sign a Bitcoin PSBT
+// @Description Co-sign a base64 BIP174 PSBT. Native SegWit (P2WPKH) inputs whose BIP32 derivation names this
+// @Description wallet's master fingerprint and one of its addresses are signed; other inputs are left untouched.
+// @Description With finalize set, complete inputs are finalized and the raw transaction is extracted once all are.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SignBtcPSBTReq true "PSBT to sign"
+// @Success 200 {object} core.ApiResponse{data=dto.SignedPSBTRes}
+// @Failure 400 {object} core.ApiResponse "Invalid PSBT, no matching inputs or invalid passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/btc/psbt/sign [post]
+func (ctl *WalletController) SignBtcPSBT(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SignBtcPSBTReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SignBtcPSBT(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
 	if err != nil {
 		return c.Status(500).JSON(
 			core.Error(500, "internal error", err.Error(), nil),
@@ -91,3 +666,132 @@ func (ctl *WalletController) RestoreWallet(c *fiber.Ctx) error {
 
 	return c.Status(resp.Code).JSON(resp)
 }
+
+// maxUserAgentLength matches the AuditEvents.UserAgent column.
+const maxUserAgentLength = 512
+
+// SignEthTransaction godoc
+// @Summary Sign an Ethereum transaction offline
+// @Description Sign a legacy (gas_price) or EIP-1559 (max_fee_per_gas, max_priority_fee_per_gas) transaction
+// @Description with the key of one of the wallet's Ethereum addresses. Amounts are wei, as decimal or 0x-hex strings.
+// @Description chain_id must match the wallet's Ethereum network. The transaction is not broadcast.
+// @Description With reserve_nonce, the nonce is leased from the shared allocator instead; commit it once the
+// @Description transaction is broadcast, or release it if the transaction is abandoned.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SignEthTransactionReq true "Transaction to sign"
+// @Success 200 {object} core.ApiResponse{data=dto.SignedTransactionRes}
+// @Failure 400 {object} core.ApiResponse "Invalid transaction or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or address not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 502 {object} core.ApiResponse "Nonce could not be reserved"
+// @Failure 503 {object} core.ApiResponse "No nonce allocator for the wallet's network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/sign/transaction [post]
+func (ctl *WalletController) SignEthTransaction(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SignEthTransactionReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SignEthTransaction(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// SignTokenTransfer godoc
+// @Summary Sign an ERC-20 token transfer offline
+// @Description Build transfer(to, amount) calldata for a registered token on the wallet's Ethereum network and
+// @Description sign it as a transaction from one of the wallet's addresses to the token contract. amount is in the
+// @Description token's base units. Gas, fee and nonce fields are as for signing a transaction. The transaction is not broadcast.
+// @Tags Wallet
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.SignTokenTransferReq true "Token transfer to sign"
+// @Success 200 {object} core.ApiResponse{data=dto.SignedTransactionRes}
+// @Failure 400 {object} core.ApiResponse "Invalid transaction or passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet, address or token not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Failure 502 {object} core.ApiResponse "Nonce could not be reserved"
+// @Failure 503 {object} core.ApiResponse "No nonce allocator for the wallet's network"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/sign/token-transfer [post]
+func (ctl *WalletController) SignTokenTransfer(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.SignTokenTransferReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.SignTokenTransfer(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// requestMeta collects the caller's address and client for audit events.
+func requestMeta(c *fiber.Ctx) dto.RequestMeta {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	return dto.RequestMeta{
+		IpAddress: c.IP(),
+		UserAgent: userAgent,
+	}
+}
+
+// currentUserId returns the ID of the user the request's JWT was issued to.
+func currentUserId(c *fiber.Ctx) (string, error) {
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		return "", err
+	}
+	return claims.UserID.String(), nil
+}
@@ -0,0 +1,282 @@
+package controllers
+
+import (
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateWithdrawal godoc
+// @Summary Request a withdrawal
+// @Description Submit a transaction, token transfer, PSBT or EIP-712 typed data for approval. Transfers and token
+// @Description allowances (approve, increaseAllowance, permits) above the approval threshold
+// @Description (WITHDRAWAL_APPROVAL_THRESHOLDS), or that take the total signed without approval over
+// @Description WITHDRAWAL_APPROVAL_WINDOW above it, contract calls that cannot be decoded and typed data other than
+// @Description permits can only be signed through an approved request. Passphrases are not stored; they are given
+// @Description when the approved request is executed.
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param data body dto.CreateWithdrawalReq true "Transfer to approve"
+// @Success 201 {object} core.ApiResponse{data=dto.WithdrawalRes}
+// @Failure 400 {object} core.ApiResponse "Invalid transfer or approvals not enabled"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet, address or token not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/withdrawals [post]
+func (ctl *WalletController) CreateWithdrawal(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.CreateWithdrawalReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.CreateWithdrawal(c.Context(), userId, c.Params("id"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ListWithdrawals godoc
+// @Summary List withdrawal requests of a wallet
+// @Description Return the wallet's withdrawal requests, newest first, with every approver decision.
+// @Tags Withdrawal
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Success 200 {object} core.ApiResponse{data=[]dto.WithdrawalRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/withdrawals [get]
+func (ctl *WalletController) ListWithdrawals(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ListWithdrawals(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// GetWithdrawal godoc
+// @Summary Get a withdrawal request
+// @Description Return one of the wallet's withdrawal requests with every approver decision.
+// @Tags Withdrawal
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param withdrawalId path string true "Withdrawal request ID"
+// @Success 200 {object} core.ApiResponse{data=dto.WithdrawalRes}
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 404 {object} core.ApiResponse "Wallet or withdrawal not found"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/withdrawals/{withdrawalId} [get]
+func (ctl *WalletController) GetWithdrawal(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.GetWithdrawal(c.Context(), userId, c.Params("id"), c.Params("withdrawalId"))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ExecuteWithdrawal godoc
+// @Summary Execute an approved withdrawal
+// @Description Sign the transfer of an approved withdrawal request. It is signed at most once; the response is the
+// @Description one of the matching signing endpoint.
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param id path string true "Wallet ID"
+// @Param withdrawalId path string true "Withdrawal request ID"
+// @Param data body dto.ExecuteWithdrawalReq true "Wallet passphrases"
+// @Success 200 {object} core.ApiResponse
+// @Failure 400 {object} core.ApiResponse "Invalid passphrase"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Destination not in withdrawal allowlist"
+// @Failure 404 {object} core.ApiResponse "Wallet or withdrawal not found"
+// @Failure 409 {object} core.ApiResponse "Withdrawal not approved"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/wallets/{id}/withdrawals/{withdrawalId}/execute [post]
+func (ctl *WalletController) ExecuteWithdrawal(c *fiber.Ctx) error {
+	userId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.ExecuteWithdrawalReq
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid body", err.Error(), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ExecuteWithdrawal(c.Context(), userId, c.Params("id"), c.Params("withdrawalId"), &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ListPendingWithdrawals godoc
+// @Summary List withdrawal requests awaiting approvers
+// @Description Return withdrawal requests of all wallets in a status (default pending), oldest first. Requires the
+// @Description withdrawal:approve credential.
+// @Tags Withdrawal
+// @Produce json
+// @Param status query string false "Request status" Enums(pending, approved, rejected, expired, executed)
+// @Success 200 {object} core.ApiResponse{data=[]dto.WithdrawalRes}
+// @Failure 400 {object} core.ApiResponse "Invalid status"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/withdrawals [get]
+func (ctl *WalletController) ListPendingWithdrawals(c *fiber.Ctx) error {
+	var req dto.ListWithdrawalsReq
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "invalid query", err.Error(), nil),
+		)
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.ListPendingWithdrawals(c.Context(), &req)
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
+
+// ApproveWithdrawal godoc
+// @Summary Approve a withdrawal request
+// @Description Record an approval. The request is approved once WITHDRAWAL_APPROVALS approvers other than the
+// @Description requester approve it. Requires the withdrawal:approve credential.
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param withdrawalId path string true "Withdrawal request ID"
+// @Param data body dto.DecideWithdrawalReq false "Optional comment"
+// @Success 200 {object} core.ApiResponse{data=dto.WithdrawalRes}
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied or own request"
+// @Failure 404 {object} core.ApiResponse "Withdrawal not found"
+// @Failure 409 {object} core.ApiResponse "Withdrawal not pending or already decided"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/withdrawals/{withdrawalId}/approve [post]
+func (ctl *WalletController) ApproveWithdrawal(c *fiber.Ctx) error {
+	return ctl.decideWithdrawal(c, models.WithdrawalDecisionApproved)
+}
+
+// RejectWithdrawal godoc
+// @Summary Reject a withdrawal request
+// @Description Record a rejection, which rejects the request. Requires the withdrawal:approve credential.
+// @Tags Withdrawal
+// @Accept json
+// @Produce json
+// @Param withdrawalId path string true "Withdrawal request ID"
+// @Param data body dto.DecideWithdrawalReq false "Optional reason"
+// @Success 200 {object} core.ApiResponse{data=dto.WithdrawalRes}
+// @Failure 400 {object} core.ApiResponse "Invalid body"
+// @Failure 401 {object} core.ApiResponse "Unauthorized"
+// @Failure 403 {object} core.ApiResponse "Permission denied or own request"
+// @Failure 404 {object} core.ApiResponse "Withdrawal not found"
+// @Failure 409 {object} core.ApiResponse "Withdrawal not pending or already decided"
+// @Failure 500 {object} core.ApiResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /v1/withdrawals/{withdrawalId}/reject [post]
+func (ctl *WalletController) RejectWithdrawal(c *fiber.Ctx) error {
+	return ctl.decideWithdrawal(c, models.WithdrawalDecisionRejected)
+}
+
+func (ctl *WalletController) decideWithdrawal(c *fiber.Ctx, decision string) error {
+	approverId, err := currentUserId(c)
+	if err != nil {
+		return c.Status(401).JSON(
+			core.Error(401, "unauthorized", err.Error(), nil),
+		)
+	}
+
+	var req dto.DecideWithdrawalReq
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(400).JSON(
+				core.Error(400, "invalid body", err.Error(), nil),
+			)
+		}
+	}
+
+	validate := utils.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return c.Status(400).JSON(
+			core.Error(400, "validation error", utils.ValidatorErrors(err), nil),
+		)
+	}
+
+	resp, err := ctl.walletService.DecideWithdrawal(c.Context(), approverId, c.Params("withdrawalId"), decision, &req, requestMeta(c))
+	if err != nil {
+		return c.Status(500).JSON(
+			core.Error(500, "internal error", err.Error(), nil),
+		)
+	}
+
+	return c.Status(resp.Code).JSON(resp)
+}
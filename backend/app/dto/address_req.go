@@ -0,0 +1,10 @@
+package dto
+
+type DeriveAddressReq struct {
+	Passphrase     string `json:"passphrase,omitempty"`
+	SeedPassphrase string `json:"seed_passphrase,omitempty"`
+	Chain          string `json:"chain,omitempty" validate:"omitempty,oneof=ETH BTC"`
+	AddressType    string `json:"address_type,omitempty" validate:"omitempty,oneof=p2wpkh p2sh-p2wpkh p2pkh"`
+	Account        uint32 `json:"account" validate:"lt=2147483648"`
+	Change         uint32 `json:"change" validate:"oneof=0 1"`
+}
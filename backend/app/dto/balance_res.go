@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/create-go-app/fiber-go-template/pkg/amount"
+
+type BalanceRes struct {
+	Chain            string     `json:"chain"`
+	Network          string     `json:"network"`
+	Address          string     `json:"address"`
+	Asset            string     `json:"asset"`
+	ContractAddress  string     `json:"contract_address,omitempty"`
+	Decimals         uint8      `json:"decimals"`
+	Balance          amount.Int `json:"balance" swaggertype:"string" example:"1500000000000000000"`
+	BalanceFormatted string     `json:"balance_formatted" example:"1.5"`
+}
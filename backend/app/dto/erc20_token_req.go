@@ -0,0 +1,36 @@
+package dto
+
+// CreateErc20TokenReq registers an ERC-20 contract on an Ethereum network.
+// Decimals must match the contract's decimals().
+type CreateErc20TokenReq struct {
+	Network         string `json:"network" validate:"required,oneof=mainnet sepolia holesky dev"`
+	ContractAddress string `json:"contract_address" validate:"required,eth_addr"`
+	Symbol          string `json:"symbol" validate:"required,max=32"`
+	Name            string `json:"name" validate:"required,max=128"`
+	Decimals        *uint8 `json:"decimals" validate:"required,max=77"`
+}
+
+// ListErc20TokensReq filters the registry by network.
+type ListErc20TokensReq struct {
+	Network string `query:"network" validate:"omitempty,oneof=mainnet sepolia holesky dev"`
+}
+
+// SignTokenTransferReq builds and signs an ERC-20 transfer(to, amount) call
+// from one of the wallet's addresses. Token is the symbol of a registered
+// token on the wallet's network; Amount is in the token's base units. Gas
+// and fee fields are as in [SignEthTransactionReq].
+type SignTokenTransferReq struct {
+	Passphrase           string `json:"passphrase,omitempty"`
+	SeedPassphrase       string `json:"seed_passphrase,omitempty"`
+	From                 string `json:"from" validate:"required,eth_addr"`
+	Token                string `json:"token" validate:"required,max=32"`
+	To                   string `json:"to" validate:"required,eth_addr"`
+	Amount               string `json:"amount" validate:"required,uint256"`
+	ChainId              uint64 `json:"chain_id" validate:"required"`
+	Nonce                uint64 `json:"nonce"`
+	ReserveNonce         bool   `json:"reserve_nonce,omitempty"`
+	Gas                  uint64 `json:"gas" validate:"required"`
+	GasPrice             string `json:"gas_price,omitempty" validate:"omitempty,uint256"`
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty" validate:"omitempty,uint256"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty" validate:"omitempty,uint256"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type Erc20TokenRes struct {
+	TokenId         string    `json:"token_id"`
+	Chain           string    `json:"chain"`
+	Network         string    `json:"network"`
+	ContractAddress string    `json:"contract_address"`
+	Symbol          string    `json:"symbol"`
+	Name            string    `json:"name"`
+	Decimals        uint8     `json:"decimals"`
+	CreateDate      time.Time `json:"create_date"`
+}
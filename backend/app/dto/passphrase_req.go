@@ -0,0 +1,7 @@
+package dto
+
+type ChangePassphraseReq struct {
+	// CurrentPassphrase is empty when the wallet has no passphrase yet.
+	CurrentPassphrase string `json:"current_passphrase,omitempty"`
+	NewPassphrase     string `json:"new_passphrase" validate:"required,max=256"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// RequestMeta identifies where a request came from, for audit events.
+type RequestMeta struct {
+	IpAddress string
+	UserAgent string
+}
@@ -1,7 +1,14 @@
 package dto
 
 type RestoreWalletReq struct {
-	WalletName   string `json:"wallet_name,omitempty"`
-	SecretPhrase string `json:"secret_phrase" validate:"required"`
-	Passphrase   string `json:"passphrase,omitempty"`
+	WalletName     string `json:"wallet_name,omitempty"`
+	SecretPhrase   string `json:"secret_phrase" validate:"required"`
+	Passphrase     string `json:"passphrase,omitempty"`
+	SeedPassphrase string `json:"seed_passphrase,omitempty"`
+
+	// Import creates a new wallet when no existing wallet matches the phrase.
+	Import     bool     `json:"import,omitempty"`
+	Chains     []string `json:"chains,omitempty" validate:"omitempty,unique,dive,oneof=ETH BTC"`
+	EthNetwork string   `json:"eth_network,omitempty" validate:"omitempty,oneof=mainnet sepolia holesky dev"`
+	BtcNetwork string   `json:"btc_network,omitempty" validate:"omitempty,oneof=mainnet testnet signet regtest"`
 }
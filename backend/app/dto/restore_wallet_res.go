@@ -4,4 +4,5 @@ package dto
 type RestoreWalletRes struct {
 	WalletId  string   `json:"wallet_id"`
 	Addresses []string `json:"addresses"`
+	Imported  bool     `json:"imported"`
 }
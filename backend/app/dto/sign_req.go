@@ -0,0 +1,84 @@
+package dto
+
+import "encoding/json"
+
+// SignEthTransactionReq describes an Ethereum transaction to sign offline.
+// Set GasPrice for a legacy transaction, or MaxFeePerGas and
+// MaxPriorityFeePerGas for an EIP-1559 one. Amounts are in wei, as decimal
+// or 0x-prefixed hex strings. An empty To deploys a contract. With
+// ReserveNonce set, Nonce is ignored and the next free nonce of From is
+// leased from the shared allocator instead.
+type SignEthTransactionReq struct {
+	Passphrase           string `json:"passphrase,omitempty"`
+	SeedPassphrase       string `json:"seed_passphrase,omitempty"`
+	From                 string `json:"from" validate:"required,eth_addr"`
+	ChainId              uint64 `json:"chain_id" validate:"required"`
+	Nonce                uint64 `json:"nonce"`
+	ReserveNonce         bool   `json:"reserve_nonce,omitempty"`
+	To                   string `json:"to,omitempty" validate:"omitempty,eth_addr"`
+	Value                string `json:"value,omitempty" validate:"omitempty,uint256"`
+	Gas                  uint64 `json:"gas" validate:"required"`
+	GasPrice             string `json:"gas_price,omitempty" validate:"omitempty,uint256"`
+	MaxFeePerGas         string `json:"max_fee_per_gas,omitempty" validate:"omitempty,uint256"`
+	MaxPriorityFeePerGas string `json:"max_priority_fee_per_gas,omitempty" validate:"omitempty,uint256"`
+	Data                 string `json:"data,omitempty" validate:"omitempty,hexadecimal"`
+}
+
+// SignMessageReq is an EIP-191 personal_sign request. With encoding "hex"
+// the message is 0x-prefixed bytes; otherwise it is signed as UTF-8 text.
+type SignMessageReq struct {
+	Passphrase     string `json:"passphrase,omitempty"`
+	SeedPassphrase string `json:"seed_passphrase,omitempty"`
+	Address        string `json:"address" validate:"required,eth_addr"`
+	Message        string `json:"message" validate:"required"`
+	Encoding       string `json:"encoding,omitempty" validate:"omitempty,oneof=utf8 hex"`
+}
+
+// SignTypedDataReq is an EIP-712 eth_signTypedData_v4 request.
+type SignTypedDataReq struct {
+	Passphrase     string          `json:"passphrase,omitempty"`
+	SeedPassphrase string          `json:"seed_passphrase,omitempty"`
+	Address        string          `json:"address" validate:"required,eth_addr"`
+	TypedData      json.RawMessage `json:"typed_data" validate:"required" swaggertype:"object"`
+}
+
+// VerifySignatureReq checks that Signature was made by Address over either
+// a personal message or EIP-712 typed data.
+type VerifySignatureReq struct {
+	Type      string          `json:"type" validate:"required,oneof=personal typed_data"`
+	Address   string          `json:"address" validate:"required,eth_addr"`
+	Signature string          `json:"signature" validate:"required,hexadecimal"`
+	Message   string          `json:"message,omitempty" validate:"required_if=Type personal"`
+	Encoding  string          `json:"encoding,omitempty" validate:"omitempty,oneof=utf8 hex"`
+	TypedData json.RawMessage `json:"typed_data,omitempty" validate:"required_if=Type typed_data" swaggertype:"object"`
+}
+
+// SignBtcPSBTReq is a base64 BIP174 PSBT to co-sign. Only native SegWit
+// inputs whose BIP32 derivation points at one of the wallet's addresses
+// are signed. With Finalize set, complete inputs are finalized and, once
+// all of them are, the raw transaction is returned as well.
+type SignBtcPSBTReq struct {
+	Passphrase     string `json:"passphrase,omitempty"`
+	SeedPassphrase string `json:"seed_passphrase,omitempty"`
+	Psbt           string `json:"psbt" validate:"required,base64"`
+	Finalize       bool   `json:"finalize,omitempty"`
+}
+
+// ReleaseNonceReq gives back a reserved nonce of Address whose transaction
+// will not be broadcast.
+type ReleaseNonceReq struct {
+	Address string  `json:"address" validate:"required,eth_addr"`
+	Nonce   *uint64 `json:"nonce" validate:"required"`
+}
+
+// CommitNonceReq ends the lease of a reserved nonce of Address once its
+// transaction was broadcast.
+type CommitNonceReq struct {
+	Address string  `json:"address" validate:"required,eth_addr"`
+	Nonce   *uint64 `json:"nonce" validate:"required"`
+}
+
+// ResyncNonceReq realigns the nonce allocator of Address with the chain.
+type ResyncNonceReq struct {
+	Address string `json:"address" validate:"required,eth_addr"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+type SignedTransactionRes struct {
+	From           string `json:"from"`
+	Nonce          uint64 `json:"nonce"`
+	Hash           string `json:"hash"`
+	RawTransaction string `json:"raw_transaction"`
+	Type           string `json:"type"`
+}
+
+type MessageSignatureRes struct {
+	Address   string `json:"address"`
+	Hash      string `json:"hash"`
+	Signature string `json:"signature"`
+}
+
+type VerifySignatureRes struct {
+	Valid  bool   `json:"valid"`
+	Signer string `json:"signer"`
+}
+
+type SignedPSBTRes struct {
+	Psbt           string `json:"psbt"`
+	SignedInputs   []int  `json:"signed_inputs"`
+	Complete       bool   `json:"complete"`
+	RawTransaction string `json:"raw_transaction,omitempty"`
+	TxId           string `json:"txid,omitempty"`
+}
+
+type NonceRes struct {
+	Address   string `json:"address"`
+	NextNonce uint64 `json:"next_nonce"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// SpendingPolicyLimitsReq are the limits of a spending policy. Amounts are
+// in base units of the policy's asset; leaving one out switches it off.
+// Signing is allowed from AllowedFromHour up to, not including,
+// AllowedToHour in Timezone (default UTC); the window may wrap past
+// midnight.
+type SpendingPolicyLimitsReq struct {
+	MaxPerTransaction string `json:"max_per_transaction,omitempty" validate:"omitempty,uint256"`
+	DailyLimit        string `json:"daily_limit,omitempty" validate:"omitempty,uint256"`
+	WeeklyLimit       string `json:"weekly_limit,omitempty" validate:"omitempty,uint256"`
+	AllowedFromHour   *int   `json:"allowed_from_hour,omitempty" validate:"required_with=AllowedToHour,omitempty,min=0,max=23"`
+	AllowedToHour     *int   `json:"allowed_to_hour,omitempty" validate:"required_with=AllowedFromHour,omitempty,min=0,max=23"`
+	Timezone          string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// CreateSpendingPolicyReq sets limits for one of a user's wallets, or for
+// all of them together when WalletId is empty. Asset is a symbol such as
+// ETH, BTC or a registered token; "*" covers every signing request and
+// only takes allowed hours.
+type CreateSpendingPolicyReq struct {
+	UserId   string `json:"user_id" validate:"required,max=128"`
+	WalletId string `json:"wallet_id,omitempty" validate:"omitempty,max=128"`
+	Asset    string `json:"asset" validate:"required,max=32"`
+	SpendingPolicyLimitsReq
+}
+
+// UpdateSpendingPolicyReq replaces the limits of a policy.
+type UpdateSpendingPolicyReq struct {
+	SpendingPolicyLimitsReq
+}
+
+// ListSpendingPoliciesReq selects the user whose policies are listed.
+type ListSpendingPoliciesReq struct {
+	UserId string `query:"user_id" validate:"required,max=128"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+// SpendingPolicyRes is a spending policy. Scope is "wallet" for a policy
+// of one wallet and "user" for one over all of the user's wallets.
+type SpendingPolicyRes struct {
+	SpendingPolicyId  string      `json:"spending_policy_id"`
+	UserId            string      `json:"user_id"`
+	WalletId          *string     `json:"wallet_id,omitempty"`
+	Scope             string      `json:"scope"`
+	Asset             string      `json:"asset"`
+	MaxPerTransaction *amount.Int `json:"max_per_transaction,omitempty" swaggertype:"string"`
+	DailyLimit        *amount.Int `json:"daily_limit,omitempty" swaggertype:"string"`
+	WeeklyLimit       *amount.Int `json:"weekly_limit,omitempty" swaggertype:"string"`
+	AllowedFromHour   *int        `json:"allowed_from_hour,omitempty"`
+	AllowedToHour     *int        `json:"allowed_to_hour,omitempty"`
+	Timezone          string      `json:"timezone"`
+	CreateDate        time.Time   `json:"create_date"`
+	UpdateDate        time.Time   `json:"update_date"`
+}
+
+// SpendingPolicyViolationRes explains which rule of which policy refused
+// a signing request. Rule is one of max_per_transaction, daily_limit,
+// weekly_limit, allowed_hours or unknown_asset, the last for a token
+// missing from the registry or a call that cannot be decoded while amounts
+// are limited. For the rolling caps, Used is what was already signed for
+// within Window; for allowed hours the window is given instead.
+type SpendingPolicyViolationRes struct {
+	Rule             string      `json:"rule"`
+	SpendingPolicyId string      `json:"spending_policy_id"`
+	Scope            string      `json:"scope"`
+	Asset            string      `json:"asset"`
+	Amount           *amount.Int `json:"amount,omitempty" swaggertype:"string"`
+	Limit            *amount.Int `json:"limit,omitempty" swaggertype:"string"`
+	Used             *amount.Int `json:"used,omitempty" swaggertype:"string"`
+	Window           string      `json:"window,omitempty"`
+	AllowedFromHour  *int        `json:"allowed_from_hour,omitempty"`
+	AllowedToHour    *int        `json:"allowed_to_hour,omitempty"`
+	Timezone         string      `json:"timezone,omitempty"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "time"
+
+// RecordTransactionReq adds a transaction to a wallet's ledger. For an
+// outgoing transaction FromAddress must belong to the wallet, for an
+// incoming one ToAddress. Amount is an integer in the asset's base units
+// (wei, satoshi); Asset defaults to the chain's native currency and may
+// name an ERC-20 token registered on the wallet's network. Status is
+// pending by default, or broadcast for a transaction already sent, which
+// needs its TxHash; later states are reached through status updates.
+type RecordTransactionReq struct {
+	Chain           string     `json:"chain" validate:"required,oneof=ETH BTC"`
+	Direction       string     `json:"direction" validate:"required,oneof=incoming outgoing"`
+	FromAddress     string     `json:"from_address" validate:"required,max=128"`
+	ToAddress       string     `json:"to_address" validate:"required,max=128"`
+	Amount          string     `json:"amount" validate:"required,uint256"`
+	Asset           string     `json:"asset,omitempty" validate:"omitempty,max=32"`
+	TxHash          string     `json:"tx_hash,omitempty" validate:"omitempty,max=128"`
+	Status          string     `json:"status,omitempty" validate:"omitempty,oneof=pending broadcast"`
+	TransactionDate *time.Time `json:"transaction_date,omitempty"`
+}
+
+// ListTransactionsReq holds the query parameters of the ledger listing.
+// From and To are inclusive RFC 3339 timestamps; Cursor is the
+// next_cursor of the previous page.
+type ListTransactionsReq struct {
+	Status    string `query:"status" validate:"omitempty,oneof=pending broadcast confirmed failed replaced"`
+	Direction string `query:"direction" validate:"omitempty,oneof=incoming outgoing"`
+	Address   string `query:"address" validate:"omitempty,max=128"`
+	From      string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Cursor    string `query:"cursor" validate:"omitempty,max=256"`
+	Limit     int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// UpdateTransactionStatusReq moves a transaction along its lifecycle:
+// pending → broadcast → confirmed, failed or replaced. A pending
+// transaction may also fail before it is broadcast.
+type UpdateTransactionStatusReq struct {
+	Status string `json:"status" validate:"required,oneof=pending broadcast confirmed failed replaced"`
+	TxHash string `json:"tx_hash,omitempty" validate:"omitempty,max=128"`
+}
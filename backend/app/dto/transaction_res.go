@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+type TransactionRes struct {
+	TransactionId   string     `json:"transaction_id"`
+	WalletId        string     `json:"wallet_id"`
+	Chain           string     `json:"chain"`
+	Direction       string     `json:"direction"`
+	FromAddress     string     `json:"from_address"`
+	ToAddress       string     `json:"to_address"`
+	Amount          amount.Int `json:"amount" swaggertype:"string" example:"1500000000000000000"`
+	Asset           string     `json:"asset"`
+	Decimals        uint8      `json:"decimals"`
+	ContractAddress string     `json:"contract_address,omitempty"`
+	AmountFormatted string     `json:"amount_formatted" example:"1.5"`
+	TxHash          string     `json:"tx_hash,omitempty"`
+	Status          string     `json:"status"`
+	TransactionDate time.Time  `json:"transaction_date"`
+	CreateDate      time.Time  `json:"create_date"`
+	UpdateDate      time.Time  `json:"update_date"`
+}
+
+// CursorMeta describes a page of a cursor-paginated list. NextCursor is
+// empty on the last page.
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
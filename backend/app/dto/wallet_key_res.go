@@ -0,0 +1,10 @@
+package dto
+
+// RewrapWalletsRes summarizes a KEK re-wrap run.
+type RewrapWalletsRes struct {
+	KekId     string `json:"kek_id"`
+	Rewrapped int    `json:"rewrapped"`
+	Upgraded  int    `json:"upgraded"`
+	Pending   int    `json:"pending"`
+	Failed    int    `json:"failed"`
+}
@@ -1,6 +1,12 @@
 package dto
 
 type CreateWalletReq struct {
-	WalletName string `json:"wallet_name" validate:"required,min=3,max=50"`
-	Passphrase string `json:"passphrase,omitempty"`
+	WalletName     string   `json:"wallet_name" validate:"required,min=3,max=50"`
+	Passphrase     string   `json:"passphrase,omitempty"`
+	SeedPassphrase string   `json:"seed_passphrase,omitempty"`
+	WordCount      int      `json:"word_count,omitempty" validate:"omitempty,oneof=12 15 18 21 24"`
+	Language       string   `json:"language,omitempty" validate:"omitempty,oneof=english japanese spanish french italian korean chinese_simplified chinese_traditional czech"`
+	Chains         []string `json:"chains,omitempty" validate:"omitempty,unique,dive,oneof=ETH BTC"`
+	EthNetwork     string   `json:"eth_network,omitempty" validate:"omitempty,oneof=mainnet sepolia holesky dev"`
+	BtcNetwork     string   `json:"btc_network,omitempty" validate:"omitempty,oneof=mainnet testnet signet regtest"`
 }
@@ -1,7 +1,34 @@
 package dto
 
+import "time"
+
 type CreateWalletRes struct {
-	WalletId     string `json:"wallet_id"`
-	Address      string `json:"address"`
+	WalletId     string       `json:"wallet_id"`
+	Address      string       `json:"address"`
+	Addresses    []AddressRes `json:"addresses"`
 	SecretPhrase string
 }
+
+type AddressRes struct {
+	AddressId      string    `json:"address_id"`
+	Address        string    `json:"address"`
+	Chain          string    `json:"chain"`
+	DerivationPath string    `json:"derivation_path"`
+	Account        uint32    `json:"account"`
+	Change         uint32    `json:"change"`
+	Index          uint32    `json:"index"`
+	CreateDate     time.Time `json:"create_date"`
+}
+
+type WalletRes struct {
+	WalletId          string       `json:"wallet_id"`
+	WalletName        string       `json:"wallet_name"`
+	EthNetwork        string       `json:"eth_network"`
+	BtcNetwork        string       `json:"btc_network"`
+	HasSeedPassphrase bool         `json:"has_seed_passphrase"`
+	WatchOnly         bool         `json:"watch_only"`
+	ExtendedPublicKey string       `json:"extended_public_key,omitempty"`
+	Addresses         []AddressRes `json:"addresses"`
+	CreateDate        time.Time    `json:"create_date"`
+	UpdateDate        time.Time    `json:"update_date"`
+}
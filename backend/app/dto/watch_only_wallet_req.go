@@ -0,0 +1,11 @@
+package dto
+
+// ImportWatchOnlyWalletReq registers an account-level extended public key
+// (xpub/ypub/zpub, or tpub/upub/vpub on Bitcoin test networks) of one chain.
+type ImportWatchOnlyWalletReq struct {
+	WalletName        string `json:"wallet_name" validate:"required,min=3,max=50"`
+	ExtendedPublicKey string `json:"extended_public_key" validate:"required,max=200"`
+	Chain             string `json:"chain" validate:"required,oneof=ETH BTC"`
+	EthNetwork        string `json:"eth_network,omitempty" validate:"omitempty,oneof=mainnet sepolia holesky dev"`
+	BtcNetwork        string `json:"btc_network,omitempty" validate:"omitempty,oneof=mainnet testnet signet regtest"`
+}
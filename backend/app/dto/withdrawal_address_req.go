@@ -0,0 +1,18 @@
+package dto
+
+// AddWithdrawalAddressReq adds Address on Chain to the wallet's address
+// book of approved destinations. The wallet passphrase confirms the change.
+type AddWithdrawalAddressReq struct {
+	Passphrase string `json:"passphrase,omitempty"`
+	Chain      string `json:"chain" validate:"required,oneof=ETH BTC"`
+	Address    string `json:"address" validate:"required,max=128"`
+	Label      string `json:"label" validate:"required,max=128"`
+}
+
+// SetAllowlistModeReq switches the wallet's allowlist mode. Switching it
+// on is immediate; switching it off waits for the cooling-off period and
+// needs the wallet passphrase.
+type SetAllowlistModeReq struct {
+	Passphrase string `json:"passphrase,omitempty"`
+	Enabled    *bool  `json:"enabled" validate:"required"`
+}
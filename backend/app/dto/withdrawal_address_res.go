@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type WithdrawalAddressRes struct {
+	WithdrawalAddressId string    `json:"withdrawal_address_id"`
+	Chain               string    `json:"chain"`
+	Address             string    `json:"address"`
+	Label               string    `json:"label"`
+	Active              bool      `json:"active"`
+	ActiveDate          time.Time `json:"active_date"`
+	CreateDate          time.Time `json:"create_date"`
+}
+
+// WithdrawalAllowlistRes is a wallet's address book. Enabled tells whether
+// payouts are limited to active addresses right now; DisableDate is set
+// while a switch-off is pending.
+type WithdrawalAllowlistRes struct {
+	Enabled     bool                   `json:"enabled"`
+	DisableDate *time.Time             `json:"disable_date,omitempty"`
+	Addresses   []WithdrawalAddressRes `json:"addresses"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+// CreateWithdrawalReq submits an outgoing transfer for approval. Kind
+// selects which of EthTransaction, TokenTransfer, Psbt or TypedData is
+// signed once the request is approved and executed. Passphrases inside them are ignored:
+// they are given when executing.
+type CreateWithdrawalReq struct {
+	Kind           string                 `json:"kind" validate:"required,oneof=eth_transaction token_transfer btc_psbt typed_data"`
+	EthTransaction *SignEthTransactionReq `json:"eth_transaction,omitempty" validate:"required_if=Kind eth_transaction"`
+	TokenTransfer  *SignTokenTransferReq  `json:"token_transfer,omitempty" validate:"required_if=Kind token_transfer"`
+	Psbt           *SignBtcPSBTReq        `json:"psbt,omitempty" validate:"required_if=Kind btc_psbt"`
+	TypedData      *SignTypedDataReq      `json:"typed_data,omitempty" validate:"required_if=Kind typed_data"`
+}
+
+// ExecuteWithdrawalReq unlocks the wallet to sign an approved withdrawal.
+type ExecuteWithdrawalReq struct {
+	Passphrase     string `json:"passphrase,omitempty"`
+	SeedPassphrase string `json:"seed_passphrase,omitempty"`
+}
+
+// DecideWithdrawalReq records an approver's decision with an optional
+// reason.
+type DecideWithdrawalReq struct {
+	Comment string `json:"comment,omitempty" validate:"max=512"`
+}
+
+// ListWithdrawalsReq holds the query parameters of the approvers' queue.
+// Status defaults to pending.
+type ListWithdrawalsReq struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected expired executed"`
+}
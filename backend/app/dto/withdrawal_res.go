@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+type WithdrawalDecisionRes struct {
+	ApproverId string    `json:"approver_id"`
+	Decision   string    `json:"decision"`
+	Comment    string    `json:"comment,omitempty"`
+	CreateDate time.Time `json:"create_date"`
+}
+
+// WithdrawalRes is a withdrawal request. Amount is in the asset's base
+// units; Destination lists the receiving addresses, comma separated.
+type WithdrawalRes struct {
+	WithdrawalId      string                  `json:"withdrawal_id"`
+	WalletId          string                  `json:"wallet_id"`
+	RequesterId       string                  `json:"requester_id"`
+	Kind              string                  `json:"kind"`
+	Chain             string                  `json:"chain"`
+	Asset             string                  `json:"asset"`
+	Decimals          uint8                   `json:"decimals"`
+	Amount            amount.Int              `json:"amount" swaggertype:"string" example:"1500000000000000000"`
+	Destination       string                  `json:"destination"`
+	Status            string                  `json:"status"`
+	RequiredApprovals int                     `json:"required_approvals"`
+	Approvals         int                     `json:"approvals"`
+	Decisions         []WithdrawalDecisionRes `json:"decisions"`
+	ExpireDate        time.Time               `json:"expire_date"`
+	TxHash            string                  `json:"tx_hash,omitempty"`
+	CreateDate        time.Time               `json:"create_date"`
+	UpdateDate        time.Time               `json:"update_date"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AuditEvent đại diện bảng "AuditEvents"
+type AuditEvent struct {
+	AuditEventId string    `gorm:"column:AuditEventId;primaryKey;type:varchar(128);not null"`
+	UserId       string    `gorm:"column:UserId;type:varchar(128);not null;index"`
+	WalletId     string    `gorm:"column:WalletId;type:varchar(128);default:null;index"`
+	Action       string    `gorm:"column:Action;type:varchar(64);not null"`
+	IpAddress    string    `gorm:"column:IpAddress;type:varchar(64)"`
+	UserAgent    string    `gorm:"column:UserAgent;type:varchar(512)"`
+	Metadata     string    `gorm:"column:Metadata;type:text"`
+	CreateDate   time.Time `gorm:"column:CreateDate;type:timestamptz"`
+}
+
+func (AuditEvent) TableName() string {
+	return "AuditEvents"
+}
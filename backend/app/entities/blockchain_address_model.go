@@ -5,11 +5,17 @@ import (
 )
 
 type BlockchainAddress struct {
-	AddressId  string    `gorm:"column:AddressId;primaryKey;type:varchar(128);not null"`
-	WalletId   string    `gorm:"column:WalletId;type:varchar(128);not null"`
-	Address    string    `gorm:"column:Address;type:varchar(128);not null"`
-	CreateDate time.Time `gorm:"column:CreateDate;type:timestamptz"`
-	UpdateDate time.Time `gorm:"column:UpdateDate;type:timestamptz"`
+	AddressId      string    `gorm:"column:AddressId;primaryKey;type:varchar(128);not null"`
+	WalletId       string    `gorm:"column:WalletId;type:varchar(128);not null;uniqueIndex:idx_BlockchainAddresses_WalletId_DerivationPath,priority:1"`
+	Address        string    `gorm:"column:Address;type:varchar(128);not null"`
+	Chain          string    `gorm:"column:Chain;type:varchar(16);not null"`
+	DerivationPath string    `gorm:"column:DerivationPath;type:varchar(64);not null;uniqueIndex:idx_BlockchainAddresses_WalletId_DerivationPath,priority:2"`
+	Purpose        uint32    `gorm:"column:Purpose;type:int;not null"`
+	Account        uint32    `gorm:"column:Account;type:int;not null"`
+	Change         uint32    `gorm:"column:Change;type:int;not null"`
+	AddressIndex   uint32    `gorm:"column:AddressIndex;type:int;not null"`
+	CreateDate     time.Time `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate     time.Time `gorm:"column:UpdateDate;type:timestamptz"`
 }
 
 func (BlockchainAddress) TableName() string {
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Erc20Token đại diện bảng "Erc20Tokens"
+//
+// The registry of ERC-20 contracts the wallet knows. Symbols are unique
+// per network, so a symbol names one contract.
+type Erc20Token struct {
+	TokenId         string    `gorm:"column:TokenId;primaryKey;type:varchar(128);not null"`
+	Chain           string    `gorm:"column:Chain;type:varchar(16);not null;uniqueIndex:idx_Erc20Tokens_Chain_Network_ContractAddress,priority:1;uniqueIndex:idx_Erc20Tokens_Chain_Network_Symbol,priority:1"`
+	Network         string    `gorm:"column:Network;type:varchar(16);not null;uniqueIndex:idx_Erc20Tokens_Chain_Network_ContractAddress,priority:2;uniqueIndex:idx_Erc20Tokens_Chain_Network_Symbol,priority:2"`
+	ContractAddress string    `gorm:"column:ContractAddress;type:varchar(42);not null;uniqueIndex:idx_Erc20Tokens_Chain_Network_ContractAddress,priority:3"`
+	Symbol          string    `gorm:"column:Symbol;type:varchar(32);not null;uniqueIndex:idx_Erc20Tokens_Chain_Network_Symbol,priority:3"`
+	Name            string    `gorm:"column:Name;type:varchar(128);not null"`
+	Decimals        uint8     `gorm:"column:Decimals;type:smallint;not null"`
+	CreateDate      time.Time `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate      time.Time `gorm:"column:UpdateDate;type:timestamptz"`
+}
+
+func (Erc20Token) TableName() string {
+	return "Erc20Tokens"
+}
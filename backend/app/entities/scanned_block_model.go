@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ScannedBlock đại diện bảng "ScannedBlocks"
+//
+// The highest row per chain and network is the deposit watcher's
+// checkpoint; the rows below it let the watcher find where a reorg forked.
+type ScannedBlock struct {
+	Chain       string    `gorm:"column:Chain;primaryKey;type:varchar(16);not null"`
+	Network     string    `gorm:"column:Network;primaryKey;type:varchar(16);not null"`
+	BlockNumber uint64    `gorm:"column:BlockNumber;primaryKey;type:bigint;not null"`
+	BlockHash   string    `gorm:"column:BlockHash;type:varchar(66);not null"`
+	ParentHash  string    `gorm:"column:ParentHash;type:varchar(66);not null"`
+	CreateDate  time.Time `gorm:"column:CreateDate;type:timestamptz"`
+}
+
+func (ScannedBlock) TableName() string {
+	return "ScannedBlocks"
+}
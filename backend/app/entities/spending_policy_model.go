@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+// AnyAsset is the asset of a policy that covers every signing request.
+// Such a policy can only restrict hours, as amounts of different assets
+// cannot be added up.
+const AnyAsset = "*"
+
+// SpendingPolicy đại diện bảng "SpendingPolicies"
+//
+// Limits on what can be signed for a user: for one of their wallets, or
+// for all of them together when WalletId is nil. Amounts are in base units
+// of Asset; nil leaves that limit off. Signing is allowed from
+// AllowedFromHour up to, not including, AllowedToHour in Timezone; a
+// window that wraps past midnight is allowed.
+type SpendingPolicy struct {
+	SpendingPolicyId  string      `gorm:"column:SpendingPolicyId;primaryKey;type:varchar(128);not null"`
+	UserId            string      `gorm:"column:UserId;type:varchar(128);not null"`
+	WalletId          *string     `gorm:"column:WalletId;type:varchar(128);default:null;index"`
+	Asset             string      `gorm:"column:Asset;type:varchar(32);not null"`
+	MaxPerTransaction *amount.Int `gorm:"column:MaxPerTransaction;type:numeric(78,0);default:null"`
+	DailyLimit        *amount.Int `gorm:"column:DailyLimit;type:numeric(78,0);default:null"`
+	WeeklyLimit       *amount.Int `gorm:"column:WeeklyLimit;type:numeric(78,0);default:null"`
+	AllowedFromHour   *int        `gorm:"column:AllowedFromHour;type:smallint;default:null"`
+	AllowedToHour     *int        `gorm:"column:AllowedToHour;type:smallint;default:null"`
+	Timezone          string      `gorm:"column:Timezone;type:varchar(64);not null;default:UTC"`
+	CreateDate        time.Time   `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate        time.Time   `gorm:"column:UpdateDate;type:timestamptz"`
+}
+
+func (SpendingPolicy) TableName() string {
+	return "SpendingPolicies"
+}
+
+// Covers reports whether the policy applies to transfers of asset.
+func (p *SpendingPolicy) Covers(asset string) bool {
+	return p.Asset == AnyAsset || strings.EqualFold(p.Asset, asset)
+}
+
+// HoursAllow reports whether signing is allowed at t. A policy without an
+// hour window allows any time.
+func (p *SpendingPolicy) HoursAllow(t time.Time) (bool, error) {
+	if p.AllowedFromHour == nil || p.AllowedToHour == nil {
+		return true, nil
+	}
+
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return false, err
+	}
+
+	hour, from, to := t.In(loc).Hour(), *p.AllowedFromHour, *p.AllowedToHour
+	if from <= to {
+		return from <= hour && hour < to, nil
+	}
+	return hour >= from || hour < to, nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingPolicyHoursAllow(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2026, 10, 17, hour, 30, 0, 0, time.UTC)
+	}
+	hours := func(from, to int, timezone string) *SpendingPolicy {
+		return &SpendingPolicy{AllowedFromHour: &from, AllowedToHour: &to, Timezone: timezone}
+	}
+
+	tests := []struct {
+		name   string
+		policy *SpendingPolicy
+		at     time.Time
+		want   bool
+	}{
+		{"no window", &SpendingPolicy{Timezone: "UTC"}, at(3), true},
+		{"inside", hours(9, 17, "UTC"), at(9), true},
+		{"end excluded", hours(9, 17, "UTC"), at(17), false},
+		{"before", hours(9, 17, "UTC"), at(8), false},
+		{"wraps past midnight, late", hours(22, 6, "UTC"), at(23), true},
+		{"wraps past midnight, early", hours(22, 6, "UTC"), at(5), true},
+		{"wraps past midnight, day", hours(22, 6, "UTC"), at(12), false},
+		{"in the policy timezone", hours(9, 17, "Asia/Ho_Chi_Minh"), at(3), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := tt.policy.HoursAllow(tt.at)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, allowed)
+		})
+	}
+}
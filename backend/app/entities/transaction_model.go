@@ -1,16 +1,47 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+// Transaction statuses. Allowed changes between them are enforced by the
+// transaction service.
+const (
+	TransactionStatusPending   = "pending"
+	TransactionStatusBroadcast = "broadcast"
+	TransactionStatusConfirmed = "confirmed"
+	TransactionStatusFailed    = "failed"
+	TransactionStatusReplaced  = "replaced"
+)
+
+// Transaction directions, seen from the wallet.
+const (
+	TransactionDirectionIncoming = "incoming"
+	TransactionDirectionOutgoing = "outgoing"
+)
 
 // Transaction đại diện bảng "Transactions"
 type Transaction struct {
-	TransactionId   string    `gorm:"column:TransactionId;primaryKey;type:varchar(128);not null"`
-	WalletId        string    `gorm:"column:WalletId;type:varchar(128);not null"`
-	FromAddress     string    `gorm:"column:FromAddress;type:varchar(128);not null"`
-	ToAddress       string    `gorm:"column:ToAddress;type:varchar(128);not null"`
-	Amount          float64   `gorm:"column:Amount;type:decimal(18,8);not null"`
-	TransactionDate time.Time `gorm:"column:TransactionDate;type:timestamptz"`
-	Status          string    `gorm:"column:Status;type:varchar(50);not null"`
+	TransactionId   string     `gorm:"column:TransactionId;primaryKey;type:varchar(128);not null;index:idx_Transactions_WalletId_TransactionDate,priority:3"`
+	WalletId        string     `gorm:"column:WalletId;type:varchar(128);not null;index:idx_Transactions_WalletId_TransactionDate,priority:1"`
+	Chain           string     `gorm:"column:Chain;type:varchar(16);not null;index:idx_Transactions_Chain_BlockNumber,priority:1"`
+	Direction       string     `gorm:"column:Direction;type:varchar(16);not null"`
+	FromAddress     string     `gorm:"column:FromAddress;type:varchar(128);not null"`
+	ToAddress       string     `gorm:"column:ToAddress;type:varchar(128);not null"`
+	Amount          amount.Int `gorm:"column:Amount;type:numeric(78,0);not null"`
+	Asset           string     `gorm:"column:Asset;type:varchar(32);not null"`
+	Decimals        uint8      `gorm:"column:Decimals;type:smallint;not null"`
+	ContractAddress string     `gorm:"column:ContractAddress;type:varchar(42);default:null"`
+	TxHash          string     `gorm:"column:TxHash;type:varchar(128);default:null;index"`
+	BlockNumber     uint64     `gorm:"column:BlockNumber;type:bigint;default:null;index:idx_Transactions_Chain_BlockNumber,priority:2"`
+	BlockHash       string     `gorm:"column:BlockHash;type:varchar(66);default:null"`
+	Confirmations   uint64     `gorm:"column:Confirmations;type:bigint;not null;default:0"`
+	TransactionDate time.Time  `gorm:"column:TransactionDate;type:timestamptz;index:idx_Transactions_WalletId_TransactionDate,priority:2"`
+	Status          string     `gorm:"column:Status;type:varchar(50);not null"`
+	CreateDate      time.Time  `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate      time.Time  `gorm:"column:UpdateDate;type:timestamptz"`
 
 	// 🔗 Relation
 	Wallet Wallet `gorm:"foreignKey:WalletId;references:WalletId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -19,3 +50,9 @@ type Transaction struct {
 func (Transaction) TableName() string {
 	return "Transactions"
 }
+
+// Watched reports whether the deposit watcher found the transaction on
+// chain. Only the watcher changes the status of such a transaction.
+func (t *Transaction) Watched() bool {
+	return t.BlockNumber != 0
+}
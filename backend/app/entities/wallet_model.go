@@ -4,16 +4,42 @@ import "time"
 
 // Wallet đại diện bảng "Wallets"
 type Wallet struct {
-	WalletId         string    `gorm:"column:WalletId;primaryKey;type:varchar(128);not null"`
-	WalletName       string    `gorm:"column:WalletName;type:varchar(256);not null"`
-	SecretPhraseHash string    `gorm:"column:SecretPhraseHash;type:text;not null"`
-	PassphraseHash   string    `gorm:"column:PassphraseHash;type:text"`
-	CreateDate       time.Time `gorm:"column:CreateDate;type:timestamptz"`
-	UpdateDate       time.Time `gorm:"column:UpdateDate;type:timestamptz"`
+	WalletId             string     `gorm:"column:WalletId;primaryKey;type:varchar(128);not null"`
+	UserId               string     `gorm:"column:UserId;type:varchar(128);index;uniqueIndex:idx_Wallets_UserId_Fingerprint,priority:1"`
+	Fingerprint          string     `gorm:"column:Fingerprint;type:varchar(64);default:null;uniqueIndex:idx_Wallets_UserId_Fingerprint,priority:2"`
+	WalletName           string     `gorm:"column:WalletName;type:varchar(256);not null"`
+	EthNetwork           string     `gorm:"column:EthNetwork;type:varchar(16);not null"`
+	BtcNetwork           string     `gorm:"column:BtcNetwork;type:varchar(16);not null"`
+	SecretPhraseHash     string     `gorm:"column:SecretPhraseHash;type:text;not null"`
+	WrappedDek           string     `gorm:"column:WrappedDek;type:text;default:null"`
+	KekId                string     `gorm:"column:KekId;type:varchar(64);default:null;index"`
+	PassphraseHash       string     `gorm:"column:PassphraseHash;type:text"`
+	HasSeedPassphrase    bool       `gorm:"column:HasSeedPassphrase;type:boolean;not null;default:false"`
+	AllowlistEnabled     bool       `gorm:"column:AllowlistEnabled;type:boolean;not null;default:false"`
+	AllowlistDisableDate *time.Time `gorm:"column:AllowlistDisableDate;type:timestamptz;default:null"`
+	ExtendedPublicKey    string     `gorm:"column:ExtendedPublicKey;type:text;default:null"`
+	WatchOnlyChain       string     `gorm:"column:WatchOnlyChain;type:varchar(8);default:null"`
+	CreateDate           time.Time  `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate           time.Time  `gorm:"column:UpdateDate;type:timestamptz"`
 
 	// 🔗 Relations
 	BlockchainAddresses []BlockchainAddress `gorm:"foreignKey:WalletId;references:WalletId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Transactions        []Transaction       `gorm:"foreignKey:WalletId;references:WalletId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	WithdrawalAddresses []WithdrawalAddress `gorm:"foreignKey:WalletId;references:WalletId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	WithdrawalRequests  []WithdrawalRequest `gorm:"foreignKey:WalletId;references:WalletId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// AllowlistEnforced reports whether payouts are limited to active
+// withdrawal addresses at t. Switching the allowlist off only takes effect
+// at AllowlistDisableDate, which is nil while no switch-off is pending.
+func (w *Wallet) AllowlistEnforced(t time.Time) bool {
+	return w.AllowlistEnabled && (w.AllowlistDisableDate == nil || t.Before(*w.AllowlistDisableDate))
+}
+
+// WatchOnly reports whether the wallet only holds an extended public key.
+// It has no mnemonic, so it can derive and track addresses but never sign.
+func (w *Wallet) WatchOnly() bool {
+	return w.ExtendedPublicKey != ""
 }
 
 func (Wallet) TableName() string {
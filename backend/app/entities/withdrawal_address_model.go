@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// WithdrawalAddress đại diện bảng "WithdrawalAddresses"
+//
+// A wallet's address book of approved destinations. An entry can only be
+// paid to from ActiveDate on, a cooling-off period after it was added.
+type WithdrawalAddress struct {
+	WithdrawalAddressId string    `gorm:"column:WithdrawalAddressId;primaryKey;type:varchar(128);not null"`
+	WalletId            string    `gorm:"column:WalletId;type:varchar(128);not null;uniqueIndex:idx_WithdrawalAddresses_WalletId_Chain_Address,priority:1"`
+	Chain               string    `gorm:"column:Chain;type:varchar(16);not null;uniqueIndex:idx_WithdrawalAddresses_WalletId_Chain_Address,priority:2"`
+	Address             string    `gorm:"column:Address;type:varchar(128);not null;uniqueIndex:idx_WithdrawalAddresses_WalletId_Chain_Address,priority:3"`
+	Label               string    `gorm:"column:Label;type:varchar(128);not null"`
+	ActiveDate          time.Time `gorm:"column:ActiveDate;type:timestamptz;not null"`
+	CreateDate          time.Time `gorm:"column:CreateDate;type:timestamptz"`
+}
+
+func (WithdrawalAddress) TableName() string {
+	return "WithdrawalAddresses"
+}
+
+// IsActive reports whether the entry may be paid to at t.
+func (a *WithdrawalAddress) IsActive(t time.Time) bool {
+	return !t.Before(a.ActiveDate)
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+// Withdrawal request statuses. A pending request becomes approved once it
+// has enough approvals, or rejected on the first rejection; approved
+// requests are executed by signing. Pending and approved requests expire
+// at ExpireDate.
+const (
+	WithdrawalStatusPending  = "pending"
+	WithdrawalStatusApproved = "approved"
+	WithdrawalStatusRejected = "rejected"
+	WithdrawalStatusExpired  = "expired"
+	WithdrawalStatusExecuted = "executed"
+)
+
+// Kinds of signing a withdrawal request runs once approved.
+const (
+	WithdrawalKindEthTransaction = "eth_transaction"
+	WithdrawalKindTokenTransfer  = "token_transfer"
+	WithdrawalKindBtcPSBT        = "btc_psbt"
+	WithdrawalKindTypedData      = "typed_data"
+)
+
+// Approver decisions.
+const (
+	WithdrawalDecisionApproved = "approved"
+	WithdrawalDecisionRejected = "rejected"
+)
+
+// WithdrawalRequest đại diện bảng "WithdrawalRequests"
+//
+// An outgoing transfer waiting for approvals. Payload holds the signing
+// request, without passphrases, that runs when the request is executed;
+// Asset, Amount and Destination summarize it for approvers.
+type WithdrawalRequest struct {
+	WithdrawalRequestId string     `gorm:"column:WithdrawalRequestId;primaryKey;type:varchar(128);not null"`
+	WalletId            string     `gorm:"column:WalletId;type:varchar(128);not null;index"`
+	RequesterId         string     `gorm:"column:RequesterId;type:varchar(128);not null"`
+	Kind                string     `gorm:"column:Kind;type:varchar(32);not null"`
+	Chain               string     `gorm:"column:Chain;type:varchar(16);not null"`
+	Asset               string     `gorm:"column:Asset;type:varchar(32);not null"`
+	Decimals            uint8      `gorm:"column:Decimals;type:smallint;not null"`
+	Amount              amount.Int `gorm:"column:Amount;type:numeric(78,0);not null"`
+	Destination         string     `gorm:"column:Destination;type:text;not null"`
+	Payload             string     `gorm:"column:Payload;type:text;not null"`
+	Status              string     `gorm:"column:Status;type:varchar(16);not null;index"`
+	RequiredApprovals   int        `gorm:"column:RequiredApprovals;type:int;not null"`
+	ExpireDate          time.Time  `gorm:"column:ExpireDate;type:timestamptz;not null"`
+	TxHash              string     `gorm:"column:TxHash;type:varchar(128);default:null"`
+	CreateDate          time.Time  `gorm:"column:CreateDate;type:timestamptz"`
+	UpdateDate          time.Time  `gorm:"column:UpdateDate;type:timestamptz"`
+
+	// 🔗 Relations
+	Approvals []WithdrawalApproval `gorm:"foreignKey:WithdrawalRequestId;references:WithdrawalRequestId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (WithdrawalRequest) TableName() string {
+	return "WithdrawalRequests"
+}
+
+// ApprovalCount returns how many approvers approved the request.
+func (r *WithdrawalRequest) ApprovalCount() int {
+	count := 0
+	for _, approval := range r.Approvals {
+		if approval.Decision == WithdrawalDecisionApproved {
+			count++
+		}
+	}
+	return count
+}
+
+// WithdrawalApproval đại diện bảng "WithdrawalApprovals"
+//
+// One approver's decision on a withdrawal request. Each approver decides
+// at most once per request.
+type WithdrawalApproval struct {
+	WithdrawalApprovalId string    `gorm:"column:WithdrawalApprovalId;primaryKey;type:varchar(128);not null"`
+	WithdrawalRequestId  string    `gorm:"column:WithdrawalRequestId;type:varchar(128);not null;uniqueIndex:idx_WithdrawalApprovals_WithdrawalRequestId_ApproverId,priority:1"`
+	ApproverId           string    `gorm:"column:ApproverId;type:varchar(128);not null;uniqueIndex:idx_WithdrawalApprovals_WithdrawalRequestId_ApproverId,priority:2"`
+	Decision             string    `gorm:"column:Decision;type:varchar(16);not null"`
+	Comment              string    `gorm:"column:Comment;type:varchar(512)"`
+	CreateDate           time.Time `gorm:"column:CreateDate;type:timestamptz"`
+}
+
+func (WithdrawalApproval) TableName() string {
+	return "WithdrawalApprovals"
+}
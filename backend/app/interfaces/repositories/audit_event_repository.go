@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+}
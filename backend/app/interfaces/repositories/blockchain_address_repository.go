@@ -8,4 +8,6 @@ import (
 
 type BlockchainAddressRepository interface {
 	Create(ctx context.Context, addr *models.BlockchainAddress) error
+	NextIndex(ctx context.Context, walletId, chain string, purpose, account, change uint32) (uint32, error)
+	ListByNetwork(ctx context.Context, chain, network string) ([]models.BlockchainAddress, error)
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type Erc20TokenRepository interface {
+	Create(ctx context.Context, token *models.Erc20Token) error
+	ListByNetwork(ctx context.Context, chain, network string) ([]models.Erc20Token, error)
+	GetBySymbol(ctx context.Context, chain, network, symbol string) (*models.Erc20Token, error)
+	GetByContract(ctx context.Context, chain, network, contract string) (*models.Erc20Token, error)
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type ScannedBlockRepository interface {
+	Create(ctx context.Context, block *models.ScannedBlock) error
+	Latest(ctx context.Context, chain, network string) (*models.ScannedBlock, error)
+	ListFrom(ctx context.Context, chain, network string, fromBlock uint64) ([]models.ScannedBlock, error)
+	DeleteAbove(ctx context.Context, chain, network string, blockNumber uint64) error
+	DeleteBelow(ctx context.Context, chain, network string, blockNumber uint64) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type SpendingPolicyRepository interface {
+	Create(ctx context.Context, policy *models.SpendingPolicy) error
+	GetById(ctx context.Context, spendingPolicyId string) (*models.SpendingPolicy, error)
+	Update(ctx context.Context, policy *models.SpendingPolicy) error
+	Delete(ctx context.Context, spendingPolicyId string) (*models.SpendingPolicy, error)
+	ListByUser(ctx context.Context, userId string) ([]models.SpendingPolicy, error)
+	ListForWallet(ctx context.Context, userId, walletId string) ([]models.SpendingPolicy, error)
+}
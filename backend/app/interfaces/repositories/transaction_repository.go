@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+// TransactionCursor is the position of the last transaction of a page.
+// Pages are ordered by TransactionDate, then TransactionId, newest first.
+type TransactionCursor struct {
+	TransactionDate time.Time
+	TransactionId   string
+}
+
+// TransactionFilter selects a page of a wallet's transactions. Empty
+// fields do not filter; Address matches either side of the transfer.
+type TransactionFilter struct {
+	WalletId  string
+	Status    string
+	Direction string
+	Address   string
+	From      *time.Time
+	To        *time.Time
+	After     *TransactionCursor
+	Limit     int
+}
+
+type TransactionRepository interface {
+	Create(ctx context.Context, tx *models.Transaction) error
+	GetByIdAndWallet(ctx context.Context, transactionId, walletId string) (*models.Transaction, error)
+	List(ctx context.Context, filter TransactionFilter) ([]models.Transaction, error)
+	UpdateStatus(ctx context.Context, transactionId, fromStatus, toStatus, txHash string) error
+	UpdateDepositConfirmations(ctx context.Context, chain, network string, head, required uint64) error
+	DeleteDepositsAbove(ctx context.Context, chain, network string, blockNumber uint64) ([]models.Transaction, error)
+}
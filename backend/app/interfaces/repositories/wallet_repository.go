@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	models "github.com/create-go-app/fiber-go-template/app/entities"
 )
@@ -9,5 +10,17 @@ import (
 type WalletRepository interface {
 	Create(ctx context.Context, wallet *models.Wallet) error
 	GetById(ctx context.Context, walletId string) (*models.Wallet, error)
+	GetByIdAndUser(ctx context.Context, walletId, userId string) (*models.Wallet, error)
+	GetByIdForUpdate(ctx context.Context, walletId string) (*models.Wallet, error)
+	GetByFingerprint(ctx context.Context, userId, fingerprint string) (*models.Wallet, error)
+	ListByUser(ctx context.Context, userId string) ([]models.Wallet, error)
+	ListWithoutFingerprint(ctx context.Context, userId string) ([]models.Wallet, error)
+	UpdateFingerprint(ctx context.Context, walletId, fingerprint string) error
+	UpdateEncryptedMnemonic(ctx context.Context, walletId, secretPhraseHash, wrappedDek, kekId string) error
+	UpdatePassphrase(ctx context.Context, walletId, passphraseHash, secretPhraseHash, wrappedDek, kekId string) error
+	UpdateAllowlist(ctx context.Context, walletId string, enabled bool, disableDate *time.Time) error
+	ListNotWrappedBy(ctx context.Context, kekId, afterWalletId string, limit int) ([]models.Wallet, error)
 	ListAll(ctx context.Context) ([]models.Wallet, error)
+	ListUnowned(ctx context.Context) ([]models.Wallet, error)
+	ClaimUnowned(ctx context.Context, walletId, userId string) error
 }
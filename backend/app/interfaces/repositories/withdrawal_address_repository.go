@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type WithdrawalAddressRepository interface {
+	Create(ctx context.Context, address *models.WithdrawalAddress) error
+	ListByWallet(ctx context.Context, walletId string) ([]models.WithdrawalAddress, error)
+	ListByWalletAndChain(ctx context.Context, walletId, chain string) ([]models.WithdrawalAddress, error)
+	Delete(ctx context.Context, walletId, withdrawalAddressId string) (*models.WithdrawalAddress, error)
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+)
+
+type WithdrawalRequestRepository interface {
+	Create(ctx context.Context, request *models.WithdrawalRequest) error
+	GetById(ctx context.Context, withdrawalRequestId string) (*models.WithdrawalRequest, error)
+	GetByIdForUpdate(ctx context.Context, withdrawalRequestId string) (*models.WithdrawalRequest, error)
+	ListByWallet(ctx context.Context, walletId string) ([]models.WithdrawalRequest, error)
+	ListByStatus(ctx context.Context, status string) ([]models.WithdrawalRequest, error)
+	UpdateStatus(ctx context.Context, withdrawalRequestId, fromStatus, toStatus, txHash string) error
+	ExpireDue(ctx context.Context, now time.Time) error
+	CreateApproval(ctx context.Context, approval *models.WithdrawalApproval) error
+}
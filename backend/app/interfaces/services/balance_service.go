@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+)
+
+type BalanceService interface {
+	GetBalances(ctx context.Context, userId, walletId string) (*core.ApiResponse, error)
+}
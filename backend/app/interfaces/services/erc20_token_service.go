@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+)
+
+type Erc20TokenService interface {
+	ListTokens(ctx context.Context, req *dto.ListErc20TokensReq) (*core.ApiResponse, error)
+	CreateToken(ctx context.Context, req *dto.CreateErc20TokenReq) (*core.ApiResponse, error)
+}
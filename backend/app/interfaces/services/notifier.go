@@ -0,0 +1,9 @@
+package services
+
+import "context"
+
+// Notifier tells a user about security-relevant changes to their account
+// through a channel outside the API, such as email.
+type Notifier interface {
+	Notify(ctx context.Context, userId, event string, details map[string]any) error
+}
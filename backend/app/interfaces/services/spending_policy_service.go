@@ -0,0 +1,16 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+)
+
+type SpendingPolicyService interface {
+	ListPolicies(ctx context.Context, req *dto.ListSpendingPoliciesReq) (*core.ApiResponse, error)
+	ListWalletPolicies(ctx context.Context, userId, walletId string) (*core.ApiResponse, error)
+	CreatePolicy(ctx context.Context, adminId string, req *dto.CreateSpendingPolicyReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	UpdatePolicy(ctx context.Context, adminId, spendingPolicyId string, req *dto.UpdateSpendingPolicyReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	DeletePolicy(ctx context.Context, adminId, spendingPolicyId string, meta dto.RequestMeta) (*core.ApiResponse, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+)
+
+type TransactionService interface {
+	RecordTransaction(ctx context.Context, userId, walletId string, req *dto.RecordTransactionReq) (*core.ApiResponse, error)
+	ListTransactions(ctx context.Context, userId, walletId string, req *dto.ListTransactionsReq) (*core.ApiResponse, error)
+	GetTransaction(ctx context.Context, userId, walletId, transactionId string) (*core.ApiResponse, error)
+	UpdateTransactionStatus(ctx context.Context, userId, walletId, transactionId string, req *dto.UpdateTransactionStatusReq) (*core.ApiResponse, error)
+}
@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+)
+
+type WalletClaimService interface {
+	ListUnclaimed(ctx context.Context) ([]dto.WalletRes, error)
+	ClaimWallet(ctx context.Context, walletId, userId string) error
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+)
+
+type WalletKeyService interface {
+	RewrapWallets(ctx context.Context) (*dto.RewrapWalletsRes, error)
+}
@@ -8,6 +8,30 @@ import (
 )
 
 type WalletService interface {
-	CreateWallet(ctx context.Context, req *dto.CreateWalletReq) (*dto.CreateWalletRes, error)
-	RestoreWallet(ctx context.Context, req *dto.RestoreWalletReq) (*core.ApiResponse, error)
+	CreateWallet(ctx context.Context, userId string, req *dto.CreateWalletReq) (*dto.CreateWalletRes, error)
+	RestoreWallet(ctx context.Context, userId string, req *dto.RestoreWalletReq) (*core.ApiResponse, error)
+	ImportWatchOnlyWallet(ctx context.Context, userId string, req *dto.ImportWatchOnlyWalletReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	GetWallets(ctx context.Context, userId string) (*core.ApiResponse, error)
+	GetWallet(ctx context.Context, userId, walletId string) (*core.ApiResponse, error)
+	DeriveAddress(ctx context.Context, userId, walletId string, req *dto.DeriveAddressReq) (*core.ApiResponse, error)
+	ChangePassphrase(ctx context.Context, userId, walletId string, req *dto.ChangePassphraseReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	SignEthTransaction(ctx context.Context, userId, walletId string, req *dto.SignEthTransactionReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	SignTokenTransfer(ctx context.Context, userId, walletId string, req *dto.SignTokenTransferReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	ReleaseNonce(ctx context.Context, userId, walletId string, req *dto.ReleaseNonceReq) (*core.ApiResponse, error)
+	CommitNonce(ctx context.Context, userId, walletId string, req *dto.CommitNonceReq) (*core.ApiResponse, error)
+	ResyncNonce(ctx context.Context, userId, walletId string, req *dto.ResyncNonceReq) (*core.ApiResponse, error)
+	SignMessage(ctx context.Context, userId, walletId string, req *dto.SignMessageReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	SignTypedData(ctx context.Context, userId, walletId string, req *dto.SignTypedDataReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	VerifySignature(ctx context.Context, req *dto.VerifySignatureReq) (*core.ApiResponse, error)
+	SignBtcPSBT(ctx context.Context, userId, walletId string, req *dto.SignBtcPSBTReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	GetAllowlist(ctx context.Context, userId, walletId string) (*core.ApiResponse, error)
+	AddWithdrawalAddress(ctx context.Context, userId, walletId string, req *dto.AddWithdrawalAddressReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	RemoveWithdrawalAddress(ctx context.Context, userId, walletId, withdrawalAddressId string, meta dto.RequestMeta) (*core.ApiResponse, error)
+	SetAllowlistMode(ctx context.Context, userId, walletId string, req *dto.SetAllowlistModeReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	CreateWithdrawal(ctx context.Context, userId, walletId string, req *dto.CreateWithdrawalReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	ListWithdrawals(ctx context.Context, userId, walletId string) (*core.ApiResponse, error)
+	GetWithdrawal(ctx context.Context, userId, walletId, withdrawalId string) (*core.ApiResponse, error)
+	ExecuteWithdrawal(ctx context.Context, userId, walletId, withdrawalId string, req *dto.ExecuteWithdrawalReq, meta dto.RequestMeta) (*core.ApiResponse, error)
+	ListPendingWithdrawals(ctx context.Context, req *dto.ListWithdrawalsReq) (*core.ApiResponse, error)
+	DecideWithdrawal(ctx context.Context, approverId, withdrawalId, decision string, req *dto.DecideWithdrawalReq, meta dto.RequestMeta) (*core.ApiResponse, error)
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+)
+
+type AuditEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) repositories.AuditEventRepository {
+	return &AuditEventRepositoryImpl{db: db}
+}
+
+func (r *AuditEventRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.AuditEventRepository].
+func (r *AuditEventRepositoryImpl) Create(
+	ctx context.Context,
+	event *models.AuditEvent,
+) error {
+
+	return r.getDB(ctx).Create(event).Error
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	models "github.com/create-go-app/fiber-go-template/app/entities"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
 	"github.com/create-go-app/fiber-go-template/platform/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BlockchainAddressRepositoryImpl struct {
@@ -30,3 +33,75 @@ func (r *BlockchainAddressRepositoryImpl) Create(
 
 	return r.getDB(ctx).Create(addr).Error
 }
+
+// NextIndex implements [repositories.BlockchainAddressRepository].
+// It returns the first index after the highest one already derived
+// under the given purpose, account and change levels.
+func (r *BlockchainAddressRepositoryImpl) NextIndex(
+	ctx context.Context,
+	walletId string,
+	chain string,
+	purpose uint32,
+	account uint32,
+	change uint32,
+) (uint32, error) {
+
+	var last models.BlockchainAddress
+
+	err := r.getDB(ctx).
+		Where(map[string]interface{}{
+			"WalletId": walletId,
+			"Chain":    chain,
+			"Purpose":  purpose,
+			"Account":  account,
+			"Change":   change,
+		}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "AddressIndex"}, Desc: true}).
+		First(&last).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return last.AddressIndex + 1, nil
+}
+
+// ListByNetwork implements [repositories.BlockchainAddressRepository].
+// It returns the chain's addresses of every wallet on the given network.
+func (r *BlockchainAddressRepositoryImpl) ListByNetwork(
+	ctx context.Context,
+	chain string,
+	network string,
+) ([]models.BlockchainAddress, error) {
+
+	var addrs []models.BlockchainAddress
+
+	db := r.getDB(ctx)
+	err := db.
+		Where(clause.Eq{Column: clause.Column{Name: "Chain"}, Value: chain}).
+		Where(clause.Expr{SQL: "? IN (?)", Vars: []interface{}{
+			clause.Column{Name: "WalletId"}, walletsOnNetwork(db, chain, network),
+		}}).
+		Find(&addrs).
+		Error
+
+	return addrs, err
+}
+
+// walletsOnNetwork is a subquery of the ids of wallets whose network for
+// chain is network.
+func walletsOnNetwork(db *gorm.DB, chain, network string) *gorm.DB {
+	column := "EthNetwork"
+	if chain == crypto.ChainBTC {
+		column = "BtcNetwork"
+	}
+
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&models.Wallet{}).
+		Select("WalletId").
+		Where(clause.Eq{Column: clause.Column{Name: column}, Value: network})
+}
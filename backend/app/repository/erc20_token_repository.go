@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Erc20TokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewErc20TokenRepository(db *gorm.DB) repositories.Erc20TokenRepository {
+	return &Erc20TokenRepositoryImpl{db: db}
+}
+
+func (r *Erc20TokenRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.Erc20TokenRepository].
+// A contract or symbol already registered on the network is a conflict.
+func (r *Erc20TokenRepositoryImpl) Create(
+	ctx context.Context,
+	token *models.Erc20Token,
+) error {
+
+	err := r.getDB(ctx).Create(token).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+// ListByNetwork implements [repositories.Erc20TokenRepository].
+func (r *Erc20TokenRepositoryImpl) ListByNetwork(
+	ctx context.Context,
+	chain string,
+	network string,
+) ([]models.Erc20Token, error) {
+
+	var tokens []models.Erc20Token
+
+	err := r.getDB(ctx).
+		Where(&models.Erc20Token{Chain: chain, Network: network}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "Symbol"}}).
+		Find(&tokens).
+		Error
+
+	return tokens, err
+}
+
+// GetBySymbol implements [repositories.Erc20TokenRepository].
+// Symbols match case-insensitively.
+func (r *Erc20TokenRepositoryImpl) GetBySymbol(
+	ctx context.Context,
+	chain string,
+	network string,
+	symbol string,
+) (*models.Erc20Token, error) {
+
+	var token models.Erc20Token
+
+	err := r.getDB(ctx).
+		Where(&models.Erc20Token{Chain: chain, Network: network}).
+		Where("UPPER(?) = UPPER(?)", clause.Column{Name: "Symbol"}, symbol).
+		First(&token).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// GetByContract implements [repositories.Erc20TokenRepository].
+// contract must be EIP-55 checksummed, as stored.
+func (r *Erc20TokenRepositoryImpl) GetByContract(
+	ctx context.Context,
+	chain string,
+	network string,
+	contract string,
+) (*models.Erc20Token, error) {
+
+	var token models.Erc20Token
+
+	err := r.getDB(ctx).
+		Where(&models.Erc20Token{Chain: chain, Network: network, ContractAddress: contract}).
+		First(&token).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
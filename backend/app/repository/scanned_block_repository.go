@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScannedBlockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewScannedBlockRepository(db *gorm.DB) repositories.ScannedBlockRepository {
+	return &ScannedBlockRepositoryImpl{db: db}
+}
+
+func (r *ScannedBlockRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.ScannedBlockRepository].
+func (r *ScannedBlockRepositoryImpl) Create(
+	ctx context.Context,
+	block *models.ScannedBlock,
+) error {
+
+	err := r.getDB(ctx).Create(block).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+// Latest implements [repositories.ScannedBlockRepository].
+// It returns the checkpoint, or ErrNotFound before the first scan.
+func (r *ScannedBlockRepositoryImpl) Latest(
+	ctx context.Context,
+	chain string,
+	network string,
+) (*models.ScannedBlock, error) {
+
+	var block models.ScannedBlock
+
+	err := r.getDB(ctx).
+		Where(&models.ScannedBlock{Chain: chain, Network: network}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "BlockNumber"}, Desc: true}).
+		First(&block).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+// ListFrom implements [repositories.ScannedBlockRepository].
+// Blocks come newest first.
+func (r *ScannedBlockRepositoryImpl) ListFrom(
+	ctx context.Context,
+	chain string,
+	network string,
+	fromBlock uint64,
+) ([]models.ScannedBlock, error) {
+
+	var blocks []models.ScannedBlock
+
+	err := r.getDB(ctx).
+		Where(&models.ScannedBlock{Chain: chain, Network: network}).
+		Where(clause.Gte{Column: clause.Column{Name: "BlockNumber"}, Value: fromBlock}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "BlockNumber"}, Desc: true}).
+		Find(&blocks).
+		Error
+
+	return blocks, err
+}
+
+// DeleteAbove implements [repositories.ScannedBlockRepository].
+func (r *ScannedBlockRepositoryImpl) DeleteAbove(
+	ctx context.Context,
+	chain string,
+	network string,
+	blockNumber uint64,
+) error {
+
+	return r.getDB(ctx).
+		Where(&models.ScannedBlock{Chain: chain, Network: network}).
+		Where(clause.Gt{Column: clause.Column{Name: "BlockNumber"}, Value: blockNumber}).
+		Delete(&models.ScannedBlock{}).
+		Error
+}
+
+// DeleteBelow implements [repositories.ScannedBlockRepository].
+func (r *ScannedBlockRepositoryImpl) DeleteBelow(
+	ctx context.Context,
+	chain string,
+	network string,
+	blockNumber uint64,
+) error {
+
+	return r.getDB(ctx).
+		Where(&models.ScannedBlock{Chain: chain, Network: network}).
+		Where(clause.Lt{Column: clause.Column{Name: "BlockNumber"}, Value: blockNumber}).
+		Delete(&models.ScannedBlock{}).
+		Error
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SpendingPolicyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSpendingPolicyRepository(db *gorm.DB) repositories.SpendingPolicyRepository {
+	return &SpendingPolicyRepositoryImpl{db: db}
+}
+
+func (r *SpendingPolicyRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.SpendingPolicyRepository].
+// A second policy for the same scope and asset is a conflict.
+func (r *SpendingPolicyRepositoryImpl) Create(
+	ctx context.Context,
+	policy *models.SpendingPolicy,
+) error {
+
+	err := r.getDB(ctx).Create(policy).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+// GetById implements [repositories.SpendingPolicyRepository].
+func (r *SpendingPolicyRepositoryImpl) GetById(
+	ctx context.Context,
+	spendingPolicyId string,
+) (*models.SpendingPolicy, error) {
+
+	var policy models.SpendingPolicy
+
+	err := r.getDB(ctx).
+		Where(&models.SpendingPolicy{SpendingPolicyId: spendingPolicyId}).
+		First(&policy).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Update implements [repositories.SpendingPolicyRepository].
+// All limits are written, so a nil limit is switched off.
+func (r *SpendingPolicyRepositoryImpl) Update(
+	ctx context.Context,
+	policy *models.SpendingPolicy,
+) error {
+
+	result := r.getDB(ctx).
+		Model(&models.SpendingPolicy{}).
+		Where(&models.SpendingPolicy{SpendingPolicyId: policy.SpendingPolicyId}).
+		Select(
+			"MaxPerTransaction", "DailyLimit", "WeeklyLimit",
+			"AllowedFromHour", "AllowedToHour", "Timezone", "UpdateDate",
+		).
+		Updates(policy)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+// Delete implements [repositories.SpendingPolicyRepository].
+// It returns the removed policy.
+func (r *SpendingPolicyRepositoryImpl) Delete(
+	ctx context.Context,
+	spendingPolicyId string,
+) (*models.SpendingPolicy, error) {
+
+	var removed []models.SpendingPolicy
+
+	err := r.getDB(ctx).
+		Clauses(clause.Returning{}).
+		Where(&models.SpendingPolicy{SpendingPolicyId: spendingPolicyId}).
+		Delete(&removed).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) == 0 {
+		return nil, domainerrors.ErrNotFound
+	}
+
+	return &removed[0], nil
+}
+
+// ListByUser implements [repositories.SpendingPolicyRepository].
+// Policies for all of the user's wallets come first.
+func (r *SpendingPolicyRepositoryImpl) ListByUser(
+	ctx context.Context,
+	userId string,
+) ([]models.SpendingPolicy, error) {
+
+	var policies []models.SpendingPolicy
+
+	err := r.getDB(ctx).
+		Where(&models.SpendingPolicy{UserId: userId}).
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "WalletId"}, Desc: true},
+			{Column: clause.Column{Name: "Asset"}},
+		}}).
+		Find(&policies).
+		Error
+
+	return policies, err
+}
+
+// ListForWallet implements [repositories.SpendingPolicyRepository].
+// It returns the policies that bind the wallet: its own and those for all
+// of the user's wallets.
+func (r *SpendingPolicyRepositoryImpl) ListForWallet(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) ([]models.SpendingPolicy, error) {
+
+	var policies []models.SpendingPolicy
+
+	err := r.getDB(ctx).
+		Where(&models.SpendingPolicy{UserId: userId}).
+		Where(clause.Or(
+			clause.Eq{Column: clause.Column{Name: "WalletId"}, Value: walletId},
+			clause.Eq{Column: clause.Column{Name: "WalletId"}, Value: nil},
+		)).
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: clause.Column{Name: "WalletId"}, Desc: true},
+			{Column: clause.Column{Name: "Asset"}},
+		}}).
+		Find(&policies).
+		Error
+
+	return policies, err
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransactionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) repositories.TransactionRepository {
+	return &TransactionRepositoryImpl{db: db}
+}
+
+func (r *TransactionRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.TransactionRepository].
+func (r *TransactionRepositoryImpl) Create(
+	ctx context.Context,
+	tx *models.Transaction,
+) error {
+
+	err := r.getDB(ctx).Create(tx).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+// GetByIdAndWallet implements [repositories.TransactionRepository].
+func (r *TransactionRepositoryImpl) GetByIdAndWallet(
+	ctx context.Context,
+	transactionId string,
+	walletId string,
+) (*models.Transaction, error) {
+
+	var tx models.Transaction
+
+	err := r.getDB(ctx).
+		Where(&models.Transaction{TransactionId: transactionId, WalletId: walletId}).
+		First(&tx).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// List implements [repositories.TransactionRepository].
+func (r *TransactionRepositoryImpl) List(
+	ctx context.Context,
+	filter repositories.TransactionFilter,
+) ([]models.Transaction, error) {
+
+	var txs []models.Transaction
+
+	date := clause.Column{Name: "TransactionDate"}
+	id := clause.Column{Name: "TransactionId"}
+
+	query := r.getDB(ctx).
+		Where(clause.Eq{Column: clause.Column{Name: "WalletId"}, Value: filter.WalletId})
+
+	if filter.Status != "" {
+		query = query.Where(clause.Eq{Column: clause.Column{Name: "Status"}, Value: filter.Status})
+	}
+	if filter.Direction != "" {
+		query = query.Where(clause.Eq{Column: clause.Column{Name: "Direction"}, Value: filter.Direction})
+	}
+	if filter.Address != "" {
+		query = query.Where(clause.Or(
+			clause.Eq{Column: clause.Column{Name: "FromAddress"}, Value: filter.Address},
+			clause.Eq{Column: clause.Column{Name: "ToAddress"}, Value: filter.Address},
+		))
+	}
+	if filter.From != nil {
+		query = query.Where(clause.Gte{Column: date, Value: *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(clause.Lte{Column: date, Value: *filter.To})
+	}
+	if filter.After != nil {
+		query = query.Where(clause.Or(
+			clause.Lt{Column: date, Value: filter.After.TransactionDate},
+			clause.And(
+				clause.Eq{Column: date, Value: filter.After.TransactionDate},
+				clause.Lt{Column: id, Value: filter.After.TransactionId},
+			),
+		))
+	}
+
+	err := query.
+		Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+			{Column: date, Desc: true},
+			{Column: id, Desc: true},
+		}}).
+		Limit(filter.Limit).
+		Find(&txs).
+		Error
+
+	return txs, err
+}
+
+// UpdateStatus implements [repositories.TransactionRepository].
+// The change only applies while the transaction is still in fromStatus,
+// so concurrent updates cannot skip a state; otherwise ErrConflict is
+// returned. An empty txHash keeps the stored hash.
+func (r *TransactionRepositoryImpl) UpdateStatus(
+	ctx context.Context,
+	transactionId string,
+	fromStatus string,
+	toStatus string,
+	txHash string,
+) error {
+
+	updates := map[string]interface{}{
+		"Status":     toStatus,
+		"UpdateDate": time.Now(),
+	}
+	if txHash != "" {
+		updates["TxHash"] = txHash
+	}
+
+	result := r.getDB(ctx).
+		Model(&models.Transaction{}).
+		Where(&models.Transaction{TransactionId: transactionId, Status: fromStatus}).
+		Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrConflict
+	}
+	return nil
+}
+
+// UpdateDepositConfirmations implements [repositories.TransactionRepository].
+// It sets the confirmation count of unconfirmed deposits found on chain as
+// of block head, and confirms those that reached required.
+func (r *TransactionRepositoryImpl) UpdateDepositConfirmations(
+	ctx context.Context,
+	chain string,
+	network string,
+	head uint64,
+	required uint64,
+) error {
+
+	confirmations := gorm.Expr(`? - "BlockNumber" + 1`, head)
+
+	db := r.getDB(ctx)
+	return db.
+		Model(&models.Transaction{}).
+		Where(&models.Transaction{
+			Chain:     chain,
+			Direction: models.TransactionDirectionIncoming,
+			Status:    models.TransactionStatusBroadcast,
+		}).
+		Where(clause.Lte{Column: clause.Column{Name: "BlockNumber"}, Value: head}).
+		Where(clause.Expr{SQL: "? IN (?)", Vars: []interface{}{
+			clause.Column{Name: "WalletId"}, walletsOnNetwork(db, chain, network),
+		}}).
+		Updates(map[string]interface{}{
+			"Confirmations": confirmations,
+			"Status": gorm.Expr(
+				`CASE WHEN ? - "BlockNumber" + 1 >= ? THEN ? ELSE "Status" END`,
+				head, required, models.TransactionStatusConfirmed,
+			),
+			"UpdateDate": time.Now(),
+		}).
+		Error
+}
+
+// DeleteDepositsAbove implements [repositories.TransactionRepository].
+// It removes the deposits found in blocks after blockNumber, which a reorg
+// orphaned, and returns them.
+func (r *TransactionRepositoryImpl) DeleteDepositsAbove(
+	ctx context.Context,
+	chain string,
+	network string,
+	blockNumber uint64,
+) ([]models.Transaction, error) {
+
+	var txs []models.Transaction
+
+	db := r.getDB(ctx)
+	err := db.
+		Clauses(clause.Returning{}).
+		Where(&models.Transaction{
+			Chain:     chain,
+			Direction: models.TransactionDirectionIncoming,
+		}).
+		Where(clause.Gt{Column: clause.Column{Name: "BlockNumber"}, Value: blockNumber}).
+		Where(clause.Expr{SQL: "? IN (?)", Vars: []interface{}{
+			clause.Column{Name: "WalletId"}, walletsOnNetwork(db, chain, network),
+		}}).
+		Delete(&txs).
+		Error
+
+	return txs, err
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
 	models "github.com/create-go-app/fiber-go-template/app/entities"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
 	"github.com/create-go-app/fiber-go-template/platform/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WalletRepositoryImpl struct {
@@ -51,6 +55,274 @@ func (r *WalletRepositoryImpl) GetById(
 	return &wallet, nil
 }
 
+// GetByIdAndUser implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) GetByIdAndUser(
+	ctx context.Context,
+	walletId string,
+	userId string,
+) (*models.Wallet, error) {
+
+	var wallet models.Wallet
+
+	err := r.getDB(ctx).
+		Preload("BlockchainAddresses").
+		Where(&models.Wallet{WalletId: walletId, UserId: userId}).
+		First(&wallet).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// GetByIdForUpdate implements [repositories.WalletRepository].
+// The row stays locked until the surrounding transaction ends, so work
+// that reads and then extends the wallet's addresses runs one at a time.
+func (r *WalletRepositoryImpl) GetByIdForUpdate(
+	ctx context.Context,
+	walletId string,
+) (*models.Wallet, error) {
+
+	var wallet models.Wallet
+
+	err := r.getDB(ctx).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where(&models.Wallet{WalletId: walletId}).
+		First(&wallet).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// ListByUser implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) ListByUser(
+	ctx context.Context,
+	userId string,
+) ([]models.Wallet, error) {
+
+	var wallets []models.Wallet
+
+	err := r.getDB(ctx).
+		Preload("BlockchainAddresses").
+		Where(&models.Wallet{UserId: userId}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}, Desc: true}).
+		Find(&wallets).
+		Error
+
+	return wallets, err
+}
+
+// GetByFingerprint implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) GetByFingerprint(
+	ctx context.Context,
+	userId string,
+	fingerprint string,
+) (*models.Wallet, error) {
+
+	var wallet models.Wallet
+
+	err := r.getDB(ctx).
+		Preload("BlockchainAddresses").
+		Where(&models.Wallet{UserId: userId, Fingerprint: fingerprint}).
+		First(&wallet).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// ListWithoutFingerprint implements [repositories.WalletRepository].
+// It returns the user's wallets created before fingerprints were introduced.
+func (r *WalletRepositoryImpl) ListWithoutFingerprint(
+	ctx context.Context,
+	userId string,
+) ([]models.Wallet, error) {
+
+	var wallets []models.Wallet
+
+	err := r.getDB(ctx).
+		Preload("BlockchainAddresses").
+		Where(&models.Wallet{UserId: userId}).
+		Where(clause.Eq{Column: clause.Column{Name: "Fingerprint"}, Value: nil}).
+		Find(&wallets).
+		Error
+
+	return wallets, err
+}
+
+// UpdateFingerprint implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) UpdateFingerprint(
+	ctx context.Context,
+	walletId string,
+	fingerprint string,
+) error {
+
+	return r.getDB(ctx).
+		Model(&models.Wallet{}).
+		Where(&models.Wallet{WalletId: walletId}).
+		Update("Fingerprint", fingerprint).
+		Error
+}
+
+// UpdateEncryptedMnemonic implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) UpdateEncryptedMnemonic(
+	ctx context.Context,
+	walletId string,
+	secretPhraseHash string,
+	wrappedDek string,
+	kekId string,
+) error {
+
+	return r.getDB(ctx).
+		Model(&models.Wallet{}).
+		Where(&models.Wallet{WalletId: walletId}).
+		Updates(map[string]interface{}{
+			"SecretPhraseHash": secretPhraseHash,
+			"WrappedDek":       wrappedDek,
+			"KekId":            kekId,
+			"UpdateDate":       time.Now(),
+		}).
+		Error
+}
+
+// UpdatePassphrase implements [repositories.WalletRepository].
+// The passphrase hash and the mnemonic sealed under it change together.
+func (r *WalletRepositoryImpl) UpdatePassphrase(
+	ctx context.Context,
+	walletId string,
+	passphraseHash string,
+	secretPhraseHash string,
+	wrappedDek string,
+	kekId string,
+) error {
+
+	return r.getDB(ctx).
+		Model(&models.Wallet{}).
+		Where(&models.Wallet{WalletId: walletId}).
+		Updates(map[string]interface{}{
+			"PassphraseHash":   passphraseHash,
+			"SecretPhraseHash": secretPhraseHash,
+			"WrappedDek":       wrappedDek,
+			"KekId":            kekId,
+			"UpdateDate":       time.Now(),
+		}).
+		Error
+}
+
+// UpdateAllowlist implements [repositories.WalletRepository].
+func (r *WalletRepositoryImpl) UpdateAllowlist(
+	ctx context.Context,
+	walletId string,
+	enabled bool,
+	disableDate *time.Time,
+) error {
+
+	return r.getDB(ctx).
+		Model(&models.Wallet{}).
+		Where(&models.Wallet{WalletId: walletId}).
+		Updates(map[string]interface{}{
+			"AllowlistEnabled":     enabled,
+			"AllowlistDisableDate": disableDate,
+			"UpdateDate":           time.Now(),
+		}).
+		Error
+}
+
+// ListNotWrappedBy implements [repositories.WalletRepository].
+// It pages through wallets whose DEK is not wrapped by kekId, including
+// wallets sealed before envelope encryption, in WalletId order. Watch-only
+// wallets have nothing sealed and are left out.
+func (r *WalletRepositoryImpl) ListNotWrappedBy(
+	ctx context.Context,
+	kekId string,
+	afterWalletId string,
+	limit int,
+) ([]models.Wallet, error) {
+
+	var wallets []models.Wallet
+
+	err := r.getDB(ctx).
+		Where(clause.Or(
+			clause.Eq{Column: clause.Column{Name: "KekId"}, Value: nil},
+			clause.Neq{Column: clause.Column{Name: "KekId"}, Value: kekId},
+		)).
+		Where(clause.Eq{Column: clause.Column{Name: "ExtendedPublicKey"}, Value: nil}).
+		Where(clause.Gt{Column: clause.Column{Name: "WalletId"}, Value: afterWalletId}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "WalletId"}}).
+		Limit(limit).
+		Find(&wallets).
+		Error
+
+	return wallets, err
+}
+
+// ListUnowned implements [repositories.WalletRepository].
+// It returns the wallets created before they were bound to a user, oldest
+// first.
+func (r *WalletRepositoryImpl) ListUnowned(
+	ctx context.Context,
+) ([]models.Wallet, error) {
+
+	var wallets []models.Wallet
+
+	err := r.getDB(ctx).
+		Preload("BlockchainAddresses").
+		Where(clause.Eq{Column: clause.Column{Name: "UserId"}, Value: nil}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}}).
+		Find(&wallets).
+		Error
+
+	return wallets, err
+}
+
+// ClaimUnowned implements [repositories.WalletRepository].
+// Only a wallet without an owner can be claimed; an owned or missing
+// wallet is reported as not found.
+func (r *WalletRepositoryImpl) ClaimUnowned(
+	ctx context.Context,
+	walletId string,
+	userId string,
+) error {
+
+	res := r.getDB(ctx).
+		Model(&models.Wallet{}).
+		Where(&models.Wallet{WalletId: walletId}).
+		Where(clause.Eq{Column: clause.Column{Name: "UserId"}, Value: nil}).
+		Updates(map[string]interface{}{
+			"UserId":     userId,
+			"UpdateDate": time.Now(),
+		})
+
+	if errors.Is(res.Error, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
 func NewWalletRepository(db *gorm.DB) repositories.WalletRepository {
 	return &WalletRepositoryImpl{db}
 }
@@ -64,5 +336,9 @@ func (r *WalletRepositoryImpl) Create(
 	ctx context.Context,
 	w *models.Wallet,
 ) error {
-	return r.getDB(ctx).Create(w).Error
+	err := r.getDB(ctx).Create(w).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
 }
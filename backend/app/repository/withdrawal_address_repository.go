@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WithdrawalAddressRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWithdrawalAddressRepository(db *gorm.DB) repositories.WithdrawalAddressRepository {
+	return &WithdrawalAddressRepositoryImpl{db: db}
+}
+
+func (r *WithdrawalAddressRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Create implements [repositories.WithdrawalAddressRepository].
+// An address already in the wallet's address book is a conflict.
+func (r *WithdrawalAddressRepositoryImpl) Create(
+	ctx context.Context,
+	address *models.WithdrawalAddress,
+) error {
+
+	err := r.getDB(ctx).Create(address).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
+
+// ListByWallet implements [repositories.WithdrawalAddressRepository].
+// Entries come oldest first.
+func (r *WithdrawalAddressRepositoryImpl) ListByWallet(
+	ctx context.Context,
+	walletId string,
+) ([]models.WithdrawalAddress, error) {
+
+	var addresses []models.WithdrawalAddress
+
+	err := r.getDB(ctx).
+		Where(&models.WithdrawalAddress{WalletId: walletId}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}}).
+		Find(&addresses).
+		Error
+
+	return addresses, err
+}
+
+// ListByWalletAndChain implements [repositories.WithdrawalAddressRepository].
+func (r *WithdrawalAddressRepositoryImpl) ListByWalletAndChain(
+	ctx context.Context,
+	walletId string,
+	chain string,
+) ([]models.WithdrawalAddress, error) {
+
+	var addresses []models.WithdrawalAddress
+
+	err := r.getDB(ctx).
+		Where(&models.WithdrawalAddress{WalletId: walletId, Chain: chain}).
+		Find(&addresses).
+		Error
+
+	return addresses, err
+}
+
+// Delete implements [repositories.WithdrawalAddressRepository].
+// It returns the removed entry.
+func (r *WithdrawalAddressRepositoryImpl) Delete(
+	ctx context.Context,
+	walletId string,
+	withdrawalAddressId string,
+) (*models.WithdrawalAddress, error) {
+
+	var removed []models.WithdrawalAddress
+
+	err := r.getDB(ctx).
+		Clauses(clause.Returning{}).
+		Where(&models.WithdrawalAddress{WalletId: walletId, WithdrawalAddressId: withdrawalAddressId}).
+		Delete(&removed).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(removed) == 0 {
+		return nil, domainerrors.ErrNotFound
+	}
+
+	return &removed[0], nil
+}
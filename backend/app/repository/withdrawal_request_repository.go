@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WithdrawalRequestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWithdrawalRequestRepository(db *gorm.DB) repositories.WithdrawalRequestRepository {
+	return &WithdrawalRequestRepositoryImpl{db: db}
+}
+
+func (r *WithdrawalRequestRepositoryImpl) getDB(ctx context.Context) *gorm.DB {
+	if tx := database.GetTx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.WithContext(ctx)
+}
+
+// withApprovals loads the decisions of each request in the order they
+// were made.
+func withApprovals(db *gorm.DB) *gorm.DB {
+	return db.Preload("Approvals", func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}})
+	})
+}
+
+// Create implements [repositories.WithdrawalRequestRepository].
+func (r *WithdrawalRequestRepositoryImpl) Create(
+	ctx context.Context,
+	request *models.WithdrawalRequest,
+) error {
+
+	return r.getDB(ctx).Omit(clause.Associations).Create(request).Error
+}
+
+// GetById implements [repositories.WithdrawalRequestRepository].
+func (r *WithdrawalRequestRepositoryImpl) GetById(
+	ctx context.Context,
+	withdrawalRequestId string,
+) (*models.WithdrawalRequest, error) {
+
+	return r.get(withApprovals(r.getDB(ctx)), withdrawalRequestId)
+}
+
+// GetByIdForUpdate implements [repositories.WithdrawalRequestRepository].
+// The row stays locked until the surrounding transaction ends, so
+// decisions on one request are applied one at a time.
+func (r *WithdrawalRequestRepositoryImpl) GetByIdForUpdate(
+	ctx context.Context,
+	withdrawalRequestId string,
+) (*models.WithdrawalRequest, error) {
+
+	db := r.getDB(ctx).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+	return r.get(withApprovals(db), withdrawalRequestId)
+}
+
+func (r *WithdrawalRequestRepositoryImpl) get(
+	db *gorm.DB,
+	withdrawalRequestId string,
+) (*models.WithdrawalRequest, error) {
+
+	var request models.WithdrawalRequest
+
+	err := db.
+		Where(&models.WithdrawalRequest{WithdrawalRequestId: withdrawalRequestId}).
+		First(&request).
+		Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// ListByWallet implements [repositories.WithdrawalRequestRepository].
+// Requests come newest first.
+func (r *WithdrawalRequestRepositoryImpl) ListByWallet(
+	ctx context.Context,
+	walletId string,
+) ([]models.WithdrawalRequest, error) {
+
+	var requests []models.WithdrawalRequest
+
+	err := withApprovals(r.getDB(ctx)).
+		Where(&models.WithdrawalRequest{WalletId: walletId}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}, Desc: true}).
+		Find(&requests).
+		Error
+
+	return requests, err
+}
+
+// ListByStatus implements [repositories.WithdrawalRequestRepository].
+// Requests come oldest first, the order approvers should handle them in.
+func (r *WithdrawalRequestRepositoryImpl) ListByStatus(
+	ctx context.Context,
+	status string,
+) ([]models.WithdrawalRequest, error) {
+
+	var requests []models.WithdrawalRequest
+
+	err := withApprovals(r.getDB(ctx)).
+		Where(&models.WithdrawalRequest{Status: status}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "CreateDate"}}).
+		Find(&requests).
+		Error
+
+	return requests, err
+}
+
+// UpdateStatus implements [repositories.WithdrawalRequestRepository].
+// The change only applies while the request is still in fromStatus;
+// otherwise it is a conflict.
+func (r *WithdrawalRequestRepositoryImpl) UpdateStatus(
+	ctx context.Context,
+	withdrawalRequestId string,
+	fromStatus string,
+	toStatus string,
+	txHash string,
+) error {
+
+	updates := map[string]interface{}{
+		"Status":     toStatus,
+		"UpdateDate": time.Now(),
+	}
+	if txHash != "" {
+		updates["TxHash"] = txHash
+	}
+
+	result := r.getDB(ctx).
+		Model(&models.WithdrawalRequest{}).
+		Where(&models.WithdrawalRequest{WithdrawalRequestId: withdrawalRequestId, Status: fromStatus}).
+		Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrConflict
+	}
+	return nil
+}
+
+// ExpireDue implements [repositories.WithdrawalRequestRepository].
+// Pending and approved requests past their expiry date become expired.
+func (r *WithdrawalRequestRepositoryImpl) ExpireDue(
+	ctx context.Context,
+	now time.Time,
+) error {
+
+	return r.getDB(ctx).
+		Model(&models.WithdrawalRequest{}).
+		Where(clause.IN{
+			Column: clause.Column{Name: "Status"},
+			Values: []interface{}{models.WithdrawalStatusPending, models.WithdrawalStatusApproved},
+		}).
+		Where(clause.Lte{Column: clause.Column{Name: "ExpireDate"}, Value: now}).
+		Updates(map[string]interface{}{
+			"Status":     models.WithdrawalStatusExpired,
+			"UpdateDate": now,
+		}).
+		Error
+}
+
+// CreateApproval implements [repositories.WithdrawalRequestRepository].
+// A second decision by the same approver is a conflict.
+func (r *WithdrawalRequestRepositoryImpl) CreateApproval(
+	ctx context.Context,
+	approval *models.WithdrawalApproval,
+) error {
+
+	err := r.getDB(ctx).Create(approval).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domainerrors.ErrConflict
+	}
+	return err
+}
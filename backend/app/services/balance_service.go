@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// balanceCacheTTL drops cached balances of past blocks. Entries are keyed
+// by the chain head, so a new block already invalidates them.
+const balanceCacheTTL = time.Minute
+
+var balanceCache = cache.NewCacheBuilder("balance")
+
+type BalanceServiceImpl struct {
+	walletRepo   repositories.WalletRepository
+	tokenRepo    repositories.Erc20TokenRepository
+	ethClient    blockchain.ChainClient
+	ethNetwork   *crypto.Network
+	cacheService *cache.CacheService
+}
+
+// NewBalanceService reads Ethereum and ERC-20 balances through ethClient,
+// which serves ethNetwork. Both are nil when no node is configured.
+func NewBalanceService(
+	walletRepo repositories.WalletRepository,
+	tokenRepo repositories.Erc20TokenRepository,
+	ethClient blockchain.ChainClient,
+	ethNetwork *crypto.Network,
+	cacheService *cache.CacheService,
+) services.BalanceService {
+	return &BalanceServiceImpl{
+		walletRepo:   walletRepo,
+		tokenRepo:    tokenRepo,
+		ethClient:    ethClient,
+		ethNetwork:   ethNetwork,
+		cacheService: cacheService,
+	}
+}
+
+// GetBalances implements [services.BalanceService].
+// Balances are cached per chain head, address and asset: repeated reads
+// within a block hit the cache and the next block misses it.
+func (s *BalanceServiceImpl) GetBalances(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Load the wallet
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	asset, err := crypto.NativeAsset(crypto.ChainETH)
+	if err != nil {
+		return core.Error(500, "unsupported chain", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Read every Ethereum address on the wallet's network, in the
+	// native asset and each registered token
+	balances := []dto.BalanceRes{}
+	var (
+		tokens []models.Erc20Token
+		head   uint64
+		loaded bool
+	)
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain != crypto.ChainETH {
+			continue
+		}
+
+		if s.ethClient == nil || s.ethNetwork.Name != wallet.EthNetwork {
+			return core.Error(503, "no ethereum node configured for wallet network", nil, map[string]any{
+				"network": wallet.EthNetwork,
+			}), nil
+		}
+		if !loaded {
+			if tokens, err = s.tokenRepo.ListByNetwork(ctx, crypto.ChainETH, s.ethNetwork.Name); err != nil {
+				return core.Error(500, "cannot load tokens", err.Error(), nil), nil
+			}
+			if head, err = s.ethClient.BlockNumber(ctx); err != nil {
+				return core.Error(502, "cannot read chain head from node", err.Error(), nil), nil
+			}
+			loaded = true
+		}
+
+		owner := common.HexToAddress(addr.Address)
+		balance, err := s.cachedBalance(head, addr.Address, asset.Symbol, asset.Decimals, "", func() (*big.Int, error) {
+			return s.ethClient.BalanceAt(ctx, owner, new(big.Int).SetUint64(head))
+		})
+		if err != nil {
+			return core.Error(502, "cannot read balance from node", err.Error(), map[string]any{
+				"address": addr.Address,
+			}), nil
+		}
+		balances = append(balances, balance)
+
+		for _, token := range tokens {
+			contract := common.HexToAddress(token.ContractAddress)
+			balance, err := s.cachedBalance(head, addr.Address, token.Symbol, token.Decimals, token.ContractAddress, func() (*big.Int, error) {
+				return blockchain.ERC20BalanceOf(ctx, s.ethClient, contract, owner)
+			})
+			if err != nil {
+				return core.Error(502, "cannot read token balance from node", err.Error(), map[string]any{
+					"address": addr.Address,
+					"token":   token.Symbol,
+				}), nil
+			}
+			balances = append(balances, balance)
+		}
+	}
+
+	return core.Success(200, "balances fetched", balances, nil), nil
+}
+
+// cachedBalance reads one balance of address at the head block through
+// the cache. An empty contract means the native asset.
+func (s *BalanceServiceImpl) cachedBalance(
+	head uint64,
+	address string,
+	symbol string,
+	decimals uint8,
+	contract string,
+	fetch func() (*big.Int, error),
+) (dto.BalanceRes, error) {
+
+	block := strconv.FormatUint(head, 10)
+	key := balanceCache.Key(s.ethNetwork.Name, block, address)
+	if contract != "" {
+		key = balanceCache.Key(s.ethNetwork.Name, block, address, contract)
+	}
+
+	return cache.CacheOrFetch(s.cacheService, key, balanceCacheTTL, func() (dto.BalanceRes, error) {
+		units, err := fetch()
+		if err != nil {
+			return dto.BalanceRes{}, err
+		}
+
+		value := amount.New(units)
+		return dto.BalanceRes{
+			Chain:            crypto.ChainETH,
+			Network:          s.ethNetwork.Name,
+			Address:          address,
+			Asset:            symbol,
+			ContractAddress:  contract,
+			Decimals:         decimals,
+			Balance:          value,
+			BalanceFormatted: amount.Format(value, decimals),
+		}, nil
+	})
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+const balanceWalletId = "wallet-balances"
+
+func TestGetBalancesCachesPerBlock(t *testing.T) {
+	chain := newDepositChain(t)
+	node := &countingClient{ChainClient: chain}
+	svc, redisServer := newBalanceService(t, chain, node)
+
+	chain.send(t, depositAddress, big.NewInt(params.Ether))
+	chain.Commit()
+
+	// 1️⃣ The first read misses and asks the node
+	requireBalance(t, svc, big.NewInt(params.Ether))
+	require.EqualValues(t, 1, node.balanceReads.Load())
+	require.True(t, redisServer.Exists(balanceCache.Key("simulated", "1", depositAddress.Hex())))
+
+	// 2️⃣ Reads within the same block hit the cache
+	requireBalance(t, svc, big.NewInt(params.Ether))
+	require.EqualValues(t, 1, node.balanceReads.Load())
+
+	// 3️⃣ A new block invalidates it
+	chain.send(t, depositAddress, big.NewInt(params.Ether))
+	chain.Commit()
+
+	requireBalance(t, svc, big.NewInt(2*params.Ether))
+	require.EqualValues(t, 2, node.balanceReads.Load())
+}
+
+func TestGetBalancesIncludesRegisteredTokens(t *testing.T) {
+	chain := newDepositChain(t)
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	require.NoError(t, chain.tokens.Create(context.Background(), &models.Erc20Token{
+		Chain:           crypto.ChainETH,
+		Network:         chain.network.Name,
+		ContractAddress: usdc.Hex(),
+		Symbol:          "USDC",
+		Decimals:        6,
+	}))
+	node := &countingClient{ChainClient: chain, tokenBalance: big.NewInt(1_500_000)}
+	svc, redisServer := newBalanceService(t, chain, node)
+	chain.Commit()
+
+	res, err := svc.GetBalances(context.Background(), testUserId, balanceWalletId)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+
+	// The native balance comes first, then one entry per token
+	balances := res.Data.([]dto.BalanceRes)
+	require.Len(t, balances, 2)
+	require.Equal(t, "ETH", balances[0].Asset)
+	require.Empty(t, balances[0].ContractAddress)
+
+	token := balances[1]
+	require.Equal(t, "USDC", token.Asset)
+	require.Equal(t, usdc.Hex(), token.ContractAddress)
+	require.EqualValues(t, 6, token.Decimals)
+	require.Equal(t, "1500000", token.Balance.String())
+	require.Equal(t, "1.5", token.BalanceFormatted)
+	require.Equal(t, usdc, *node.tokenCall.To)
+	require.True(t, redisServer.Exists(balanceCache.Key("simulated", "1", depositAddress.Hex(), usdc.Hex())))
+}
+
+func TestGetBalancesReportsNodeErrors(t *testing.T) {
+	ctx := context.Background()
+	chain := newDepositChain(t)
+	errDown := errors.New("connection refused")
+
+	t.Run("chain head unavailable", func(t *testing.T) {
+		node := &countingClient{ChainClient: chain, blockNumberErr: errDown}
+		svc, _ := newBalanceService(t, chain, node)
+
+		res, err := svc.GetBalances(ctx, testUserId, balanceWalletId)
+		require.NoError(t, err)
+		require.Equal(t, 502, res.Code)
+		require.Equal(t, "cannot read chain head from node", res.Message)
+		require.Zero(t, node.balanceReads.Load())
+	})
+
+	t.Run("balance unavailable", func(t *testing.T) {
+		node := &countingClient{ChainClient: chain, balanceErr: errDown}
+		svc, redisServer := newBalanceService(t, chain, node)
+
+		res, err := svc.GetBalances(ctx, testUserId, balanceWalletId)
+		require.NoError(t, err)
+		require.Equal(t, 502, res.Code)
+		require.Equal(t, "cannot read balance from node", res.Message)
+
+		// Failures are not cached
+		require.Empty(t, redisServer.Keys())
+	})
+
+	t.Run("no node for the wallet network", func(t *testing.T) {
+		svc := NewBalanceService(balanceWallets("sepolia"), &memoryTokens{}, chain, chain.network, newTestCache(t, miniredis.RunT(t)))
+
+		res, err := svc.GetBalances(ctx, testUserId, balanceWalletId)
+		require.NoError(t, err)
+		require.Equal(t, 503, res.Code)
+	})
+}
+
+// countingClient counts balance reads and fails the calls it is told to.
+// Contract calls are answered with tokenBalance.
+type countingClient struct {
+	blockchain.ChainClient
+
+	balanceReads   atomic.Int64
+	blockNumberErr error
+	balanceErr     error
+	tokenBalance   *big.Int
+	tokenCall      ethereum.CallMsg
+}
+
+func (c *countingClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if c.blockNumberErr != nil {
+		return 0, c.blockNumberErr
+	}
+	return c.ChainClient.BlockNumber(ctx)
+}
+
+func (c *countingClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if c.balanceErr != nil {
+		return nil, c.balanceErr
+	}
+	c.balanceReads.Add(1)
+	return c.ChainClient.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *countingClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.tokenCall = call
+	return common.BigToHash(c.tokenBalance).Bytes(), nil
+}
+
+// newBalanceService reads the balances of depositAddress on chain, in
+// ether and chain's tokens, through node, caching in a fresh in-memory Redis.
+func newBalanceService(t *testing.T, chain *depositChain, node blockchain.ChainClient) (*BalanceServiceImpl, *miniredis.Miniredis) {
+	t.Helper()
+
+	redisServer := miniredis.RunT(t)
+	svc := NewBalanceService(balanceWallets(chain.network.Name), chain.tokens, node, chain.network, newTestCache(t, redisServer))
+	return svc.(*BalanceServiceImpl), redisServer
+}
+
+// balanceWallets holds one wallet on network owning depositAddress.
+func balanceWallets(network string) repositories.WalletRepository {
+	return newMemoryStore(&models.Wallet{
+		WalletId:   balanceWalletId,
+		UserId:     testUserId,
+		EthNetwork: network,
+		BlockchainAddresses: []models.BlockchainAddress{
+			{WalletId: balanceWalletId, Chain: crypto.ChainETH, Address: depositAddress.Hex()},
+		},
+	}).walletRepo()
+}
+
+// newTestCache returns a cache service over an in-memory Redis server.
+func newTestCache(t *testing.T, server *miniredis.Miniredis) *cache.CacheService {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return cache.NewCacheServiceWithClient(context.Background(), client)
+}
+
+func requireBalance(t *testing.T, svc *BalanceServiceImpl, want *big.Int) {
+	t.Helper()
+
+	res, err := svc.GetBalances(context.Background(), testUserId, balanceWalletId)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+
+	balances := res.Data.([]dto.BalanceRes)
+	require.Len(t, balances, 1)
+	require.Equal(t, want.String(), balances[0].Balance.String())
+}
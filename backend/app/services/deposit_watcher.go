@@ -0,0 +1,552 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Deposit polls run as messages on a per-network queue, consumed by a
+// [cache.Worker] through [DepositWatcher.HandlePoll].
+const (
+	DepositQueue   = "deposit_queue"
+	DepositPollMsg = "deposit.poll"
+)
+
+// depositPollCache holds, per network, the run whose poll messages are
+// current.
+var depositPollCache = cache.NewCacheBuilder("deposit_poll")
+
+// DepositQueueName returns the queue polling network.
+func DepositQueueName(network string) string {
+	return DepositQueue + ":" + network
+}
+
+// ErrReorgTooDeep is returned when none of the stored recent blocks is
+// still on the canonical chain, so the fork point is unknown.
+var ErrReorgTooDeep = errors.New("reorg deeper than stored blocks")
+
+// DepositWatcherConfig tunes a [DepositWatcher]. Zero values fall back to
+// the defaults below.
+type DepositWatcherConfig struct {
+	// Confirmations is the depth at which a deposit becomes confirmed.
+	// A depth of 1 confirms deposits in the block they are mined in.
+	Confirmations uint64
+	// ReorgDepth is how many recent block hashes are kept to find the
+	// fork point of a reorg. It is at least Confirmations.
+	ReorgDepth uint64
+	// PollInterval is the time between the end of a poll and the next.
+	PollInterval time.Duration
+	// BatchSize caps the blocks scanned per poll, so a long catch-up
+	// still checkpoints and updates confirmations regularly.
+	BatchSize uint64
+	// StartBlock is scanned first when there is no checkpoint yet.
+	// Nil starts at the chain head.
+	StartBlock *uint64
+}
+
+const (
+	defaultDepositConfirmations = 12
+	defaultDepositReorgDepth    = 64
+	defaultDepositPollInterval  = 12 * time.Second
+	defaultDepositBatchSize     = 100
+)
+
+func (c DepositWatcherConfig) withDefaults() DepositWatcherConfig {
+	if c.Confirmations == 0 {
+		c.Confirmations = defaultDepositConfirmations
+	}
+	if c.ReorgDepth == 0 {
+		c.ReorgDepth = defaultDepositReorgDepth
+	}
+	if c.ReorgDepth < c.Confirmations {
+		c.ReorgDepth = c.Confirmations
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = defaultDepositPollInterval
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultDepositBatchSize
+	}
+	return c
+}
+
+// DepositWatcher follows new Ethereum blocks and records transfers of
+// ether and of registered ERC-20 tokens to any address of a wallet on the
+// client's network as incoming transactions. Deposits start as "broadcast" and become "confirmed" at
+// the configured depth; deposits in blocks orphaned by a reorg are
+// removed again.
+//
+// Each block is recorded together with its deposits in one database
+// transaction, and the highest recorded block is the checkpoint a restart
+// resumes from. Two watchers on the same network cannot both record a
+// block, since the second insert of a block conflicts and rolls back.
+//
+// Polls are scheduled through the message queue: [DepositWatcher.Schedule]
+// enqueues the first one and every poll enqueues the next, so one poll
+// runs at a time on the network whichever worker picks it up.
+//
+// Ether is only seen in top-level transfers, not when moved by contract
+// calls; tokens are seen through their Transfer logs.
+type DepositWatcher struct {
+	ethClient        blockchain.ChainClient
+	network          *crypto.Network
+	addressRepo      repositories.BlockchainAddressRepository
+	txRepo           repositories.TransactionRepository
+	scannedBlockRepo repositories.ScannedBlockRepository
+	tokenRepo        repositories.Erc20TokenRepository
+	txManager        repositories.TransactionManager
+	queue            *cache.MessageQueue
+	cacheService     *cache.CacheService
+	config           DepositWatcherConfig
+}
+
+// NewDepositWatcher watches the chain of ethClient, which serves network.
+func NewDepositWatcher(
+	ethClient blockchain.ChainClient,
+	network *crypto.Network,
+	addressRepo repositories.BlockchainAddressRepository,
+	txRepo repositories.TransactionRepository,
+	scannedBlockRepo repositories.ScannedBlockRepository,
+	tokenRepo repositories.Erc20TokenRepository,
+	txManager repositories.TransactionManager,
+	queue *cache.MessageQueue,
+	cacheService *cache.CacheService,
+	config DepositWatcherConfig,
+) *DepositWatcher {
+	return &DepositWatcher{
+		ethClient:        ethClient,
+		network:          network,
+		addressRepo:      addressRepo,
+		txRepo:           txRepo,
+		scannedBlockRepo: scannedBlockRepo,
+		tokenRepo:        tokenRepo,
+		txManager:        txManager,
+		queue:            queue,
+		cacheService:     cacheService,
+		config:           config.withDefaults(),
+	}
+}
+
+// Schedule starts a new run of polls with one due now. A run started
+// earlier, by this process or another, ends at its next message.
+func (w *DepositWatcher) Schedule() error {
+	run := uuid.New().String()
+	if err := w.cacheService.Set(depositPollCache.Key(w.network.Name), run, 0); err != nil {
+		return fmt.Errorf("start poll run: %w", err)
+	}
+
+	return w.queue.Enqueue(DepositQueueName(w.network.Name), DepositPollMsg, map[string]interface{}{
+		"run": run,
+	}, nil)
+}
+
+// HandlePoll is the [cache.MessageHandler] of poll messages. It polls,
+// then enqueues the next poll of the run after the poll interval. Poll
+// errors are logged and left to the next poll; only a failure to enqueue
+// is returned, for the worker to retry the message.
+func (w *DepositWatcher) HandlePoll(msgType string, payload []byte) error {
+	var msg struct {
+		Run string `json:"run"`
+	}
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return fmt.Errorf("decode poll message: %w", err)
+	}
+
+	current, err := w.isCurrentRun(msg.Run)
+	if err != nil {
+		return err
+	}
+	if !current {
+		return nil
+	}
+
+	if err := w.Poll(context.Background()); err != nil {
+		log.Printf("deposit watcher (%s): %v", w.network.Name, err)
+	}
+
+	return w.queue.EnqueueDelayed(DepositQueueName(w.network.Name), DepositPollMsg, map[string]interface{}{
+		"run": msg.Run,
+	}, w.config.PollInterval, nil)
+}
+
+// isCurrentRun reports whether poll messages of run are still due. When
+// the marker was lost, the first run to notice takes over.
+func (w *DepositWatcher) isCurrentRun(run string) (bool, error) {
+	key := depositPollCache.Key(w.network.Name)
+
+	var current string
+	err := w.cacheService.GetStruct(key, &current)
+	if errors.Is(err, redis.Nil) {
+		return w.cacheService.SetNX(key, run, 0)
+	}
+	if err != nil {
+		return false, fmt.Errorf("load poll run: %w", err)
+	}
+
+	return current == run, nil
+}
+
+// Poll scans the blocks after the checkpoint, up to the chain head or the
+// batch size, then updates the confirmations of pending deposits.
+func (w *DepositWatcher) Poll(ctx context.Context) error {
+	// 1️⃣ Find where to resume
+	head, err := w.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("read head: %w", err)
+	}
+
+	checkpoint, err := w.scannedBlockRepo.Latest(ctx, w.network.Chain, w.network.Name)
+	if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
+		return fmt.Errorf("load checkpoint: %w", err)
+	}
+
+	next := head
+	if checkpoint != nil {
+		next = checkpoint.BlockNumber + 1
+	} else if w.config.StartBlock != nil {
+		next = *w.config.StartBlock
+	}
+
+	watch, err := w.loadWatchList(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 2️⃣ Scan new blocks, rolling back first if the chain forked
+	for scanned := uint64(0); next <= head && scanned < w.config.BatchSize; scanned++ {
+		block, err := w.ethClient.BlockByNumber(ctx, new(big.Int).SetUint64(next))
+		if err != nil {
+			return fmt.Errorf("read block %d: %w", next, err)
+		}
+
+		if checkpoint != nil && block.ParentHash().Hex() != checkpoint.BlockHash {
+			ancestor, err := w.rollback(ctx, checkpoint.BlockNumber)
+			if err != nil {
+				return err
+			}
+			if checkpoint, err = w.scannedBlockRepo.Latest(ctx, w.network.Chain, w.network.Name); err != nil {
+				return fmt.Errorf("load checkpoint: %w", err)
+			}
+			next = ancestor + 1
+			continue
+		}
+
+		if checkpoint, err = w.scanBlock(ctx, block, watch); err != nil {
+			return err
+		}
+		next++
+	}
+
+	if checkpoint == nil {
+		return nil
+	}
+
+	// 3️⃣ Confirm deposits that are deep enough
+	if err := w.txRepo.UpdateDepositConfirmations(
+		ctx, w.network.Chain, w.network.Name, checkpoint.BlockNumber, w.config.Confirmations,
+	); err != nil {
+		return fmt.Errorf("update confirmations: %w", err)
+	}
+
+	// 4️⃣ Forget block hashes older than the reorg window
+	if checkpoint.BlockNumber > w.config.ReorgDepth {
+		if err := w.scannedBlockRepo.DeleteBelow(
+			ctx, w.network.Chain, w.network.Name, checkpoint.BlockNumber-w.config.ReorgDepth,
+		); err != nil {
+			return fmt.Errorf("prune scanned blocks: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// watchList is what a poll looks for: the wallets holding each address
+// on the network, and the registered tokens by contract.
+type watchList struct {
+	addresses map[common.Address][]string
+	tokens    map[common.Address]models.Erc20Token
+}
+
+// loadWatchList reads the addresses and tokens of the network. A wallet
+// imported twice holds the same address, so an address may map to several
+// wallets.
+func (w *DepositWatcher) loadWatchList(ctx context.Context) (*watchList, error) {
+	addrs, err := w.addressRepo.ListByNetwork(ctx, w.network.Chain, w.network.Name)
+	if err != nil {
+		return nil, fmt.Errorf("load addresses: %w", err)
+	}
+	tokens, err := w.tokenRepo.ListByNetwork(ctx, w.network.Chain, w.network.Name)
+	if err != nil {
+		return nil, fmt.Errorf("load tokens: %w", err)
+	}
+
+	watch := &watchList{
+		addresses: make(map[common.Address][]string, len(addrs)),
+		tokens:    make(map[common.Address]models.Erc20Token, len(tokens)),
+	}
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr.Address) {
+			continue
+		}
+		key := common.HexToAddress(addr.Address)
+		watch.addresses[key] = append(watch.addresses[key], addr.WalletId)
+	}
+	for _, token := range tokens {
+		watch.tokens[common.HexToAddress(token.ContractAddress)] = token
+	}
+	return watch, nil
+}
+
+// scanBlock records the deposits of block and the block itself, which
+// becomes the new checkpoint.
+func (w *DepositWatcher) scanBlock(
+	ctx context.Context,
+	block *types.Block,
+	watch *watchList,
+) (*models.ScannedBlock, error) {
+
+	deposits, err := w.nativeDeposits(ctx, block, watch)
+	if err != nil {
+		return nil, err
+	}
+	tokenDeposits, err := w.tokenDeposits(ctx, block, watch)
+	if err != nil {
+		return nil, err
+	}
+	deposits = append(deposits, tokenDeposits...)
+
+	scanned := &models.ScannedBlock{
+		Chain:       w.network.Chain,
+		Network:     w.network.Name,
+		BlockNumber: block.NumberU64(),
+		BlockHash:   block.Hash().Hex(),
+		ParentHash:  block.ParentHash().Hex(),
+		CreateDate:  time.Now(),
+	}
+
+	err = w.txManager.Do(ctx, func(ctx context.Context) error {
+		for i := range deposits {
+			if err := w.txRepo.Create(ctx, &deposits[i]); err != nil {
+				return err
+			}
+		}
+		return w.scannedBlockRepo.Create(ctx, scanned)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record block %d: %w", block.NumberU64(), err)
+	}
+
+	for _, deposit := range deposits {
+		log.Printf(
+			"deposit %s of %s %s to %s in block %d",
+			deposit.TxHash, amount.Format(deposit.Amount, deposit.Decimals), deposit.Asset,
+			deposit.ToAddress, deposit.BlockNumber,
+		)
+	}
+
+	return scanned, nil
+}
+
+// nativeDeposits returns a deposit per watched wallet for each successful
+// transfer of ether to a watched address in block.
+func (w *DepositWatcher) nativeDeposits(
+	ctx context.Context,
+	block *types.Block,
+	watch *watchList,
+) ([]models.Transaction, error) {
+
+	asset, err := crypto.NativeAsset(w.network.Chain)
+	if err != nil {
+		return nil, err
+	}
+	signer := types.LatestSignerForChainID(w.network.ChainID)
+
+	deposits := []models.Transaction{}
+	for _, tx := range block.Transactions() {
+		if tx.To() == nil || tx.Value().Sign() == 0 {
+			continue
+		}
+		walletIds, ok := watch.addresses[*tx.To()]
+		if !ok {
+			continue
+		}
+
+		receipt, err := w.ethClient.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("read receipt %s: %w", tx.Hash().Hex(), err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("recover sender %s: %w", tx.Hash().Hex(), err)
+		}
+
+		for _, walletId := range walletIds {
+			deposits = append(deposits, w.newDeposit(block, walletId, tx.Hash(), from, *tx.To(), tx.Value(), asset, ""))
+		}
+	}
+
+	return deposits, nil
+}
+
+// tokenDeposits returns a deposit per watched wallet for each ERC-20
+// Transfer of a registered token to a watched address in block.
+func (w *DepositWatcher) tokenDeposits(
+	ctx context.Context,
+	block *types.Block,
+	watch *watchList,
+) ([]models.Transaction, error) {
+
+	if len(watch.tokens) == 0 {
+		return nil, nil
+	}
+
+	contracts := make([]common.Address, 0, len(watch.tokens))
+	for contract := range watch.tokens {
+		contracts = append(contracts, contract)
+	}
+
+	blockHash := block.Hash()
+	logs, err := w.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		BlockHash: &blockHash,
+		Addresses: contracts,
+		Topics:    [][]common.Hash{{blockchain.ERC20TransferTopic}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read logs of block %d: %w", block.NumberU64(), err)
+	}
+
+	deposits := []models.Transaction{}
+	for _, entry := range logs {
+		if entry.Removed {
+			continue
+		}
+		transfer, err := blockchain.ParseERC20Transfer(entry)
+		if err != nil || transfer.Value.Sign() == 0 {
+			continue
+		}
+		walletIds, ok := watch.addresses[transfer.To]
+		if !ok {
+			continue
+		}
+
+		token := watch.tokens[transfer.Token]
+		asset := crypto.Asset{Symbol: token.Symbol, Decimals: token.Decimals}
+		for _, walletId := range walletIds {
+			deposits = append(deposits, w.newDeposit(
+				block, walletId, entry.TxHash, transfer.From, transfer.To, transfer.Value, asset, token.ContractAddress,
+			))
+		}
+	}
+
+	return deposits, nil
+}
+
+// newDeposit builds the ledger entry of a transfer found in block. An
+// empty contract means the native asset.
+func (w *DepositWatcher) newDeposit(
+	block *types.Block,
+	walletId string,
+	txHash common.Hash,
+	from common.Address,
+	to common.Address,
+	value *big.Int,
+	asset crypto.Asset,
+	contract string,
+) models.Transaction {
+
+	now := time.Now()
+	return models.Transaction{
+		TransactionId:   uuid.New().String(),
+		WalletId:        walletId,
+		Chain:           w.network.Chain,
+		Direction:       models.TransactionDirectionIncoming,
+		FromAddress:     from.Hex(),
+		ToAddress:       to.Hex(),
+		Amount:          amount.New(value),
+		Asset:           asset.Symbol,
+		Decimals:        asset.Decimals,
+		ContractAddress: contract,
+		TxHash:          txHash.Hex(),
+		BlockNumber:     block.NumberU64(),
+		BlockHash:       block.Hash().Hex(),
+		Confirmations:   1,
+		TransactionDate: time.Unix(int64(block.Time()), 0),
+		Status:          models.TransactionStatusBroadcast,
+		CreateDate:      now,
+		UpdateDate:      now,
+	}
+}
+
+// rollback finds the newest stored block below from that is still on the
+// canonical chain, then removes every block and deposit above it.
+func (w *DepositWatcher) rollback(ctx context.Context, from uint64) (uint64, error) {
+	var low uint64
+	if from > w.config.ReorgDepth {
+		low = from - w.config.ReorgDepth
+	}
+
+	stored, err := w.scannedBlockRepo.ListFrom(ctx, w.network.Chain, w.network.Name, low)
+	if err != nil {
+		return 0, fmt.Errorf("load scanned blocks: %w", err)
+	}
+
+	ancestor, found := uint64(0), false
+	for _, block := range stored {
+		header, err := w.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(block.BlockNumber))
+		if err != nil {
+			return 0, fmt.Errorf("read header %d: %w", block.BlockNumber, err)
+		}
+		if header.Hash().Hex() == block.BlockHash {
+			ancestor, found = block.BlockNumber, true
+			break
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: no match since block %d", ErrReorgTooDeep, low)
+	}
+
+	var orphaned []models.Transaction
+	err = w.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if orphaned, err = w.txRepo.DeleteDepositsAbove(ctx, w.network.Chain, w.network.Name, ancestor); err != nil {
+			return err
+		}
+		return w.scannedBlockRepo.DeleteAbove(ctx, w.network.Chain, w.network.Name, ancestor)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("roll back to block %d: %w", ancestor, err)
+	}
+
+	log.Printf("reorg on %s: rolled back %d blocks to %d", w.network.Name, from-ancestor, ancestor)
+	for _, deposit := range orphaned {
+		if deposit.Status == models.TransactionStatusConfirmed {
+			log.Printf("WARNING: confirmed deposit %s in block %d was orphaned", deposit.TxHash, deposit.BlockNumber)
+		} else {
+			log.Printf("deposit %s in block %d was orphaned", deposit.TxHash, deposit.BlockNumber)
+		}
+	}
+
+	return ancestor, nil
+}
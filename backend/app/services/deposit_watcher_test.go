@@ -0,0 +1,576 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+const depositWalletId = "wallet-1"
+
+var depositAddress = common.HexToAddress("0x00000000000000000000000000000000000d3b05")
+
+func TestDepositWatcherConfirmsDeposits(t *testing.T) {
+	ctx := context.Background()
+	chain := newDepositChain(t)
+	watcher, ledger := chain.watcher(3)
+
+	// 1️⃣ The deposit is recorded as soon as it is mined
+	chain.Commit()
+	chain.send(t, depositAddress, big.NewInt(params.Ether))
+	block := chain.Commit()
+
+	require.NoError(t, watcher.Poll(ctx))
+	deposits := ledger.deposits()
+	require.Len(t, deposits, 1)
+	require.Equal(t, depositWalletId, deposits[0].WalletId)
+	require.Equal(t, depositAddress.Hex(), deposits[0].ToAddress)
+	require.Equal(t, "1000000000000000000", deposits[0].Amount.String())
+	require.Equal(t, block.Hex(), deposits[0].BlockHash)
+	require.Equal(t, uint64(1), deposits[0].Confirmations)
+	require.Equal(t, models.TransactionStatusBroadcast, deposits[0].Status)
+	ledger.requireCheckpoint(t, 2, block)
+
+	// 2️⃣ It becomes confirmed at the configured depth
+	chain.Commit()
+	require.NoError(t, watcher.Poll(ctx))
+	require.Equal(t, models.TransactionStatusBroadcast, ledger.deposits()[0].Status)
+
+	head := chain.Commit()
+	require.NoError(t, watcher.Poll(ctx))
+	deposits = ledger.deposits()
+	require.Equal(t, uint64(3), deposits[0].Confirmations)
+	require.Equal(t, models.TransactionStatusConfirmed, deposits[0].Status)
+	ledger.requireCheckpoint(t, 4, head)
+}
+
+func TestDepositWatcherRollsBackReorg(t *testing.T) {
+	ctx := context.Background()
+	chain := newDepositChain(t)
+	watcher, ledger := chain.watcher(3)
+
+	// 1️⃣ Block 2 holds a deposit
+	ancestor := chain.Commit()
+	chain.send(t, depositAddress, big.NewInt(params.Ether))
+	orphaned := chain.Commit()
+
+	require.NoError(t, watcher.Poll(ctx))
+	require.Len(t, ledger.deposits(), 1)
+	ledger.requireCheckpoint(t, 2, orphaned)
+
+	// 2️⃣ A longer fork from block 1 replaces block 2. The pool puts the
+	// orphaned transfer back, so the fork mines it again in a new block 2.
+	require.NoError(t, chain.Backend.Fork(ancestor))
+	chain.Commit()
+	head := chain.Commit()
+	require.NotEqual(t, orphaned, chain.hashAt(t, 2))
+
+	require.NoError(t, watcher.Poll(ctx))
+
+	// 3️⃣ The deposit of the orphaned block is gone, the one on the new
+	// chain is recorded once, and the checkpoint follows the new chain
+	deposits := ledger.deposits()
+	require.Len(t, deposits, 1)
+	require.Equal(t, chain.hashAt(t, 2).Hex(), deposits[0].BlockHash)
+	require.Equal(t, uint64(2), deposits[0].Confirmations)
+	ledger.requireCheckpoint(t, 3, head)
+
+	blocks := ledger.scannedBlocks()
+	require.Len(t, blocks, 3)
+	for _, block := range blocks {
+		require.NotEqual(t, orphaned.Hex(), block.BlockHash)
+	}
+}
+
+func TestDepositWatcherResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	chain := newDepositChain(t)
+	watcher, ledger := chain.watcher(1)
+
+	chain.Commit()
+	require.NoError(t, watcher.Poll(ctx))
+	ledger.requireCheckpoint(t, 1, chain.hashAt(t, 1))
+
+	// A new watcher over the same ledger picks up after the checkpoint
+	// and records deposits in later blocks only once
+	chain.send(t, depositAddress, big.NewInt(params.GWei))
+	block := chain.Commit()
+
+	restarted := chain.watcherOver(ledger, 1)
+	require.NoError(t, restarted.Poll(ctx))
+	require.NoError(t, restarted.Poll(ctx))
+
+	deposits := ledger.deposits()
+	require.Len(t, deposits, 1)
+	require.Equal(t, block.Hex(), deposits[0].BlockHash)
+	require.Equal(t, models.TransactionStatusConfirmed, deposits[0].Status)
+	ledger.requireCheckpoint(t, 2, block)
+}
+
+func TestDepositWatcherSchedulesPolls(t *testing.T) {
+	chain := newDepositChain(t)
+	watcher, ledger := chain.watcher(1)
+	watcher.config.PollInterval = 0
+	queue := DepositQueueName(chain.network.Name)
+
+	// 1️⃣ Scheduling queues a poll due now
+	require.NoError(t, watcher.Schedule())
+	first := chain.nextPoll(t)
+
+	// 2️⃣ Handling it polls and queues the next poll of the run
+	chain.send(t, depositAddress, big.NewInt(params.GWei))
+	chain.Commit()
+	require.NoError(t, watcher.HandlePoll(DepositPollMsg, first))
+	require.Len(t, ledger.deposits(), 1)
+
+	members, err := chain.redis.ZMembers(queue + ":delayed")
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	second := chain.nextPoll(t)
+
+	// 3️⃣ A new schedule, e.g. after a restart, supersedes the earlier run:
+	// its message neither polls nor queues another
+	restarted := chain.watcherOver(ledger, 1)
+	require.NoError(t, restarted.Schedule())
+
+	chain.send(t, depositAddress, big.NewInt(params.GWei))
+	chain.Commit()
+	require.NoError(t, watcher.HandlePoll(DepositPollMsg, second))
+	require.Len(t, ledger.deposits(), 1)
+	require.False(t, chain.redis.Exists(queue+":delayed"))
+
+	require.NoError(t, restarted.HandlePoll(DepositPollMsg, chain.nextPoll(t)))
+	require.Len(t, ledger.deposits(), 2)
+}
+
+func TestDepositWatcherRecordsTokenTransfers(t *testing.T) {
+	ctx := context.Background()
+	chain := newDepositChain(t)
+	sender := ethcrypto.PubkeyToAddress(chain.key.PublicKey)
+
+	chain.Commit()
+	usdc := chain.deployToken(t)
+	unregistered := chain.deployToken(t)
+	require.NoError(t, chain.tokens.Create(ctx, &models.Erc20Token{
+		Chain:           crypto.ChainETH,
+		Network:         chain.network.Name,
+		ContractAddress: usdc.Hex(),
+		Symbol:          "USDC",
+		Decimals:        6,
+	}))
+	watcher, ledger := chain.watcher(1)
+
+	// Only Transfers of registered tokens to watched addresses are deposits
+	chain.sendToken(t, usdc, depositAddress, big.NewInt(2_500_000))
+	chain.sendToken(t, usdc, common.HexToAddress(ledgerPayee), big.NewInt(1))
+	chain.sendToken(t, unregistered, depositAddress, big.NewInt(1))
+	block := chain.Commit()
+
+	require.NoError(t, watcher.Poll(ctx))
+	deposits := ledger.deposits()
+	require.Len(t, deposits, 1)
+	require.Equal(t, "USDC", deposits[0].Asset)
+	require.EqualValues(t, 6, deposits[0].Decimals)
+	require.Equal(t, "2500000", deposits[0].Amount.String())
+	require.Equal(t, usdc.Hex(), deposits[0].ContractAddress)
+	require.Equal(t, sender.Hex(), deposits[0].FromAddress)
+	require.Equal(t, depositAddress.Hex(), deposits[0].ToAddress)
+	require.Equal(t, block.Hex(), deposits[0].BlockHash)
+	require.Equal(t, models.TransactionStatusConfirmed, deposits[0].Status)
+}
+
+// depositChain is a simulated chain with a funded sender, and the Redis
+// its watchers schedule polls on.
+type depositChain struct {
+	*blockchain.SimulatedClient
+	network *crypto.Network
+	key     *ecdsa.PrivateKey
+	nonce   uint64
+	redis   *miniredis.Miniredis
+	client  *redis.Client
+	tokens  *memoryTokens
+}
+
+func newDepositChain(t *testing.T) *depositChain {
+	t.Helper()
+
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	client := blockchain.NewSimulatedClient(types.GenesisAlloc{
+		ethcrypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+	})
+	t.Cleanup(client.Close)
+
+	redisServer := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: redisServer.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	return &depositChain{
+		SimulatedClient: client,
+		network: &crypto.Network{
+			Chain:   crypto.ChainETH,
+			Name:    "simulated",
+			ChainID: big.NewInt(blockchain.SimulatedChainID),
+		},
+		key:    key,
+		redis:  redisServer,
+		client: redisClient,
+		tokens: &memoryTokens{},
+	}
+}
+
+// watcher returns a watcher starting at block 1 over a new ledger.
+func (c *depositChain) watcher(confirmations uint64) (*DepositWatcher, *memoryLedger) {
+	ledger := newMemoryLedger(c.network, models.BlockchainAddress{
+		WalletId: depositWalletId,
+		Address:  depositAddress.Hex(),
+		Chain:    crypto.ChainETH,
+	})
+	return c.watcherOver(ledger, confirmations), ledger
+}
+
+func (c *depositChain) watcherOver(ledger *memoryLedger, confirmations uint64) *DepositWatcher {
+	start := uint64(1)
+	return NewDepositWatcher(
+		c, c.network,
+		ledger, ledger.transactions(), ledger.blocks(), c.tokens, ledger,
+		cache.NewMessageQueueWithClient(context.Background(), c.client),
+		cache.NewCacheServiceWithClient(context.Background(), c.client),
+		DepositWatcherConfig{Confirmations: confirmations, ReorgDepth: 8, StartBlock: &start},
+	)
+}
+
+// nextPoll takes the poll message due now off the queue and returns its
+// payload as a worker would hand it to the handler.
+func (c *depositChain) nextPoll(t *testing.T) []byte {
+	t.Helper()
+
+	queue := cache.NewMessageQueueWithClient(context.Background(), c.client)
+
+	require.NoError(t, queue.ProcessDelayedMessages(DepositQueueName(c.network.Name)))
+	message, err := queue.DequeueNonBlocking(DepositQueueName(c.network.Name))
+	require.NoError(t, err)
+	require.Equal(t, DepositPollMsg, message.Type)
+
+	payload, err := json.Marshal(message.Payload)
+	require.NoError(t, err)
+	return payload
+}
+
+// send submits a transfer of value wei from the funded sender.
+func (c *depositChain) send(t *testing.T, to common.Address, value *big.Int) {
+	t.Helper()
+	c.transact(t, &to, value, params.TxGas, nil)
+}
+
+// transact submits a transaction from the funded sender; a nil to
+// deploys data as a contract.
+func (c *depositChain) transact(t *testing.T, to *common.Address, value *big.Int, gas uint64, data []byte) common.Hash {
+	t.Helper()
+
+	tx, err := types.SignNewTx(c.key, types.LatestSignerForChainID(c.network.ChainID), &types.DynamicFeeTx{
+		ChainID:   c.network.ChainID,
+		Nonce:     c.nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	require.NoError(t, err)
+
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	hash, err := c.SendRawTransaction(context.Background(), raw)
+	require.NoError(t, err)
+	c.nonce++
+	return hash
+}
+
+// deployToken mines a stand-in token contract. Called with a 32-byte
+// recipient and a 32-byte value, it emits the ERC-20 Transfer event of
+// that value from the caller to the recipient; it keeps no balances.
+func (c *depositChain) deployToken(t *testing.T) common.Address {
+	t.Helper()
+
+	runtime := []byte{
+		0x60, 0x20, 0x35, 0x60, 0x00, 0x52, // mstore(0, calldataload(32))
+		0x60, 0x00, 0x35, // topic 2: calldataload(0)
+		0x33, // topic 1: caller
+		0x7f, // topic 0: push32 Transfer
+	}
+	runtime = append(runtime, blockchain.ERC20TransferTopic.Bytes()...)
+	runtime = append(runtime,
+		0x60, 0x20, 0x60, 0x00, 0xa3, // log3(0, 32, ...)
+		0x00, // stop
+	)
+	initCode := []byte{
+		0x60, byte(len(runtime)), 0x80, // size, size
+		0x60, 0x0b, 0x60, 0x00, 0x39, // codecopy(0, 11, size)
+		0x60, 0x00, 0xf3, // return(0, size)
+	}
+
+	hash := c.transact(t, nil, new(big.Int), 200_000, append(initCode, runtime...))
+	c.Commit()
+
+	receipt, err := c.TransactionReceipt(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	return receipt.ContractAddress
+}
+
+// sendToken makes token emit a Transfer of value from the sender to to.
+func (c *depositChain) sendToken(t *testing.T, token, to common.Address, value *big.Int) {
+	t.Helper()
+
+	data := append(common.BytesToHash(to.Bytes()).Bytes(), common.BigToHash(value).Bytes()...)
+	c.transact(t, &token, new(big.Int), 100_000, data)
+}
+
+func (c *depositChain) hashAt(t *testing.T, number uint64) common.Hash {
+	t.Helper()
+	header, err := c.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	require.NoError(t, err)
+	return header.Hash()
+}
+
+// memoryLedger keeps the watcher's addresses, deposits and checkpoints in
+// memory. Repository methods the watcher does not use are left to the
+// embedded interfaces and panic if called.
+type memoryLedger struct {
+	repositories.BlockchainAddressRepository
+
+	mu        sync.Mutex
+	network   *crypto.Network
+	addresses []models.BlockchainAddress
+	txs       []models.Transaction
+	scanned   []models.ScannedBlock
+}
+
+func newMemoryLedger(network *crypto.Network, addresses ...models.BlockchainAddress) *memoryLedger {
+	return &memoryLedger{network: network, addresses: addresses}
+}
+
+func (l *memoryLedger) transactions() repositories.TransactionRepository {
+	return &memoryTransactions{ledger: l}
+}
+
+func (l *memoryLedger) blocks() repositories.ScannedBlockRepository {
+	return &memoryScannedBlocks{ledger: l}
+}
+
+func (l *memoryLedger) deposits() []models.Transaction {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]models.Transaction(nil), l.txs...)
+}
+
+func (l *memoryLedger) scannedBlocks() []models.ScannedBlock {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]models.ScannedBlock(nil), l.scanned...)
+}
+
+func (l *memoryLedger) requireCheckpoint(t *testing.T, number uint64, hash common.Hash) {
+	t.Helper()
+	checkpoint, err := l.blocks().Latest(context.Background(), l.network.Chain, l.network.Name)
+	require.NoError(t, err)
+	require.Equal(t, number, checkpoint.BlockNumber)
+	require.Equal(t, hash.Hex(), checkpoint.BlockHash)
+}
+
+// ListByNetwork implements [repositories.BlockchainAddressRepository].
+func (l *memoryLedger) ListByNetwork(ctx context.Context, chain, network string) ([]models.BlockchainAddress, error) {
+	return l.addresses, nil
+}
+
+// Do implements [repositories.TransactionManager]. Nothing is rolled
+// back; the watcher only relies on the calls running in order.
+func (l *memoryLedger) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// memoryTokens is a token registry in memory. The zero value registers
+// no tokens, so only ether deposits are found.
+type memoryTokens struct {
+	repositories.Erc20TokenRepository
+
+	mu     sync.Mutex
+	tokens []models.Erc20Token
+}
+
+func (r *memoryTokens) Create(ctx context.Context, token *models.Erc20Token) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range r.tokens {
+		if t.Chain == token.Chain && t.Network == token.Network &&
+			(t.ContractAddress == token.ContractAddress || t.Symbol == token.Symbol) {
+			return domainerrors.ErrConflict
+		}
+	}
+	r.tokens = append(r.tokens, *token)
+	return nil
+}
+
+func (r *memoryTokens) ListByNetwork(ctx context.Context, chain, network string) ([]models.Erc20Token, error) {
+	return r.find(func(t *models.Erc20Token) bool {
+		return t.Chain == chain && t.Network == network
+	}), nil
+}
+
+func (r *memoryTokens) GetBySymbol(ctx context.Context, chain, network, symbol string) (*models.Erc20Token, error) {
+	return r.first(r.find(func(t *models.Erc20Token) bool {
+		return t.Chain == chain && t.Network == network && t.Symbol == symbol
+	}))
+}
+
+func (r *memoryTokens) GetByContract(ctx context.Context, chain, network, contract string) (*models.Erc20Token, error) {
+	return r.first(r.find(func(t *models.Erc20Token) bool {
+		return t.Chain == chain && t.Network == network && strings.EqualFold(t.ContractAddress, contract)
+	}))
+}
+
+func (r *memoryTokens) find(match func(t *models.Erc20Token) bool) []models.Erc20Token {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tokens []models.Erc20Token
+	for i := range r.tokens {
+		if match(&r.tokens[i]) {
+			tokens = append(tokens, r.tokens[i])
+		}
+	}
+	return tokens
+}
+
+func (r *memoryTokens) first(tokens []models.Erc20Token) (*models.Erc20Token, error) {
+	if len(tokens) == 0 {
+		return nil, domainerrors.ErrNotFound
+	}
+	return &tokens[0], nil
+}
+
+type memoryTransactions struct {
+	repositories.TransactionRepository
+	ledger *memoryLedger
+}
+
+func (r *memoryTransactions) Create(ctx context.Context, tx *models.Transaction) error {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	r.ledger.txs = append(r.ledger.txs, *tx)
+	return nil
+}
+
+func (r *memoryTransactions) UpdateDepositConfirmations(ctx context.Context, chain, network string, head, required uint64) error {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	for i := range r.ledger.txs {
+		tx := &r.ledger.txs[i]
+		if tx.Status != models.TransactionStatusBroadcast || tx.BlockNumber > head {
+			continue
+		}
+		tx.Confirmations = head - tx.BlockNumber + 1
+		if tx.Confirmations >= required {
+			tx.Status = models.TransactionStatusConfirmed
+		}
+	}
+	return nil
+}
+
+func (r *memoryTransactions) DeleteDepositsAbove(ctx context.Context, chain, network string, blockNumber uint64) ([]models.Transaction, error) {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	var kept, removed []models.Transaction
+	for _, tx := range r.ledger.txs {
+		if tx.BlockNumber > blockNumber {
+			removed = append(removed, tx)
+		} else {
+			kept = append(kept, tx)
+		}
+	}
+	r.ledger.txs = kept
+	return removed, nil
+}
+
+type memoryScannedBlocks struct {
+	ledger *memoryLedger
+}
+
+func (r *memoryScannedBlocks) Create(ctx context.Context, block *models.ScannedBlock) error {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	for _, scanned := range r.ledger.scanned {
+		if scanned.BlockNumber == block.BlockNumber {
+			return domainerrors.ErrConflict
+		}
+	}
+	r.ledger.scanned = append(r.ledger.scanned, *block)
+	sort.Slice(r.ledger.scanned, func(i, j int) bool {
+		return r.ledger.scanned[i].BlockNumber > r.ledger.scanned[j].BlockNumber
+	})
+	return nil
+}
+
+func (r *memoryScannedBlocks) Latest(ctx context.Context, chain, network string) (*models.ScannedBlock, error) {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	if len(r.ledger.scanned) == 0 {
+		return nil, domainerrors.ErrNotFound
+	}
+	latest := r.ledger.scanned[0]
+	return &latest, nil
+}
+
+func (r *memoryScannedBlocks) ListFrom(ctx context.Context, chain, network string, fromBlock uint64) ([]models.ScannedBlock, error) {
+	return r.filter(func(block models.ScannedBlock) bool { return block.BlockNumber >= fromBlock }), nil
+}
+
+func (r *memoryScannedBlocks) DeleteAbove(ctx context.Context, chain, network string, blockNumber uint64) error {
+	r.keep(func(block models.ScannedBlock) bool { return block.BlockNumber <= blockNumber })
+	return nil
+}
+
+func (r *memoryScannedBlocks) DeleteBelow(ctx context.Context, chain, network string, blockNumber uint64) error {
+	r.keep(func(block models.ScannedBlock) bool { return block.BlockNumber >= blockNumber })
+	return nil
+}
+
+func (r *memoryScannedBlocks) filter(match func(models.ScannedBlock) bool) []models.ScannedBlock {
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	var blocks []models.ScannedBlock
+	for _, block := range r.ledger.scanned {
+		if match(block) {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func (r *memoryScannedBlocks) keep(match func(models.ScannedBlock) bool) {
+	kept := r.filter(match)
+	r.ledger.mu.Lock()
+	defer r.ledger.mu.Unlock()
+	r.ledger.scanned = kept
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+type Erc20TokenServiceImpl struct {
+	tokenRepo repositories.Erc20TokenRepository
+}
+
+func NewErc20TokenService(tokenRepo repositories.Erc20TokenRepository) services.Erc20TokenService {
+	return &Erc20TokenServiceImpl{tokenRepo: tokenRepo}
+}
+
+// ListTokens implements [services.Erc20TokenService].
+// Without a network filter the mainnet registry is listed.
+func (s *Erc20TokenServiceImpl) ListTokens(
+	ctx context.Context,
+	req *dto.ListErc20TokensReq,
+) (*core.ApiResponse, error) {
+
+	network := req.Network
+	if network == "" {
+		network = crypto.NetworkMainnet
+	}
+
+	tokens, err := s.tokenRepo.ListByNetwork(ctx, crypto.ChainETH, network)
+	if err != nil {
+		return core.Error(500, "cannot load tokens", err.Error(), nil), nil
+	}
+
+	res := make([]dto.Erc20TokenRes, 0, len(tokens))
+	for i := range tokens {
+		res = append(res, toErc20TokenRes(&tokens[i]))
+	}
+
+	return core.Success(200, "tokens", res, nil), nil
+}
+
+// CreateToken implements [services.Erc20TokenService].
+func (s *Erc20TokenServiceImpl) CreateToken(
+	ctx context.Context,
+	req *dto.CreateErc20TokenReq,
+) (*core.ApiResponse, error) {
+
+	now := time.Now()
+	token := &models.Erc20Token{
+		TokenId:         uuid.New().String(),
+		Chain:           crypto.ChainETH,
+		Network:         req.Network,
+		ContractAddress: common.HexToAddress(req.ContractAddress).Hex(),
+		Symbol:          req.Symbol,
+		Name:            req.Name,
+		Decimals:        *req.Decimals,
+		CreateDate:      now,
+		UpdateDate:      now,
+	}
+
+	err := s.tokenRepo.Create(ctx, token)
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "token already registered", nil, map[string]any{
+			"network":          token.Network,
+			"contract_address": token.ContractAddress,
+			"symbol":           token.Symbol,
+		}), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot register token", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "token registered", toErc20TokenRes(token), nil), nil
+}
+
+func toErc20TokenRes(token *models.Erc20Token) dto.Erc20TokenRes {
+	return dto.Erc20TokenRes{
+		TokenId:         token.TokenId,
+		Chain:           token.Chain,
+		Network:         token.Network,
+		ContractAddress: token.ContractAddress,
+		Symbol:          token.Symbol,
+		Name:            token.Name,
+		Decimals:        token.Decimals,
+		CreateDate:      token.CreateDate,
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErc20TokenRegistry(t *testing.T) {
+	ctx := context.Background()
+	svc := NewErc20TokenService(&memoryTokens{})
+	register := func(network, contract, symbol string) *dto.CreateErc20TokenReq {
+		decimals := uint8(6)
+		return &dto.CreateErc20TokenReq{
+			Network:         network,
+			ContractAddress: contract,
+			Symbol:          symbol,
+			Name:            symbol + " Coin",
+			Decimals:        &decimals,
+		}
+	}
+
+	// 1️⃣ Contract addresses are stored checksummed
+	res, err := svc.CreateToken(ctx, register(crypto.NetworkMainnet, strings.ToLower(mainnetUSDC), "USDC"))
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	created := res.Data.(dto.Erc20TokenRes)
+	require.Equal(t, mainnetUSDC, created.ContractAddress)
+	require.NotEmpty(t, created.TokenId)
+
+	// 2️⃣ A contract or symbol is registered once per network
+	for _, req := range []*dto.CreateErc20TokenReq{
+		register(crypto.NetworkMainnet, mainnetUSDC, "USDC2"),
+		register(crypto.NetworkMainnet, ledgerPayee, "USDC"),
+	} {
+		res, err := svc.CreateToken(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, 409, res.Code)
+		require.Equal(t, "token already registered", res.Message)
+	}
+
+	res, err = svc.CreateToken(ctx, register("sepolia", mainnetUSDC, "USDC"))
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+
+	// 3️⃣ Listing is per network, mainnet by default
+	for network, want := range map[string]int{"": 1, crypto.NetworkMainnet: 1, "sepolia": 1, "holesky": 0} {
+		res, err := svc.ListTokens(ctx, &dto.ListErc20TokensReq{Network: network})
+		require.NoError(t, err)
+		require.Equal(t, 200, res.Code)
+		require.Len(t, res.Data.([]dto.Erc20TokenRes), want, network)
+	}
+}
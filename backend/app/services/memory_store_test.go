@@ -0,0 +1,461 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+)
+
+// memoryStore keeps wallets, their addresses, withdrawal allowlists and
+// requests, and audit events in memory for service tests. Repositories are views on it;
+// methods a test does not use are left to the embedded interfaces and
+// panic if called.
+type memoryStore struct {
+	mu          sync.Mutex
+	wallets     map[string]*models.Wallet
+	addresses   []models.BlockchainAddress
+	withdrawals []models.WithdrawalAddress
+	requests    map[string]*models.WithdrawalRequest
+	events      []models.AuditEvent
+	rowLocks    map[string]*sync.Mutex
+
+	// legacyScans counts listings of wallets without a fingerprint.
+	legacyScans int
+}
+
+func newMemoryStore(wallets ...*models.Wallet) *memoryStore {
+	s := &memoryStore{
+		wallets:  map[string]*models.Wallet{},
+		requests: map[string]*models.WithdrawalRequest{},
+		rowLocks: map[string]*sync.Mutex{},
+	}
+	for _, w := range wallets {
+		s.addresses = append(s.addresses, w.BlockchainAddresses...)
+		stored := *w
+		stored.BlockchainAddresses = nil
+		s.wallets[w.WalletId] = &stored
+	}
+	return s
+}
+
+func (s *memoryStore) walletRepo() repositories.WalletRepository {
+	return &memoryWallets{store: s}
+}
+
+func (s *memoryStore) addressRepo() repositories.BlockchainAddressRepository {
+	return &memoryAddresses{store: s}
+}
+
+func (s *memoryStore) withdrawalRepo() repositories.WithdrawalAddressRepository {
+	return &memoryWithdrawalAddresses{store: s}
+}
+
+func (s *memoryStore) withdrawalRequestRepo() repositories.WithdrawalRequestRepository {
+	return &memoryWithdrawalRequests{store: s}
+}
+
+func (s *memoryStore) auditRepo() repositories.AuditEventRepository {
+	return &memoryAudit{store: s}
+}
+
+// auditActions lists the actions audited so far, oldest first.
+func (s *memoryStore) auditActions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	actions := make([]string, 0, len(s.events))
+	for _, e := range s.events {
+		actions = append(actions, e.Action)
+	}
+	return actions
+}
+
+// memoryTx holds the row locks taken inside one [memoryStore.Do].
+type memoryTx struct {
+	locks []*sync.Mutex
+}
+
+type memoryTxKey struct{}
+
+// Do implements [repositories.TransactionManager]. Row locks taken by fn
+// are released when it returns, as a commit or rollback would; nothing
+// is rolled back. A nested call joins the outer transaction.
+func (s *memoryStore) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(memoryTxKey{}).(*memoryTx); ok {
+		return fn(ctx)
+	}
+
+	tx := &memoryTx{}
+	defer func() {
+		for _, lock := range tx.locks {
+			lock.Unlock()
+		}
+	}()
+	return fn(context.WithValue(ctx, memoryTxKey{}, tx))
+}
+
+// lockRow blocks until the row named key is free, then holds it until
+// the transaction in ctx ends.
+func (s *memoryStore) lockRow(ctx context.Context, key string) error {
+	tx, ok := ctx.Value(memoryTxKey{}).(*memoryTx)
+	if !ok {
+		return errors.New("row lock outside a transaction")
+	}
+
+	s.mu.Lock()
+	lock, ok := s.rowLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.rowLocks[key] = lock
+	}
+	s.mu.Unlock()
+
+	lock.Lock()
+	tx.locks = append(tx.locks, lock)
+	return nil
+}
+
+// wallet returns a copy of a stored wallet with its addresses.
+// Callers must hold s.mu.
+func (s *memoryStore) wallet(walletId string) (*models.Wallet, error) {
+	w, ok := s.wallets[walletId]
+	if !ok {
+		return nil, domainerrors.ErrNotFound
+	}
+	copied := *w
+	for _, addr := range s.addresses {
+		if addr.WalletId == walletId {
+			copied.BlockchainAddresses = append(copied.BlockchainAddresses, addr)
+		}
+	}
+	return &copied, nil
+}
+
+type memoryWallets struct {
+	repositories.WalletRepository
+	store *memoryStore
+}
+
+func (r *memoryWallets) Create(ctx context.Context, wallet *models.Wallet) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, w := range r.store.wallets {
+		if w.WalletId == wallet.WalletId ||
+			(wallet.Fingerprint != "" && w.UserId == wallet.UserId && w.Fingerprint == wallet.Fingerprint) {
+			return domainerrors.ErrConflict
+		}
+	}
+	stored := *wallet
+	stored.BlockchainAddresses = nil
+	r.store.wallets[wallet.WalletId] = &stored
+	return nil
+}
+
+func (r *memoryWallets) GetById(ctx context.Context, walletId string) (*models.Wallet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return r.store.wallet(walletId)
+}
+
+func (r *memoryWallets) GetByIdAndUser(ctx context.Context, walletId, userId string) (*models.Wallet, error) {
+	w, err := r.GetById(ctx, walletId)
+	if err != nil {
+		return nil, err
+	}
+	if w.UserId != userId {
+		return nil, domainerrors.ErrNotFound
+	}
+	return w, nil
+}
+
+func (r *memoryWallets) ListByUser(ctx context.Context, userId string) ([]models.Wallet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var wallets []models.Wallet
+	for id, w := range r.store.wallets {
+		if w.UserId == userId {
+			wallet, _ := r.store.wallet(id)
+			wallets = append(wallets, *wallet)
+		}
+	}
+	return wallets, nil
+}
+
+func (r *memoryWallets) GetByFingerprint(ctx context.Context, userId, fingerprint string) (*models.Wallet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for id, w := range r.store.wallets {
+		if w.UserId == userId && w.Fingerprint == fingerprint {
+			return r.store.wallet(id)
+		}
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (r *memoryWallets) ListWithoutFingerprint(ctx context.Context, userId string) ([]models.Wallet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.legacyScans++
+	var wallets []models.Wallet
+	for id, w := range r.store.wallets {
+		if w.UserId == userId && w.Fingerprint == "" {
+			wallet, _ := r.store.wallet(id)
+			wallets = append(wallets, *wallet)
+		}
+	}
+	return wallets, nil
+}
+
+func (r *memoryWallets) UpdateFingerprint(ctx context.Context, walletId, fingerprint string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.wallets[walletId].Fingerprint = fingerprint
+	return nil
+}
+
+func (r *memoryWallets) UpdatePassphrase(ctx context.Context, walletId, passphraseHash, secretPhraseHash, wrappedDek, kekId string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	w := r.store.wallets[walletId]
+	w.PassphraseHash = passphraseHash
+	w.SecretPhraseHash = secretPhraseHash
+	w.WrappedDek = wrappedDek
+	w.KekId = kekId
+	return nil
+}
+
+func (r *memoryWallets) UpdateAllowlist(ctx context.Context, walletId string, enabled bool, disableDate *time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	w := r.store.wallets[walletId]
+	w.AllowlistEnabled = enabled
+	w.AllowlistDisableDate = disableDate
+	return nil
+}
+
+func (r *memoryWallets) GetByIdForUpdate(ctx context.Context, walletId string) (*models.Wallet, error) {
+	if err := r.store.lockRow(ctx, "Wallets/"+walletId); err != nil {
+		return nil, err
+	}
+	return r.GetById(ctx, walletId)
+}
+
+func (r *memoryWallets) ListUnowned(ctx context.Context) ([]models.Wallet, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var wallets []models.Wallet
+	for id, w := range r.store.wallets {
+		if w.UserId == "" {
+			wallet, _ := r.store.wallet(id)
+			wallets = append(wallets, *wallet)
+		}
+	}
+	return wallets, nil
+}
+
+func (r *memoryWallets) ClaimUnowned(ctx context.Context, walletId, userId string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	w, ok := r.store.wallets[walletId]
+	if !ok || w.UserId != "" {
+		return domainerrors.ErrNotFound
+	}
+	for _, other := range r.store.wallets {
+		if other.UserId == userId && w.Fingerprint != "" && other.Fingerprint == w.Fingerprint {
+			return domainerrors.ErrConflict
+		}
+	}
+	w.UserId = userId
+	return nil
+}
+
+type memoryAddresses struct {
+	repositories.BlockchainAddressRepository
+	store *memoryStore
+}
+
+func (r *memoryAddresses) Create(ctx context.Context, addr *models.BlockchainAddress) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, a := range r.store.addresses {
+		if a.WalletId == addr.WalletId && a.DerivationPath == addr.DerivationPath {
+			return domainerrors.ErrConflict
+		}
+	}
+	r.store.addresses = append(r.store.addresses, *addr)
+	return nil
+}
+
+// NextIndex yields before returning, like a round trip to the database
+// would, so derivations that do not serialize interleave.
+func (r *memoryAddresses) NextIndex(ctx context.Context, walletId, chain string, purpose, account, change uint32) (uint32, error) {
+	r.store.mu.Lock()
+	var next uint32
+	for _, a := range r.store.addresses {
+		if a.WalletId == walletId && a.Chain == chain &&
+			a.Purpose == purpose && a.Account == account && a.Change == change &&
+			a.AddressIndex >= next {
+			next = a.AddressIndex + 1
+		}
+	}
+	r.store.mu.Unlock()
+
+	runtime.Gosched()
+	return next, nil
+}
+
+type memoryWithdrawalAddresses struct {
+	store *memoryStore
+}
+
+func (r *memoryWithdrawalAddresses) Create(ctx context.Context, address *models.WithdrawalAddress) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, a := range r.store.withdrawals {
+		if a.WalletId == address.WalletId && a.Chain == address.Chain && a.Address == address.Address {
+			return domainerrors.ErrConflict
+		}
+	}
+	r.store.withdrawals = append(r.store.withdrawals, *address)
+	return nil
+}
+
+func (r *memoryWithdrawalAddresses) ListByWallet(ctx context.Context, walletId string) ([]models.WithdrawalAddress, error) {
+	return r.list(func(a *models.WithdrawalAddress) bool { return a.WalletId == walletId }), nil
+}
+
+func (r *memoryWithdrawalAddresses) ListByWalletAndChain(ctx context.Context, walletId, chain string) ([]models.WithdrawalAddress, error) {
+	return r.list(func(a *models.WithdrawalAddress) bool { return a.WalletId == walletId && a.Chain == chain }), nil
+}
+
+func (r *memoryWithdrawalAddresses) Delete(ctx context.Context, walletId, withdrawalAddressId string) (*models.WithdrawalAddress, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for i, a := range r.store.withdrawals {
+		if a.WalletId == walletId && a.WithdrawalAddressId == withdrawalAddressId {
+			r.store.withdrawals = append(r.store.withdrawals[:i], r.store.withdrawals[i+1:]...)
+			return &a, nil
+		}
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (r *memoryWithdrawalAddresses) list(match func(a *models.WithdrawalAddress) bool) []models.WithdrawalAddress {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var entries []models.WithdrawalAddress
+	for i := range r.store.withdrawals {
+		if match(&r.store.withdrawals[i]) {
+			entries = append(entries, r.store.withdrawals[i])
+		}
+	}
+	return entries
+}
+
+type memoryWithdrawalRequests struct {
+	store *memoryStore
+}
+
+func (r *memoryWithdrawalRequests) Create(ctx context.Context, request *models.WithdrawalRequest) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	stored := *request
+	r.store.requests[request.WithdrawalRequestId] = &stored
+	return nil
+}
+
+func (r *memoryWithdrawalRequests) GetById(ctx context.Context, withdrawalRequestId string) (*models.WithdrawalRequest, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	request, ok := r.store.requests[withdrawalRequestId]
+	if !ok {
+		return nil, domainerrors.ErrNotFound
+	}
+	copied := *request
+	copied.Approvals = append([]models.WithdrawalApproval(nil), request.Approvals...)
+	return &copied, nil
+}
+
+func (r *memoryWithdrawalRequests) GetByIdForUpdate(ctx context.Context, withdrawalRequestId string) (*models.WithdrawalRequest, error) {
+	if err := r.store.lockRow(ctx, "WithdrawalRequests/"+withdrawalRequestId); err != nil {
+		return nil, err
+	}
+	return r.GetById(ctx, withdrawalRequestId)
+}
+
+func (r *memoryWithdrawalRequests) ListByWallet(ctx context.Context, walletId string) ([]models.WithdrawalRequest, error) {
+	return r.list(func(request *models.WithdrawalRequest) bool { return request.WalletId == walletId }), nil
+}
+
+func (r *memoryWithdrawalRequests) ListByStatus(ctx context.Context, status string) ([]models.WithdrawalRequest, error) {
+	return r.list(func(request *models.WithdrawalRequest) bool { return request.Status == status }), nil
+}
+
+func (r *memoryWithdrawalRequests) UpdateStatus(ctx context.Context, withdrawalRequestId, fromStatus, toStatus, txHash string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	request, ok := r.store.requests[withdrawalRequestId]
+	if !ok || request.Status != fromStatus {
+		return domainerrors.ErrConflict
+	}
+	request.Status = toStatus
+	request.UpdateDate = time.Now()
+	if txHash != "" {
+		request.TxHash = txHash
+	}
+	return nil
+}
+
+func (r *memoryWithdrawalRequests) ExpireDue(ctx context.Context, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, request := range r.store.requests {
+		if (request.Status == models.WithdrawalStatusPending || request.Status == models.WithdrawalStatusApproved) &&
+			!request.ExpireDate.After(now) {
+			request.Status = models.WithdrawalStatusExpired
+			request.UpdateDate = now
+		}
+	}
+	return nil
+}
+
+func (r *memoryWithdrawalRequests) CreateApproval(ctx context.Context, approval *models.WithdrawalApproval) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	request := r.store.requests[approval.WithdrawalRequestId]
+	for _, a := range request.Approvals {
+		if a.ApproverId == approval.ApproverId {
+			return domainerrors.ErrConflict
+		}
+	}
+	request.Approvals = append(request.Approvals, *approval)
+	return nil
+}
+
+func (r *memoryWithdrawalRequests) list(match func(request *models.WithdrawalRequest) bool) []models.WithdrawalRequest {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var requests []models.WithdrawalRequest
+	for _, request := range r.store.requests {
+		if match(request) {
+			requests = append(requests, *request)
+		}
+	}
+	return requests
+}
+
+type memoryAudit struct {
+	store *memoryStore
+}
+
+func (r *memoryAudit) Create(ctx context.Context, event *models.AuditEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.events = append(r.store.events, *event)
+	return nil
+}
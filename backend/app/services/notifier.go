@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+)
+
+// Notification queue, consumed by the workers that deliver messages.
+const (
+	NotificationQueue   = "notification_queue"
+	NotificationSendMsg = "notification.send"
+)
+
+// QueueNotifier hands notifications to the Redis message queue, addressed
+// to the user's email.
+type QueueNotifier struct {
+	queue    *cache.MessageQueue
+	userRepo repositories.UserRepository
+}
+
+func NewQueueNotifier(queue *cache.MessageQueue, userRepo repositories.UserRepository) services.Notifier {
+	return &QueueNotifier{queue: queue, userRepo: userRepo}
+}
+
+// Notify implements [services.Notifier].
+func (n *QueueNotifier) Notify(
+	ctx context.Context,
+	userId string,
+	event string,
+	details map[string]any,
+) error {
+
+	user, err := n.userRepo.GetUserByID(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("load user %s: %w", userId, err)
+	}
+
+	return n.queue.Enqueue(NotificationQueue, NotificationSendMsg, map[string]interface{}{
+		"user_id": userId,
+		"email":   user.Email,
+		"event":   event,
+		"details": details,
+	}, nil)
+}
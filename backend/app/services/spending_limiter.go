@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// spendingRetention is the longest rolling window; older spending is
+// dropped from the counters.
+const spendingRetention = 7 * 24 * time.Hour
+
+var spendingKeys = cache.NewCacheBuilder("spending")
+
+// Each counter is a sorted set of the spending of the last week, one
+// member "<reservation>:<amount>" per signing, scored by its time in Unix
+// ms. Amounts are decimal strings of base units: wei overflow both Redis
+// integers and Lua numbers, so they are added up digit by digit.
+//
+// reserve checks every cap against the spending of its counter within
+// the cap's window, and records the amounts only if all caps hold. It
+// returns 0, or the 1-based index of the first cap exceeded and what was
+// already spent against it.
+var reserveSpendingScript = redis.NewScript(`
+local function add(a, b)
+	local digits, carry = {}, 0
+	local i, j = #a, #b
+	while i > 0 or j > 0 or carry > 0 do
+		local d = carry
+		if i > 0 then d = d + a:byte(i) - 48; i = i - 1 end
+		if j > 0 then d = d + b:byte(j) - 48; j = j - 1 end
+		digits[#digits + 1] = d % 10
+		carry = math.floor(d / 10)
+	end
+	local s = {}
+	for k = #digits, 1, -1 do s[#s + 1] = tostring(digits[k]) end
+	s = table.concat(s):gsub('^0+', '')
+	if s == '' then return '0' end
+	return s
+end
+
+local function greater(a, b)
+	if #a ~= #b then return #a > #b end
+	return a > b
+end
+
+local now = tonumber(ARGV[1])
+local caps = tonumber(ARGV[4])
+local amounts = 4 + caps * 3
+
+for i = 1, #KEYS do
+	redis.call('ZREMRANGEBYSCORE', KEYS[i], '-inf', now - tonumber(ARGV[3]))
+end
+
+for c = 1, caps do
+	local base = 2 + c * 3
+	local key = tonumber(ARGV[base])
+	local used = '0'
+	local since = '(' .. (now - tonumber(ARGV[base + 1]))
+	for _, member in ipairs(redis.call('ZRANGEBYSCORE', KEYS[key], since, '+inf')) do
+		used = add(used, member:match(':(%d+)$'))
+	end
+	if greater(add(used, ARGV[amounts + key]), ARGV[base + 2]) then
+		return {c, used}
+	end
+end
+
+for i = 1, #KEYS do
+	redis.call('ZADD', KEYS[i], now, ARGV[2] .. ':' .. ARGV[amounts + i])
+	redis.call('PEXPIRE', KEYS[i], ARGV[3])
+end
+return 0
+`)
+
+// release takes back the spending of a reservation.
+var releaseSpendingScript = redis.NewScript(`
+for i = 1, #KEYS do
+	redis.call('ZREM', KEYS[i], ARGV[i])
+end
+return 0
+`)
+
+// SpendingUse is an amount signed for, to be added to a counter.
+type SpendingUse struct {
+	Counter string
+	Amount  amount.Int
+}
+
+// SpendingCap limits what a counter may reach within a rolling window.
+type SpendingCap struct {
+	Counter string
+	Window  time.Duration
+	Limit   amount.Int
+}
+
+// SpendingReservation is spending recorded by Reserve.
+type SpendingReservation struct {
+	keys    []string
+	members []string
+}
+
+// SpendingLimiter keeps rolling spending counters in Redis, so velocity
+// limits hold across API replicas. A reservation is checked and recorded
+// by one Lua script, so concurrent signings cannot both slip under a cap.
+type SpendingLimiter struct {
+	cacheService *cache.CacheService
+}
+
+func NewSpendingLimiter(cacheService *cache.CacheService) *SpendingLimiter {
+	return &SpendingLimiter{cacheService: cacheService}
+}
+
+// Reserve records uses unless that takes a counter over one of caps.
+// Counters are scoped by tag, and every counter of a call must share it;
+// it is the Redis Cluster hash tag. When a cap would be exceeded nothing
+// is recorded, and the cap and what it had already used are returned.
+func (l *SpendingLimiter) Reserve(
+	ctx context.Context,
+	tag string,
+	uses []SpendingUse,
+	caps []SpendingCap,
+) (*SpendingReservation, *SpendingCap, amount.Int, error) {
+
+	if len(uses) == 0 {
+		return &SpendingReservation{}, nil, amount.Int{}, nil
+	}
+
+	// Uses of one counter are recorded together
+	var counters []string
+	totals := make(map[string]amount.Int, len(uses))
+	for _, use := range uses {
+		if _, ok := totals[use.Counter]; !ok {
+			counters = append(counters, use.Counter)
+		}
+		totals[use.Counter] = totals[use.Counter].Add(use.Amount)
+	}
+
+	id := uuid.New().String()
+	reservation := &SpendingReservation{}
+	index := make(map[string]int, len(counters))
+	for i, counter := range counters {
+		index[counter] = i + 1
+		reservation.keys = append(reservation.keys, l.key(tag, counter))
+		reservation.members = append(reservation.members, id+":"+totals[counter].String())
+	}
+
+	args := []interface{}{time.Now().UnixMilli(), id, spendingRetention.Milliseconds(), 0}
+	var checked []SpendingCap
+	for _, limit := range caps {
+		if _, ok := index[limit.Counter]; !ok {
+			continue
+		}
+		checked = append(checked, limit)
+		args = append(args, index[limit.Counter], limit.Window.Milliseconds(), limit.Limit.String())
+	}
+	args[3] = len(checked)
+	for _, counter := range counters {
+		args = append(args, totals[counter].String())
+	}
+
+	res, err := l.cacheService.RunScript(ctx, reserveSpendingScript, reservation.keys, args...).Result()
+	if err != nil {
+		return nil, nil, amount.Int{}, fmt.Errorf("reserve spending: %w", err)
+	}
+
+	exceeded, ok := res.([]interface{})
+	if !ok {
+		return reservation, nil, amount.Int{}, nil
+	}
+	if len(exceeded) != 2 {
+		return nil, nil, amount.Int{}, fmt.Errorf("reserve spending: unexpected reply %v", res)
+	}
+	c, _ := exceeded[0].(int64)
+	usedStr, _ := exceeded[1].(string)
+	used, err := amount.Parse(usedStr)
+	if err != nil || c < 1 || int(c) > len(checked) {
+		return nil, nil, amount.Int{}, fmt.Errorf("reserve spending: unexpected reply %v", res)
+	}
+	return nil, &checked[c-1], used, nil
+}
+
+// Release takes back a reservation whose signing failed.
+func (l *SpendingLimiter) Release(ctx context.Context, reservation *SpendingReservation) error {
+	if reservation == nil || len(reservation.keys) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(reservation.members))
+	for i, member := range reservation.members {
+		members[i] = member
+	}
+	if err := l.cacheService.RunScript(ctx, releaseSpendingScript, reservation.keys, members...).Err(); err != nil {
+		return fmt.Errorf("release spending: %w", err)
+	}
+	return nil
+}
+
+// key returns the Redis key of a counter. The braces make Redis Cluster
+// place all counters of a tag in the same slot.
+func (l *SpendingLimiter) key(tag, counter string) string {
+	return spendingKeys.Key("{"+tag+"}", counter)
+}
+
+// formatWindow renders a rolling window for error details.
+func formatWindow(window time.Duration) string {
+	if window%(24*time.Hour) == 0 {
+		return strconv.Itoa(int(window/(24*time.Hour))) + "d"
+	}
+	return window.String()
+}
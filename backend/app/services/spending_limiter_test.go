@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingLimiterReserveRelease(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+	daily := SpendingCap{Counter: "wallet:ETH", Window: dailyWindow, Limit: units(t, "100")}
+	reserve := func(value string) (*SpendingReservation, *SpendingCap, amount.Int) {
+		t.Helper()
+		reservation, exceeded, used, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+			{Counter: "wallet:ETH", Amount: units(t, value)},
+		}, []SpendingCap{daily})
+		require.NoError(t, err)
+		return reservation, exceeded, used
+	}
+
+	// 1️⃣ Spending adds up to the cap, inclusive
+	first, exceeded, _ := reserve("60")
+	require.Nil(t, exceeded)
+	_, exceeded, _ = reserve("40")
+	require.Nil(t, exceeded)
+
+	// 2️⃣ Past it nothing is recorded, and what was used is reported
+	reservation, exceeded, used := reserve("1")
+	require.Nil(t, reservation)
+	require.Equal(t, &daily, exceeded)
+	require.Equal(t, "100", used.String())
+
+	// 3️⃣ Released spending no longer counts
+	require.NoError(t, limiter.Release(ctx, first))
+	_, exceeded, _ = reserve("60")
+	require.Nil(t, exceeded)
+	require.NoError(t, limiter.Release(ctx, nil))
+}
+
+func TestSpendingLimiterChecksEveryCapAtOnce(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+	uses := func(value string) []SpendingUse {
+		return []SpendingUse{
+			{Counter: "wallet:ETH", Amount: units(t, value)},
+			{Counter: "user:ETH", Amount: units(t, value)},
+		}
+	}
+	caps := []SpendingCap{
+		{Counter: "wallet:ETH", Window: dailyWindow, Limit: units(t, "100")},
+		{Counter: "user:ETH", Window: weeklyWindow, Limit: units(t, "150")},
+	}
+
+	_, exceeded, _, err := limiter.Reserve(ctx, testUserId, uses("90"), caps)
+	require.NoError(t, err)
+	require.Nil(t, exceeded)
+
+	// The user cap is hit by a use the wallet cap allows; the wallet
+	// counter is left as it was
+	_, exceeded, used, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+		{Counter: "user:ETH", Amount: units(t, "70")},
+	}, caps)
+	require.NoError(t, err)
+	require.Equal(t, "user:ETH", exceeded.Counter)
+	require.Equal(t, "90", used.String())
+
+	_, exceeded, _, err = limiter.Reserve(ctx, testUserId, uses("10"), caps)
+	require.NoError(t, err)
+	require.Nil(t, exceeded)
+	_, exceeded, used, err = limiter.Reserve(ctx, testUserId, uses("1"), caps)
+	require.NoError(t, err)
+	require.Equal(t, "wallet:ETH", exceeded.Counter)
+	require.Equal(t, "100", used.String())
+}
+
+func TestSpendingLimiterAddsAmountsBeyondInt64(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+	limit := SpendingCap{Counter: "wallet:ETH", Window: dailyWindow, Limit: units(t, "30000000000000000000000")}
+
+	for i := 0; i < 2; i++ {
+		_, exceeded, _, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+			{Counter: "wallet:ETH", Amount: units(t, "9999999999999999999999")},
+		}, []SpendingCap{limit})
+		require.NoError(t, err)
+		require.Nil(t, exceeded)
+	}
+
+	_, exceeded, used, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+		{Counter: "wallet:ETH", Amount: units(t, "10000000000000000000003")},
+	}, []SpendingCap{limit})
+	require.NoError(t, err)
+	require.NotNil(t, exceeded)
+	require.Equal(t, "19999999999999999999998", used.String())
+}
+
+func TestSpendingLimiterWindowRolls(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+	limit := SpendingCap{Counter: "wallet:ETH", Window: 50 * time.Millisecond, Limit: units(t, "1")}
+	reserve := func() *SpendingCap {
+		_, exceeded, _, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+			{Counter: "wallet:ETH", Amount: units(t, "1")},
+		}, []SpendingCap{limit})
+		require.NoError(t, err)
+		return exceeded
+	}
+
+	require.Nil(t, reserve())
+	require.NotNil(t, reserve())
+
+	// Spending older than the window no longer counts against it
+	time.Sleep(60 * time.Millisecond)
+	require.Nil(t, reserve())
+}
+
+func TestSpendingLimiterSerializesReservations(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+	caps := []SpendingCap{{Counter: "wallet:ETH", Window: dailyWindow, Limit: units(t, "10")}}
+
+	// Concurrent signings cannot together slip past the cap
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		granted int
+	)
+	for i := 0; i < 25; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, exceeded, _, err := limiter.Reserve(ctx, testUserId, []SpendingUse{
+				{Counter: "wallet:ETH", Amount: units(t, "1")},
+			}, caps)
+			if err == nil && exceeded == nil {
+				mu.Lock()
+				granted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 10, granted)
+}
+
+func TestFormatWindow(t *testing.T) {
+	require.Equal(t, "1d", formatWindow(dailyWindow))
+	require.Equal(t, "7d", formatWindow(weeklyWindow))
+	require.Equal(t, "12h0m0s", formatWindow(12*time.Hour))
+}
+
+// units parses an amount of base units.
+func units(t *testing.T, value string) amount.Int {
+	t.Helper()
+
+	n, err := amount.Parse(value)
+	require.NoError(t, err)
+	return n
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Audit event actions of policy administrators.
+const (
+	auditPolicyCreated = "spending_policy.created"
+	auditPolicyUpdated = "spending_policy.updated"
+	auditPolicyDeleted = "spending_policy.deleted"
+)
+
+// notifyPolicyChanged tells the owner their limits changed.
+const notifyPolicyChanged = "spending_policy.changed"
+
+const defaultPolicyTimezone = "UTC"
+
+type SpendingPolicyServiceImpl struct {
+	policyRepo repositories.SpendingPolicyRepository
+	walletRepo repositories.WalletRepository
+	userRepo   repositories.UserRepository
+	auditRepo  repositories.AuditEventRepository
+	txManager  repositories.TransactionManager
+	notifier   services.Notifier
+}
+
+func NewSpendingPolicyService(
+	policyRepo repositories.SpendingPolicyRepository,
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	auditRepo repositories.AuditEventRepository,
+	txManager repositories.TransactionManager,
+	notifier services.Notifier,
+) services.SpendingPolicyService {
+	return &SpendingPolicyServiceImpl{
+		policyRepo: policyRepo,
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+		notifier:   notifier,
+	}
+}
+
+// ListPolicies implements [services.SpendingPolicyService].
+func (s *SpendingPolicyServiceImpl) ListPolicies(
+	ctx context.Context,
+	req *dto.ListSpendingPoliciesReq,
+) (*core.ApiResponse, error) {
+
+	policies, err := s.policyRepo.ListByUser(ctx, req.UserId)
+	if err != nil {
+		return core.Error(500, "cannot load spending policies", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "ok", toSpendingPolicyResList(policies), nil), nil
+}
+
+// ListWalletPolicies implements [services.SpendingPolicyService].
+// Owners see the policies that bind the wallet, but cannot change them.
+func (s *SpendingPolicyServiceImpl) ListWalletPolicies(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) (*core.ApiResponse, error) {
+
+	if _, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId); err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return core.Error(404, "wallet not found", nil, nil), nil
+		}
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	policies, err := s.policyRepo.ListForWallet(ctx, userId, walletId)
+	if err != nil {
+		return core.Error(500, "cannot load spending policies", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "ok", toSpendingPolicyResList(policies), nil), nil
+}
+
+// CreatePolicy implements [services.SpendingPolicyService].
+func (s *SpendingPolicyServiceImpl) CreatePolicy(
+	ctx context.Context,
+	adminId string,
+	req *dto.CreateSpendingPolicyReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ The policy must name the user's own wallet, or none
+	if req.WalletId != "" {
+		if _, err := s.walletRepo.GetByIdAndUser(ctx, req.WalletId, req.UserId); err != nil {
+			if errors.Is(err, domainerrors.ErrNotFound) {
+				return core.Error(404, "wallet not found", nil, nil), nil
+			}
+			return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+		}
+	} else if _, err := s.userRepo.GetUserByID(ctx, req.UserId); err != nil {
+		return core.Error(404, "user not found", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Build and check the limits
+	now := time.Now()
+	policy := &models.SpendingPolicy{
+		SpendingPolicyId: uuid.New().String(),
+		UserId:           req.UserId,
+		Asset:            strings.ToUpper(req.Asset),
+		CreateDate:       now,
+		UpdateDate:       now,
+	}
+	if req.WalletId != "" {
+		policy.WalletId = &req.WalletId
+	}
+	if resp := applyPolicyLimits(policy, &req.SpendingPolicyLimitsReq); resp != nil {
+		return resp, nil
+	}
+
+	// 3️⃣ Save, audit and notify together
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.policyRepo.Create(ctx, policy); err != nil {
+			return err
+		}
+		return s.recordChange(ctx, adminId, policy, auditPolicyCreated, meta)
+	})
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "spending policy already exists for this scope and asset", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot create spending policy", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "spending policy created", toSpendingPolicyRes(policy), nil), nil
+}
+
+// UpdatePolicy implements [services.SpendingPolicyService].
+func (s *SpendingPolicyServiceImpl) UpdatePolicy(
+	ctx context.Context,
+	adminId string,
+	spendingPolicyId string,
+	req *dto.UpdateSpendingPolicyReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	policy, err := s.policyRepo.GetById(ctx, spendingPolicyId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "spending policy not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load spending policy", err.Error(), nil), nil
+	}
+
+	if resp := applyPolicyLimits(policy, &req.SpendingPolicyLimitsReq); resp != nil {
+		return resp, nil
+	}
+	policy.UpdateDate = time.Now()
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.policyRepo.Update(ctx, policy); err != nil {
+			return err
+		}
+		return s.recordChange(ctx, adminId, policy, auditPolicyUpdated, meta)
+	})
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "spending policy not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot update spending policy", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "spending policy updated", toSpendingPolicyRes(policy), nil), nil
+}
+
+// DeletePolicy implements [services.SpendingPolicyService].
+func (s *SpendingPolicyServiceImpl) DeletePolicy(
+	ctx context.Context,
+	adminId string,
+	spendingPolicyId string,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		removed, err := s.policyRepo.Delete(ctx, spendingPolicyId)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(ctx, adminId, removed, auditPolicyDeleted, meta)
+	})
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "spending policy not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot delete spending policy", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "spending policy deleted", nil, nil), nil
+}
+
+// recordChange audits a policy change by an administrator and tells the
+// owner, so limits cannot be loosened unnoticed.
+func (s *SpendingPolicyServiceImpl) recordChange(
+	ctx context.Context,
+	adminId string,
+	policy *models.SpendingPolicy,
+	action string,
+	meta dto.RequestMeta,
+) error {
+
+	res := toSpendingPolicyRes(policy)
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	walletId := ""
+	if policy.WalletId != nil {
+		walletId = *policy.WalletId
+	}
+	if err := s.auditRepo.Create(ctx, &models.AuditEvent{
+		AuditEventId: uuid.New().String(),
+		UserId:       adminId,
+		WalletId:     walletId,
+		Action:       action,
+		IpAddress:    meta.IpAddress,
+		UserAgent:    meta.UserAgent,
+		Metadata:     string(raw),
+		CreateDate:   time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	return s.notifier.Notify(ctx, policy.UserId, notifyPolicyChanged, map[string]any{
+		"action": action,
+		"policy": res,
+	})
+}
+
+// applyPolicyLimits sets the limits of req on policy, or returns why they
+// are invalid.
+func applyPolicyLimits(policy *models.SpendingPolicy, req *dto.SpendingPolicyLimitsReq) *core.ApiResponse {
+	limits := []struct {
+		value string
+		dest  **amount.Int
+	}{
+		{req.MaxPerTransaction, &policy.MaxPerTransaction},
+		{req.DailyLimit, &policy.DailyLimit},
+		{req.WeeklyLimit, &policy.WeeklyLimit},
+	}
+
+	for _, limit := range limits {
+		*limit.dest = nil
+		if limit.value == "" {
+			continue
+		}
+		if policy.Asset == models.AnyAsset {
+			return core.Error(400, "amount limits need a specific asset", nil, nil)
+		}
+		n, err := utils.ParseUint256(limit.value)
+		if err != nil {
+			return core.Error(400, "invalid amount limit", err.Error(), nil)
+		}
+		value := amount.New(n)
+		*limit.dest = &value
+	}
+
+	policy.AllowedFromHour, policy.AllowedToHour = req.AllowedFromHour, req.AllowedToHour
+	if policy.AllowedFromHour != nil && *policy.AllowedFromHour == *policy.AllowedToHour {
+		return core.Error(400, "allowed hours window is empty", nil, nil)
+	}
+	policy.Timezone = req.Timezone
+	if policy.Timezone == "" {
+		policy.Timezone = defaultPolicyTimezone
+	}
+	if _, err := time.LoadLocation(policy.Timezone); err != nil {
+		return core.Error(400, "invalid timezone", err.Error(), nil)
+	}
+
+	if policy.MaxPerTransaction == nil && policy.DailyLimit == nil &&
+		policy.WeeklyLimit == nil && policy.AllowedFromHour == nil {
+		return core.Error(400, "spending policy sets no limit", nil, nil)
+	}
+	return nil
+}
+
+func toSpendingPolicyResList(policies []models.SpendingPolicy) []dto.SpendingPolicyRes {
+	res := make([]dto.SpendingPolicyRes, 0, len(policies))
+	for i := range policies {
+		res = append(res, toSpendingPolicyRes(&policies[i]))
+	}
+	return res
+}
+
+func toSpendingPolicyRes(policy *models.SpendingPolicy) dto.SpendingPolicyRes {
+	return dto.SpendingPolicyRes{
+		SpendingPolicyId:  policy.SpendingPolicyId,
+		UserId:            policy.UserId,
+		WalletId:          policy.WalletId,
+		Scope:             policyScope(policy),
+		Asset:             policy.Asset,
+		MaxPerTransaction: policy.MaxPerTransaction,
+		DailyLimit:        policy.DailyLimit,
+		WeeklyLimit:       policy.WeeklyLimit,
+		AllowedFromHour:   policy.AllowedFromHour,
+		AllowedToHour:     policy.AllowedToHour,
+		Timezone:          policy.Timezone,
+		CreateDate:        policy.CreateDate,
+		UpdateDate:        policy.UpdateDate,
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/stretchr/testify/require"
+)
+
+const policyAdminId = "admin-1"
+
+func TestSpendingPolicyAdministration(t *testing.T) {
+	ctx := context.Background()
+	svc, store, notifier := newPolicyService()
+	walletId := ledgerWalletId
+
+	// 1️⃣ A policy for all of the user's wallets is saved, audited under
+	// the administrator and told to the owner
+	res, err := svc.CreatePolicy(ctx, policyAdminId, &dto.CreateSpendingPolicyReq{
+		UserId:                  testUserId,
+		Asset:                   "eth",
+		SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: oneEther},
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	created := res.Data.(dto.SpendingPolicyRes)
+	require.Equal(t, "ETH", created.Asset)
+	require.Equal(t, policyScopeUser, created.Scope)
+	require.Equal(t, defaultPolicyTimezone, created.Timezone)
+	require.Equal(t, oneEther, created.DailyLimit.String())
+	require.Nil(t, created.MaxPerTransaction)
+
+	require.Equal(t, []string{auditPolicyCreated}, store.auditActions())
+	require.Equal(t, policyAdminId, store.events[0].UserId)
+	require.Equal(t, []string{notifyPolicyChanged}, notifier.events())
+	require.Equal(t, testUserId, notifier.sent[0].userId)
+
+	// 2️⃣ One policy per scope and asset
+	res, err = svc.CreatePolicy(ctx, policyAdminId, &dto.CreateSpendingPolicyReq{
+		UserId:                  testUserId,
+		Asset:                   "ETH",
+		SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{WeeklyLimit: oneEther},
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 409, res.Code)
+
+	from, to := 9, 17
+	res, err = svc.CreatePolicy(ctx, policyAdminId, &dto.CreateSpendingPolicyReq{
+		UserId:   testUserId,
+		WalletId: walletId,
+		Asset:    "ETH",
+		SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{
+			MaxPerTransaction: "5",
+			AllowedFromHour:   &from,
+			AllowedToHour:     &to,
+			Timezone:          "Asia/Ho_Chi_Minh",
+		},
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	walletPolicy := res.Data.(dto.SpendingPolicyRes)
+	require.Equal(t, policyScopeWallet, walletPolicy.Scope)
+	require.Equal(t, walletId, *walletPolicy.WalletId)
+
+	// 3️⃣ The owner sees both on the wallet, without changing them
+	res, err = svc.ListWalletPolicies(ctx, testUserId, walletId)
+	require.NoError(t, err)
+	require.Len(t, res.Data.([]dto.SpendingPolicyRes), 2)
+
+	res, err = svc.ListWalletPolicies(ctx, "user-2", walletId)
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+
+	// 4️⃣ An update replaces every limit
+	res, err = svc.UpdatePolicy(ctx, policyAdminId, created.SpendingPolicyId, &dto.UpdateSpendingPolicyReq{
+		SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{WeeklyLimit: "7"},
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	updated := res.Data.(dto.SpendingPolicyRes)
+	require.Nil(t, updated.DailyLimit)
+	require.Equal(t, "7", updated.WeeklyLimit.String())
+
+	// 5️⃣ Deletion is recorded too
+	res, err = svc.DeletePolicy(ctx, policyAdminId, created.SpendingPolicyId, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	res, err = svc.DeletePolicy(ctx, policyAdminId, created.SpendingPolicyId, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+
+	res, err = svc.ListPolicies(ctx, &dto.ListSpendingPoliciesReq{UserId: testUserId})
+	require.NoError(t, err)
+	require.Len(t, res.Data.([]dto.SpendingPolicyRes), 1)
+	require.Equal(t, []string{
+		auditPolicyCreated, auditPolicyCreated, auditPolicyUpdated, auditPolicyDeleted,
+	}, store.auditActions())
+	require.Len(t, notifier.events(), 4)
+}
+
+func TestCreateSpendingPolicyRejects(t *testing.T) {
+	ctx := context.Background()
+	svc, store, notifier := newPolicyService()
+	hour := func(h int) *int { return &h }
+
+	tests := []struct {
+		name    string
+		req     dto.CreateSpendingPolicyReq
+		code    int
+		message string
+	}{
+		{"no limit", dto.CreateSpendingPolicyReq{UserId: testUserId, Asset: "ETH"}, 400, "spending policy sets no limit"},
+		{"amounts for any asset", dto.CreateSpendingPolicyReq{UserId: testUserId, Asset: models.AnyAsset, SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: "1"}}, 400, "amount limits need a specific asset"},
+		{"fractional amount", dto.CreateSpendingPolicyReq{UserId: testUserId, Asset: "ETH", SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: "1.5"}}, 400, "invalid amount limit"},
+		{"empty hours", dto.CreateSpendingPolicyReq{UserId: testUserId, Asset: "ETH", SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{AllowedFromHour: hour(8), AllowedToHour: hour(8)}}, 400, "allowed hours window is empty"},
+		{"unknown timezone", dto.CreateSpendingPolicyReq{UserId: testUserId, Asset: "ETH", SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: "1", Timezone: "Mars/Olympus"}}, 400, "invalid timezone"},
+		{"wallet of another user", dto.CreateSpendingPolicyReq{UserId: "user-2", WalletId: ledgerWalletId, Asset: "ETH", SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: "1"}}, 404, "wallet not found"},
+		{"unknown user", dto.CreateSpendingPolicyReq{UserId: "nobody", Asset: "ETH", SpendingPolicyLimitsReq: dto.SpendingPolicyLimitsReq{DailyLimit: "1"}}, 404, "user not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.CreatePolicy(ctx, policyAdminId, &tt.req, dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code)
+			require.Equal(t, tt.message, res.Message)
+		})
+	}
+	require.Empty(t, store.auditActions())
+	require.Empty(t, notifier.events())
+}
+
+// newPolicyService administers the policies of testUserId, who owns
+// ledgerWalletId, and user-2.
+func newPolicyService() (*SpendingPolicyServiceImpl, *memoryStore, *memoryNotifier) {
+	store := newMemoryStore(&models.Wallet{WalletId: ledgerWalletId, UserId: testUserId})
+	notifier := &memoryNotifier{}
+	svc := NewSpendingPolicyService(
+		&memoryPolicies{},
+		store.walletRepo(),
+		memoryUsers{ids: []string{testUserId, "user-2"}},
+		store.auditRepo(),
+		store,
+		notifier,
+	)
+	return svc.(*SpendingPolicyServiceImpl), store, notifier
+}
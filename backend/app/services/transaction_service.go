@@ -0,0 +1,446 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// defaultTransactionPageSize is used when the list request sets no limit.
+const defaultTransactionPageSize = 20
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// transactionTransitions is the transaction lifecycle. A pending
+// transaction is broadcast, or fails before reaching the network; a
+// broadcast one ends confirmed, failed or replaced by another transaction
+// spending the same nonce or inputs. Final states have no way out.
+var transactionTransitions = map[string][]string{
+	models.TransactionStatusPending:   {models.TransactionStatusBroadcast, models.TransactionStatusFailed},
+	models.TransactionStatusBroadcast: {models.TransactionStatusConfirmed, models.TransactionStatusFailed, models.TransactionStatusReplaced},
+}
+
+// canTransition reports whether a transaction may move from one status
+// to another.
+func canTransition(from, to string) bool {
+	for _, next := range transactionTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type TransactionServiceImpl struct {
+	walletRepo repositories.WalletRepository
+	txRepo     repositories.TransactionRepository
+	tokenRepo  repositories.Erc20TokenRepository
+}
+
+func NewTransactionService(
+	walletRepo repositories.WalletRepository,
+	txRepo repositories.TransactionRepository,
+	tokenRepo repositories.Erc20TokenRepository,
+) services.TransactionService {
+	return &TransactionServiceImpl{
+		walletRepo: walletRepo,
+		txRepo:     txRepo,
+		tokenRepo:  tokenRepo,
+	}
+}
+
+// RecordTransaction implements [services.TransactionService].
+func (s *TransactionServiceImpl) RecordTransaction(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.RecordTransactionReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Load the wallet
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	// 2️⃣ The wallet's side of the transfer must be one of its addresses
+	from := normalizeAddress(req.Chain, req.FromAddress)
+	to := normalizeAddress(req.Chain, req.ToAddress)
+
+	own := from
+	if req.Direction == models.TransactionDirectionIncoming {
+		own = to
+	}
+	if !walletOwnsAddress(wallet, req.Chain, own) {
+		return core.Error(400, "address does not belong to wallet", nil, map[string]any{
+			"direction": req.Direction,
+			"address":   own,
+		}), nil
+	}
+
+	// 3️⃣ Resolve the asset and its base units: the native currency, or a
+	// token registered on the wallet's network
+	asset, err := crypto.NativeAsset(req.Chain)
+	if err != nil {
+		return core.Error(400, "unsupported chain", err.Error(), nil), nil
+	}
+
+	var contract string
+	if req.Asset != "" && !strings.EqualFold(req.Asset, asset.Symbol) {
+		token, resp := s.lookupToken(ctx, wallet, req.Chain, req.Asset)
+		if resp != nil {
+			return resp, nil
+		}
+		asset = crypto.Asset{Symbol: token.Symbol, Decimals: token.Decimals}
+		contract = token.ContractAddress
+	}
+
+	value, err := utils.ParseUint256(req.Amount)
+	if err != nil || value.Sign() == 0 {
+		return core.Error(400, "amount must be a positive integer in base units", nil, nil), nil
+	}
+
+	// 4️⃣ New transactions enter the lifecycle at its start: pending, or
+	// broadcast when already sent
+	status := req.Status
+	if status == "" {
+		status = models.TransactionStatusPending
+	}
+	if status != models.TransactionStatusPending && status != models.TransactionStatusBroadcast {
+		return core.Error(400, "invalid initial status", nil, map[string]any{
+			"status":  status,
+			"allowed": []string{models.TransactionStatusPending, models.TransactionStatusBroadcast},
+		}), nil
+	}
+	if status == models.TransactionStatusBroadcast && req.TxHash == "" {
+		return core.Error(400, "tx_hash is required to record a broadcast transaction", nil, nil), nil
+	}
+
+	// 5️⃣ Save
+	now := time.Now()
+	date := now
+	if req.TransactionDate != nil {
+		date = *req.TransactionDate
+	}
+
+	tx := &models.Transaction{
+		TransactionId:   uuid.New().String(),
+		WalletId:        wallet.WalletId,
+		Chain:           req.Chain,
+		Direction:       req.Direction,
+		FromAddress:     from,
+		ToAddress:       to,
+		Amount:          amount.New(value),
+		Asset:           asset.Symbol,
+		Decimals:        asset.Decimals,
+		ContractAddress: contract,
+		TxHash:          req.TxHash,
+		TransactionDate: date,
+		Status:          status,
+		CreateDate:      now,
+		UpdateDate:      now,
+	}
+
+	if err := s.txRepo.Create(ctx, tx); err != nil {
+		return core.Error(500, "cannot record transaction", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "transaction recorded", toTransactionRes(tx), nil), nil
+}
+
+// ListTransactions implements [services.TransactionService].
+// Transactions come newest first; the cursor in the response meta
+// continues after the last one.
+func (s *TransactionServiceImpl) ListTransactions(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.ListTransactionsReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Check ownership
+	_, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Build the filter
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTransactionPageSize
+	}
+
+	filter := repositories.TransactionFilter{
+		WalletId:  walletId,
+		Status:    req.Status,
+		Direction: req.Direction,
+		Limit:     limit + 1,
+	}
+	if req.Address != "" {
+		filter.Address = normalizeAddress("", req.Address)
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return core.Error(400, "invalid from date", err.Error(), nil), nil
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return core.Error(400, "invalid to date", err.Error(), nil), nil
+		}
+		filter.To = &to
+	}
+	if req.Cursor != "" {
+		after, err := decodeTransactionCursor(req.Cursor)
+		if err != nil {
+			return core.Error(400, "invalid cursor", nil, nil), nil
+		}
+		filter.After = after
+	}
+
+	// 3️⃣ Fetch one extra row to know whether another page follows
+	txs, err := s.txRepo.List(ctx, filter)
+	if err != nil {
+		return core.Error(500, "cannot list transactions", err.Error(), nil), nil
+	}
+
+	meta := dto.CursorMeta{Limit: limit}
+	if len(txs) > limit {
+		txs = txs[:limit]
+		last := txs[limit-1]
+		meta.NextCursor = encodeTransactionCursor(repositories.TransactionCursor{
+			TransactionDate: last.TransactionDate,
+			TransactionId:   last.TransactionId,
+		})
+	}
+
+	res := make([]dto.TransactionRes, 0, len(txs))
+	for i := range txs {
+		res = append(res, toTransactionRes(&txs[i]))
+	}
+
+	return core.Success(200, "transactions fetched", res, meta), nil
+}
+
+// GetTransaction implements [services.TransactionService].
+func (s *TransactionServiceImpl) GetTransaction(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	transactionId string,
+) (*core.ApiResponse, error) {
+
+	tx, resp := s.loadTransaction(ctx, userId, walletId, transactionId)
+	if resp != nil {
+		return resp, nil
+	}
+
+	return core.Success(200, "transaction fetched", toTransactionRes(tx), nil), nil
+}
+
+// UpdateTransactionStatus implements [services.TransactionService].
+func (s *TransactionServiceImpl) UpdateTransactionStatus(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	transactionId string,
+	req *dto.UpdateTransactionStatusReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Load the transaction
+	tx, resp := s.loadTransaction(ctx, userId, walletId, transactionId)
+	if resp != nil {
+		return resp, nil
+	}
+
+	// 2️⃣ Check the state machine; deposits found on chain follow the chain
+	if tx.Watched() {
+		return core.Error(409, "transaction is tracked by the deposit watcher", nil, map[string]any{
+			"block_number": tx.BlockNumber,
+		}), nil
+	}
+	if !canTransition(tx.Status, req.Status) {
+		return core.Error(409, "invalid status transition", nil, map[string]any{
+			"from":    tx.Status,
+			"to":      req.Status,
+			"allowed": transitionsFrom(tx.Status),
+		}), nil
+	}
+	if req.Status == models.TransactionStatusBroadcast && tx.TxHash == "" && req.TxHash == "" {
+		return core.Error(400, "tx_hash is required to mark a transaction broadcast", nil, nil), nil
+	}
+
+	// 3️⃣ Apply, unless someone else moved it first
+	err := s.txRepo.UpdateStatus(ctx, tx.TransactionId, tx.Status, req.Status, req.TxHash)
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "transaction status changed concurrently", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot update transaction", err.Error(), nil), nil
+	}
+
+	tx.Status = req.Status
+	if req.TxHash != "" {
+		tx.TxHash = req.TxHash
+	}
+	tx.UpdateDate = time.Now()
+
+	return core.Success(200, "transaction status updated", toTransactionRes(tx), nil), nil
+}
+
+// loadTransaction returns a transaction of a wallet owned by the user, or
+// the response to send when there is none.
+func (s *TransactionServiceImpl) loadTransaction(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	transactionId string,
+) (*models.Transaction, *core.ApiResponse) {
+
+	_, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return nil, core.Error(404, "wallet not found", nil, nil)
+	}
+	if err != nil {
+		return nil, core.Error(500, "cannot load wallet", err.Error(), nil)
+	}
+
+	tx, err := s.txRepo.GetByIdAndWallet(ctx, transactionId, walletId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return nil, core.Error(404, "transaction not found", nil, nil)
+	}
+	if err != nil {
+		return nil, core.Error(500, "cannot load transaction", err.Error(), nil)
+	}
+
+	return tx, nil
+}
+
+// lookupToken resolves an asset symbol to a token registered on the
+// wallet's network, or returns the response to send when there is none.
+func (s *TransactionServiceImpl) lookupToken(
+	ctx context.Context,
+	wallet *models.Wallet,
+	chain string,
+	symbol string,
+) (*models.Erc20Token, *core.ApiResponse) {
+
+	unsupported := core.Error(400, "unsupported asset", nil, map[string]any{
+		"chain": chain,
+		"asset": symbol,
+	})
+	if chain != crypto.ChainETH {
+		return nil, unsupported
+	}
+
+	token, err := s.tokenRepo.GetBySymbol(ctx, chain, wallet.EthNetwork, symbol)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return nil, unsupported
+	}
+	if err != nil {
+		return nil, core.Error(500, "cannot load token", err.Error(), nil)
+	}
+
+	return token, nil
+}
+
+// transitionsFrom lists the statuses reachable from status.
+func transitionsFrom(status string) []string {
+	next := transactionTransitions[status]
+	if next == nil {
+		return []string{}
+	}
+	return next
+}
+
+// walletOwnsAddress reports whether address is one of the wallet's
+// addresses on chain.
+func walletOwnsAddress(wallet *models.Wallet, chain, address string) bool {
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain == chain && strings.EqualFold(addr.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeAddress stores Ethereum addresses in their EIP-55 checksum form
+// so filters match however the client cased them. Bitcoin addresses are
+// kept as given. An empty chain normalizes anything that looks like an
+// Ethereum address.
+func normalizeAddress(chain, address string) string {
+	if (chain == crypto.ChainETH || chain == "") && common.IsHexAddress(address) {
+		return common.HexToAddress(address).Hex()
+	}
+	return address
+}
+
+// encodeTransactionCursor renders a cursor as opaque URL-safe text.
+func encodeTransactionCursor(c repositories.TransactionCursor) string {
+	raw := c.TransactionDate.UTC().Format(time.RFC3339Nano) + "|" + c.TransactionId
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTransactionCursor reverses encodeTransactionCursor.
+func decodeTransactionCursor(s string) (*repositories.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, errInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	return &repositories.TransactionCursor{TransactionDate: t, TransactionId: id}, nil
+}
+
+func toTransactionRes(tx *models.Transaction) dto.TransactionRes {
+	return dto.TransactionRes{
+		TransactionId:   tx.TransactionId,
+		WalletId:        tx.WalletId,
+		Chain:           tx.Chain,
+		Direction:       tx.Direction,
+		FromAddress:     tx.FromAddress,
+		ToAddress:       tx.ToAddress,
+		Amount:          tx.Amount,
+		Asset:           tx.Asset,
+		Decimals:        tx.Decimals,
+		ContractAddress: tx.ContractAddress,
+		AmountFormatted: amount.Format(tx.Amount, tx.Decimals),
+		TxHash:          tx.TxHash,
+		Status:          tx.Status,
+		TransactionDate: tx.TransactionDate,
+		CreateDate:      tx.CreateDate,
+		UpdateDate:      tx.UpdateDate,
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ledgerUserId   = "user-1"
+	ledgerWalletId = "wallet-1"
+	ledgerAddress  = "0x00000000000000000000000000000000000A11cE"
+	ledgerPayee    = "0x0000000000000000000000000000000000000B0b"
+)
+
+func TestRecordTransactionInitialStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		txHash  string
+		code    int
+		message string
+	}{
+		{name: "default", code: 201},
+		{name: "pending", status: models.TransactionStatusPending, code: 201},
+		{name: "broadcast with hash", status: models.TransactionStatusBroadcast, txHash: "0xabc", code: 201},
+		{name: "broadcast without hash", status: models.TransactionStatusBroadcast, code: 400, message: "tx_hash is required to record a broadcast transaction"},
+		{name: "confirmed", status: models.TransactionStatusConfirmed, txHash: "0xabc", code: 400, message: "invalid initial status"},
+		{name: "failed", status: models.TransactionStatusFailed, code: 400, message: "invalid initial status"},
+		{name: "replaced", status: models.TransactionStatusReplaced, txHash: "0xabc", code: 400, message: "invalid initial status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txs := &memoryRecordedTransactions{}
+			svc := NewTransactionService(newLedgerWallets(), txs, nil)
+
+			res, err := svc.RecordTransaction(context.Background(), ledgerUserId, ledgerWalletId, &dto.RecordTransactionReq{
+				Chain:       crypto.ChainETH,
+				Direction:   models.TransactionDirectionOutgoing,
+				FromAddress: ledgerAddress,
+				ToAddress:   ledgerPayee,
+				Amount:      "1000",
+				TxHash:      tt.txHash,
+				Status:      tt.status,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code, res.Message)
+
+			if tt.code != 201 {
+				require.Equal(t, tt.message, res.Message)
+				require.Empty(t, txs.saved)
+				return
+			}
+			require.Len(t, txs.saved, 1)
+			want := tt.status
+			if want == "" {
+				want = models.TransactionStatusPending
+			}
+			require.Equal(t, want, txs.saved[0].Status)
+		})
+	}
+}
+
+func TestRecordTransactionKeepsExactAmounts(t *testing.T) {
+	txs := &memoryRecordedTransactions{}
+	svc := NewTransactionService(newLedgerWallets(), txs, nil)
+	record := func(value string) *core.ApiResponse {
+		res, err := svc.RecordTransaction(context.Background(), ledgerUserId, ledgerWalletId, &dto.RecordTransactionReq{
+			Chain:       crypto.ChainETH,
+			Direction:   models.TransactionDirectionOutgoing,
+			FromAddress: ledgerAddress,
+			ToAddress:   ledgerPayee,
+			Amount:      value,
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	// 1️⃣ Wei beyond float64 precision is stored and shown as given
+	res := record("1234567890123456789012")
+	require.Equal(t, 201, res.Code, res.Message)
+	require.Equal(t, "1234567890123456789012", txs.saved[0].Amount.String())
+	require.Equal(t, "ETH", txs.saved[0].Asset)
+	require.EqualValues(t, 18, txs.saved[0].Decimals)
+
+	raw, err := json.Marshal(res.Data)
+	require.NoError(t, err)
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(raw, &body))
+	require.Equal(t, "1234567890123456789012", body["amount"])
+	require.Equal(t, "1234.567890123456789012", body["amount_formatted"])
+
+	// 2️⃣ Amounts are positive integers of base units
+	for _, value := range []string{"0", "1.5"} {
+		res := record(value)
+		require.Equal(t, 400, res.Code)
+		require.Equal(t, "amount must be a positive integer in base units", res.Message)
+	}
+	require.Len(t, txs.saved, 1)
+}
+
+// newLedgerWallets holds one Ethereum wallet owning ledgerAddress.
+func newLedgerWallets() repositories.WalletRepository {
+	return newMemoryStore(&models.Wallet{
+		WalletId:   ledgerWalletId,
+		UserId:     ledgerUserId,
+		EthNetwork: "sepolia",
+		BtcNetwork: "testnet",
+		BlockchainAddresses: []models.BlockchainAddress{
+			{WalletId: ledgerWalletId, Chain: crypto.ChainETH, Address: ledgerAddress},
+		},
+	}).walletRepo()
+}
+
+type memoryRecordedTransactions struct {
+	repositories.TransactionRepository
+	saved []models.Transaction
+}
+
+func (r *memoryRecordedTransactions) Create(ctx context.Context, tx *models.Transaction) error {
+	r.saved = append(r.saved, *tx)
+	return nil
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// Notification events sent to the wallet owner.
+const (
+	notifyWithdrawalAddressAdded = "withdrawal_address.added"
+	notifyAllowlistDisabling     = "allowlist.disable_requested"
+)
+
+// GetAllowlist implements [services.WalletService].
+func (s *WalletServiceImpl) GetAllowlist(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) (*core.ApiResponse, error) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	return s.allowlistResponse(ctx, wallet, "ok")
+}
+
+// AddWithdrawalAddress implements [services.WalletService].
+// The address can only be paid to once the cooling-off period has passed,
+// and the owner is notified right away, so a hijacked session cannot add
+// and drain to a new address before the owner can react.
+func (s *WalletServiceImpl) AddWithdrawalAddress(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.AddWithdrawalAddressReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Load the wallet and confirm the passphrase
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+	if _, err := s.unlockMnemonic(ctx, wallet, req.Passphrase); err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	// 2️⃣ Normalize the address for the wallet's network
+	network, err := walletNetwork(wallet, req.Chain)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+	address, err := crypto.NormalizeAddress(network, req.Address)
+	if err != nil {
+		return core.Error(400, "invalid address", err.Error(), nil), nil
+	}
+
+	// 3️⃣ Save, audit and notify together
+	now := time.Now()
+	entry := &models.WithdrawalAddress{
+		WithdrawalAddressId: uuid.New().String(),
+		WalletId:            walletId,
+		Chain:               req.Chain,
+		Address:             address,
+		Label:               req.Label,
+		ActiveDate:          now.Add(s.allowlistDelay),
+		CreateDate:          now,
+	}
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.withdrawalRepo.Create(ctx, entry); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, userId, walletId, auditWithdrawalAddressAdded, meta, map[string]string{
+			"chain":   entry.Chain,
+			"address": entry.Address,
+			"label":   entry.Label,
+		}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, userId, notifyWithdrawalAddressAdded, map[string]any{
+			"wallet_id":   walletId,
+			"wallet_name": wallet.WalletName,
+			"chain":       entry.Chain,
+			"address":     entry.Address,
+			"label":       entry.Label,
+			"active_date": entry.ActiveDate,
+			"ip_address":  meta.IpAddress,
+		})
+	})
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "address already in withdrawal allowlist", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot add withdrawal address", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "withdrawal address added", toWithdrawalAddressRes(entry, now), nil), nil
+}
+
+// RemoveWithdrawalAddress implements [services.WalletService].
+// Removal only narrows what can be paid to, so it is immediate.
+func (s *WalletServiceImpl) RemoveWithdrawalAddress(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	withdrawalAddressId string,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	if _, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId); err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return core.Error(404, "wallet not found", nil, nil), nil
+		}
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		removed, err := s.withdrawalRepo.Delete(ctx, walletId, withdrawalAddressId)
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, userId, walletId, auditWithdrawalAddressRemoved, meta, map[string]string{
+			"chain":   removed.Chain,
+			"address": removed.Address,
+		})
+	})
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "withdrawal address not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot remove withdrawal address", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "withdrawal address removed", nil, nil), nil
+}
+
+// SetAllowlistMode implements [services.WalletService].
+// Switching off is scheduled after the cooling-off period, and the owner
+// is notified, for the same reason new addresses wait.
+func (s *WalletServiceImpl) SetAllowlistMode(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SetAllowlistModeReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	now := time.Now()
+	enforced := wallet.AllowlistEnforced(now)
+
+	// 1️⃣ Switching on, or cancelling a pending switch-off, is immediate
+	if *req.Enabled {
+		if enforced && wallet.AllowlistDisableDate == nil {
+			return s.allowlistResponse(ctx, wallet, "allowlist enabled")
+		}
+		err := s.txManager.Do(ctx, func(ctx context.Context) error {
+			if err := s.walletRepo.UpdateAllowlist(ctx, walletId, true, nil); err != nil {
+				return err
+			}
+			return s.audit(ctx, userId, walletId, auditAllowlistEnabled, meta, nil)
+		})
+		if err != nil {
+			return core.Error(500, "cannot enable allowlist", err.Error(), nil), nil
+		}
+		wallet.AllowlistEnabled, wallet.AllowlistDisableDate = true, nil
+		return s.allowlistResponse(ctx, wallet, "allowlist enabled")
+	}
+
+	// 2️⃣ Nothing to do when it is off or already being switched off
+	if !enforced || wallet.AllowlistDisableDate != nil {
+		return s.allowlistResponse(ctx, wallet, "allowlist disabled")
+	}
+
+	// 3️⃣ Otherwise confirm the passphrase and schedule the switch-off
+	if _, err := s.unlockMnemonic(ctx, wallet, req.Passphrase); err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	disableDate := now.Add(s.allowlistDelay)
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.walletRepo.UpdateAllowlist(ctx, walletId, true, &disableDate); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, userId, walletId, auditAllowlistDisableRequested, meta, map[string]string{
+			"disable_date": disableDate.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		return s.notifier.Notify(ctx, userId, notifyAllowlistDisabling, map[string]any{
+			"wallet_id":    walletId,
+			"wallet_name":  wallet.WalletName,
+			"disable_date": disableDate,
+			"ip_address":   meta.IpAddress,
+		})
+	})
+	if err != nil {
+		return core.Error(500, "cannot disable allowlist", err.Error(), nil), nil
+	}
+	wallet.AllowlistDisableDate = &disableDate
+
+	return s.allowlistResponse(ctx, wallet, "allowlist disable scheduled")
+}
+
+// allowlistResponse renders the wallet's allowlist mode and address book.
+func (s *WalletServiceImpl) allowlistResponse(
+	ctx context.Context,
+	wallet *models.Wallet,
+	message string,
+) (*core.ApiResponse, error) {
+
+	entries, err := s.withdrawalRepo.ListByWallet(ctx, wallet.WalletId)
+	if err != nil {
+		return core.Error(500, "cannot load withdrawal addresses", err.Error(), nil), nil
+	}
+
+	now := time.Now()
+	res := dto.WithdrawalAllowlistRes{
+		Enabled:   wallet.AllowlistEnforced(now),
+		Addresses: make([]dto.WithdrawalAddressRes, 0, len(entries)),
+	}
+	if res.Enabled {
+		res.DisableDate = wallet.AllowlistDisableDate
+	}
+	for i := range entries {
+		res.Addresses = append(res.Addresses, toWithdrawalAddressRes(&entries[i], now))
+	}
+
+	return core.Success(200, message, res, nil), nil
+}
+
+// checkDestinations returns an error response when the wallet's allowlist
+// is enforced and a destination is neither an active withdrawal address
+// nor one of the wallet's own addresses, which change goes back to.
+func (s *WalletServiceImpl) checkDestinations(
+	ctx context.Context,
+	wallet *models.Wallet,
+	chain string,
+	destinations []string,
+) *core.ApiResponse {
+
+	now := time.Now()
+	if !wallet.AllowlistEnforced(now) {
+		return nil
+	}
+
+	network, err := walletNetwork(wallet, chain)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil)
+	}
+	entries, err := s.withdrawalRepo.ListByWalletAndChain(ctx, wallet.WalletId, chain)
+	if err != nil {
+		return core.Error(500, "cannot load withdrawal addresses", err.Error(), nil)
+	}
+
+	allowed := ownAddresses(wallet, network)
+	for _, entry := range entries {
+		if entry.IsActive(now) {
+			allowed[entry.Address] = true
+		}
+	}
+
+	for _, destination := range destinations {
+		address, err := crypto.NormalizeAddress(network, destination)
+		if err != nil || !allowed[address] {
+			return core.Error(403, "destination not in withdrawal allowlist", nil, map[string]any{
+				"chain":   chain,
+				"address": destination,
+			})
+		}
+	}
+
+	return nil
+}
+
+// ownAddresses returns the wallet's addresses on network, normalized.
+func ownAddresses(wallet *models.Wallet, network *crypto.Network) map[string]bool {
+	own := make(map[string]bool, len(wallet.BlockchainAddresses))
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain != network.Chain {
+			continue
+		}
+		if address, err := crypto.NormalizeAddress(network, addr.Address); err == nil {
+			own[address] = true
+		}
+	}
+	return own
+}
+
+// ethDestinations returns the accounts an Ethereum transaction pays to:
+// the recipient of an ERC-20 transfer or transferFrom call, the spender of
+// an approve or increaseAllowance call, otherwise the called account.
+// Ether sent along with a token call goes to the contract as well.
+// ok is false for contract deployments, which have no destination to check.
+func ethDestinations(tx *crypto.EthereumTx) (destinations []string, ok bool) {
+	if tx.To == nil {
+		return nil, false
+	}
+
+	call, err := blockchain.UnpackERC20Call(tx.Data)
+	if err != nil {
+		return []string{tx.To.Hex()}, true
+	}
+
+	destinations = []string{call.Recipient.Hex()}
+	if tx.Value != nil && tx.Value.Sign() > 0 {
+		destinations = append(destinations, tx.To.Hex())
+	}
+	return destinations, true
+}
+
+// typedDataDestinations returns the accounts EIP-712 typed data is
+// addressed to: the spender of a permit, otherwise the verifying contract
+// and any spender the message names. ok is false when it names neither,
+// so there is nothing to check.
+func typedDataDestinations(parties *crypto.TypedDataParties) (destinations []string, ok bool) {
+	if parties.Permit != nil {
+		return []string{parties.Permit.Spender.Hex()}, true
+	}
+
+	for _, party := range []*common.Address{parties.VerifyingContract, parties.Spender} {
+		if party != nil {
+			destinations = append(destinations, party.Hex())
+		}
+	}
+	return destinations, len(destinations) > 0
+}
+
+func toWithdrawalAddressRes(entry *models.WithdrawalAddress, now time.Time) dto.WithdrawalAddressRes {
+	return dto.WithdrawalAddressRes{
+		WithdrawalAddressId: entry.WithdrawalAddressId,
+		Chain:               entry.Chain,
+		Address:             entry.Address,
+		Label:               entry.Label,
+		Active:              entry.IsActive(now),
+		ActiveDate:          entry.ActiveDate,
+		CreateDate:          entry.CreateDate,
+	}
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const allowlistDelay = 24 * time.Hour
+
+func TestWithdrawalAddressesWaitOutTheCoolingOffPeriod(t *testing.T) {
+	ctx := context.Background()
+	svc, store, notifier := newAllowlistService(t)
+	wallet := createSigningWallet(t, svc)
+	setAllowlist(t, svc, wallet, true, "")
+
+	// 1️⃣ With the allowlist on, unknown destinations are refused, the
+	// wallet's own addresses are not
+	requireNotAllowlisted(t, signEther(t, svc, wallet, "0.1"))
+	res := signEtherTo(t, svc, wallet, wallet.address)
+	require.Equal(t, 200, res.Code, res.Message)
+
+	// 2️⃣ A new address is saved pending and the owner told at once
+	before := time.Now()
+	res, err := svc.AddWithdrawalAddress(ctx, testUserId, wallet.walletId, &dto.AddWithdrawalAddressReq{
+		Passphrase: testPassphrase,
+		Chain:      crypto.ChainETH,
+		Address:    strings.ToLower(ledgerPayee),
+		Label:      "Bob",
+	}, dto.RequestMeta{IpAddress: "203.0.113.7"})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	added := res.Data.(dto.WithdrawalAddressRes)
+	require.Equal(t, common.HexToAddress(ledgerPayee).Hex(), added.Address)
+	require.False(t, added.Active)
+	require.WithinDuration(t, before.Add(allowlistDelay), added.ActiveDate, time.Minute)
+
+	require.Equal(t, []string{notifyWithdrawalAddressAdded}, notifier.events())
+	require.Equal(t, testUserId, notifier.sent[0].userId)
+	details := notifier.sent[0].details
+	require.Equal(t, added.Address, details["address"])
+	require.Equal(t, "203.0.113.7", details["ip_address"])
+	require.Contains(t, store.auditActions(), auditWithdrawalAddressAdded)
+
+	// 3️⃣ It cannot be paid to before the period is over
+	requireNotAllowlisted(t, signEther(t, svc, wallet, "0.1"))
+
+	res, err = svc.AddWithdrawalAddress(ctx, testUserId, wallet.walletId, &dto.AddWithdrawalAddressReq{
+		Passphrase: testPassphrase,
+		Chain:      crypto.ChainETH,
+		Address:    ledgerPayee,
+		Label:      "Bob again",
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 409, res.Code)
+
+	// 4️⃣ Then it can
+	passCoolingOff(store)
+	res = signEther(t, svc, wallet, "0.1")
+	require.Equal(t, 200, res.Code, res.Message)
+
+	// 5️⃣ Removal is immediate
+	res, err = svc.RemoveWithdrawalAddress(ctx, testUserId, wallet.walletId, added.WithdrawalAddressId, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	requireNotAllowlisted(t, signEther(t, svc, wallet, "0.1"))
+}
+
+func TestAddWithdrawalAddressRejects(t *testing.T) {
+	ctx := context.Background()
+	svc, store, notifier := newAllowlistService(t)
+	wallet := createSigningWallet(t, svc)
+
+	tests := []struct {
+		name    string
+		req     dto.AddWithdrawalAddressReq
+		code    int
+		message string
+	}{
+		{"wrong passphrase", dto.AddWithdrawalAddressReq{Passphrase: "wrong", Chain: crypto.ChainETH, Address: ledgerPayee}, 400, "invalid passphrase"},
+		{"malformed address", dto.AddWithdrawalAddressReq{Passphrase: testPassphrase, Chain: crypto.ChainETH, Address: "0x1234"}, 400, "invalid address"},
+		{"address of another chain", dto.AddWithdrawalAddressReq{Passphrase: testPassphrase, Chain: crypto.ChainETH, Address: "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"}, 400, "invalid address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.AddWithdrawalAddress(ctx, testUserId, wallet.walletId, &tt.req, dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code)
+			require.Equal(t, tt.message, res.Message)
+		})
+	}
+	require.Empty(t, store.withdrawals)
+	require.Empty(t, notifier.events())
+}
+
+func TestAllowlistSwitchOffWaitsOutTheCoolingOffPeriod(t *testing.T) {
+	svc, store, notifier := newAllowlistService(t)
+	wallet := createSigningWallet(t, svc)
+	setAllowlist(t, svc, wallet, true, "")
+
+	// 1️⃣ Switching off needs the passphrase
+	res, err := svc.SetAllowlistMode(context.Background(), testUserId, wallet.walletId, &dto.SetAllowlistModeReq{
+		Passphrase: "wrong",
+		Enabled:    new(bool),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+
+	// 2️⃣ It is scheduled, the owner told, and the allowlist still holds
+	allowlist := setAllowlist(t, svc, wallet, false, testPassphrase)
+	require.True(t, allowlist.Enabled)
+	require.NotNil(t, allowlist.DisableDate)
+	require.WithinDuration(t, time.Now().Add(allowlistDelay), *allowlist.DisableDate, time.Minute)
+	require.Equal(t, []string{notifyAllowlistDisabling}, notifier.events())
+	requireNotAllowlisted(t, signEther(t, svc, wallet, "0.1"))
+
+	// 3️⃣ Switching on again cancels it
+	allowlist = setAllowlist(t, svc, wallet, true, "")
+	require.True(t, allowlist.Enabled)
+	require.Nil(t, allowlist.DisableDate)
+	passCoolingOff(store)
+	requireNotAllowlisted(t, signEther(t, svc, wallet, "0.1"))
+
+	// 4️⃣ Once the period is over the allowlist is off
+	setAllowlist(t, svc, wallet, false, testPassphrase)
+	passCoolingOff(store)
+	res = signEther(t, svc, wallet, "0.1")
+	require.Equal(t, 200, res.Code, res.Message)
+
+	res, err = svc.GetAllowlist(context.Background(), testUserId, wallet.walletId)
+	require.NoError(t, err)
+	require.False(t, res.Data.(dto.WithdrawalAllowlistRes).Enabled)
+	require.Equal(t, []string{
+		auditAllowlistEnabled,
+		auditAllowlistDisableRequested,
+		auditAllowlistEnabled,
+		auditAllowlistDisableRequested,
+	}, filterActions(store.auditActions(), auditTransactionSigned))
+}
+
+func TestEthDestinations(t *testing.T) {
+	token := common.HexToAddress(mainnetUSDC)
+	payee := common.HexToAddress(ledgerPayee)
+	transfer, err := blockchain.PackERC20Transfer(payee, big.NewInt(1))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		tx   crypto.EthereumTx
+		want []string
+		ok   bool
+	}{
+		{"ether transfer", crypto.EthereumTx{To: &payee, Value: big.NewInt(1)}, []string{payee.Hex()}, true},
+		{"token transfer", crypto.EthereumTx{To: &token, Data: transfer}, []string{payee.Hex()}, true},
+		{"token transfer with ether", crypto.EthereumTx{To: &token, Value: big.NewInt(1), Data: transfer}, []string{payee.Hex(), token.Hex()}, true},
+		{"other contract call", crypto.EthereumTx{To: &token, Data: []byte{0xde, 0xad, 0xbe, 0xef}}, []string{token.Hex()}, true},
+		{"deployment", crypto.EthereumTx{Data: transfer}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destinations, ok := ethDestinations(&tt.tx)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, destinations)
+		})
+	}
+}
+
+// newAllowlistService returns a wallet service whose new withdrawal
+// addresses and allowlist switch-offs wait allowlistDelay.
+func newAllowlistService(t *testing.T) (*WalletServiceImpl, *memoryStore, *memoryNotifier) {
+	t.Helper()
+
+	store := newMemoryStore()
+	notifier := &memoryNotifier{}
+	svc := newTestWalletService(t, store)
+	svc.withdrawalRepo = store.withdrawalRepo()
+	svc.notifier = notifier
+	svc.allowlistDelay = allowlistDelay
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	return svc, store, notifier
+}
+
+func setAllowlist(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, enabled bool, passphrase string) dto.WithdrawalAllowlistRes {
+	t.Helper()
+
+	res, err := svc.SetAllowlistMode(context.Background(), testUserId, wallet.walletId, &dto.SetAllowlistModeReq{
+		Passphrase: passphrase,
+		Enabled:    &enabled,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	return res.Data.(dto.WithdrawalAllowlistRes)
+}
+
+// passCoolingOff moves every pending activation and switch-off in store
+// to now, as if the cooling-off period were over.
+func passCoolingOff(store *memoryStore) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	now := time.Now()
+	for i := range store.withdrawals {
+		store.withdrawals[i].ActiveDate = now
+	}
+	for _, w := range store.wallets {
+		if w.AllowlistDisableDate != nil {
+			w.AllowlistDisableDate = &now
+		}
+	}
+}
+
+// signEtherTo signs a transfer of 0.1 ether from the wallet to to.
+func signEtherTo(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, to string) *core.ApiResponse {
+	t.Helper()
+
+	sign := etherTransfer(t, wallet, "0.1")
+	sign.To = to
+	res, err := svc.SignEthTransaction(context.Background(), testUserId, wallet.walletId, &sign, dto.RequestMeta{})
+	require.NoError(t, err)
+	return res
+}
+
+func requireNotAllowlisted(t *testing.T, res *core.ApiResponse) {
+	t.Helper()
+
+	require.Equal(t, 403, res.Code, res.Message)
+	require.Equal(t, "destination not in withdrawal allowlist", res.Message)
+}
+
+// filterActions drops the actions named skip.
+func filterActions(actions []string, skip string) []string {
+	var kept []string
+	for _, action := range actions {
+		if action != skip {
+			kept = append(kept, action)
+		}
+	}
+	return kept
+}
+
+type notification struct {
+	userId  string
+	event   string
+	details map[string]any
+}
+
+// memoryNotifier records the notifications sent.
+type memoryNotifier struct {
+	mu   sync.Mutex
+	sent []notification
+}
+
+func (n *memoryNotifier) Notify(ctx context.Context, userId, event string, details map[string]any) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.sent = append(n.sent, notification{userId: userId, event: event, details: details})
+	return nil
+}
+
+func (n *memoryNotifier) events() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	events := make([]string, 0, len(n.sent))
+	for _, sent := range n.sent {
+		events = append(events, sent.event)
+	}
+	return events
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// auditWalletClaimed records an operator handing an unowned wallet to a
+// user.
+const auditWalletClaimed = "wallet.claimed"
+
+// WalletClaimServiceImpl hands wallets created before wallets had owners
+// to their users. Until claimed, such a wallet is unreachable through the
+// API.
+type WalletClaimServiceImpl struct {
+	walletRepo repositories.WalletRepository
+	userRepo   repositories.UserRepository
+	auditRepo  repositories.AuditEventRepository
+	txManager  repositories.TransactionManager
+}
+
+func NewWalletClaimService(
+	walletRepo repositories.WalletRepository,
+	userRepo repositories.UserRepository,
+	auditRepo repositories.AuditEventRepository,
+	txManager repositories.TransactionManager,
+) services.WalletClaimService {
+	return &WalletClaimServiceImpl{
+		walletRepo: walletRepo,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		txManager:  txManager,
+	}
+}
+
+// ListUnclaimed implements [services.WalletClaimService].
+func (s *WalletClaimServiceImpl) ListUnclaimed(ctx context.Context) ([]dto.WalletRes, error) {
+	wallets, err := s.walletRepo.ListUnowned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]dto.WalletRes, 0, len(wallets))
+	for i := range wallets {
+		res = append(res, toWalletRes(&wallets[i]))
+	}
+	return res, nil
+}
+
+// ClaimWallet implements [services.WalletClaimService].
+// It fails with [domainerrors.ErrNotFound] when the user does not exist or the
+// wallet is missing or already owned, and with [domainerrors.ErrConflict] when
+// the user already holds a wallet with the same secret phrase.
+func (s *WalletClaimServiceImpl) ClaimWallet(ctx context.Context, walletId, userId string) error {
+	if _, err := s.userRepo.GetUserByID(ctx, userId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domainerrors.ErrNotFound
+		}
+		return err
+	}
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.walletRepo.ClaimUnowned(ctx, walletId, userId); err != nil {
+			return err
+		}
+		return s.auditRepo.Create(ctx, &models.AuditEvent{
+			AuditEventId: uuid.New().String(),
+			UserId:       userId,
+			WalletId:     walletId,
+			Action:       auditWalletClaimed,
+			CreateDate:   time.Now(),
+		})
+	})
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestClaimWallet(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore(
+		&models.Wallet{WalletId: "legacy-1", Fingerprint: "fp-1"},
+		&models.Wallet{WalletId: "legacy-2", Fingerprint: "fp-2"},
+		&models.Wallet{WalletId: "owned", UserId: "user-2", Fingerprint: "fp-2"},
+	)
+	svc := NewWalletClaimService(store.walletRepo(), memoryUsers{ids: []string{testUserId, "user-2"}}, store.auditRepo(), store)
+
+	// 1️⃣ Wallets without an owner are listed
+	unclaimed, err := svc.ListUnclaimed(ctx)
+	require.NoError(t, err)
+	require.Len(t, unclaimed, 2)
+
+	// 2️⃣ Claiming one makes it reachable by its owner, once
+	require.NoError(t, svc.ClaimWallet(ctx, "legacy-1", testUserId))
+	_, err = store.walletRepo().GetByIdAndUser(ctx, "legacy-1", testUserId)
+	require.NoError(t, err)
+	require.Equal(t, []string{auditWalletClaimed}, store.auditActions())
+
+	require.ErrorIs(t, svc.ClaimWallet(ctx, "legacy-1", "user-2"), domainerrors.ErrNotFound)
+	require.ErrorIs(t, svc.ClaimWallet(ctx, "owned", testUserId), domainerrors.ErrNotFound)
+	require.ErrorIs(t, svc.ClaimWallet(ctx, "missing", testUserId), domainerrors.ErrNotFound)
+
+	// 3️⃣ Unknown users and duplicates of an owned wallet are refused
+	require.ErrorIs(t, svc.ClaimWallet(ctx, "legacy-2", "nobody"), domainerrors.ErrNotFound)
+	require.ErrorIs(t, svc.ClaimWallet(ctx, "legacy-2", "user-2"), domainerrors.ErrConflict)
+
+	unclaimed, err = svc.ListUnclaimed(ctx)
+	require.NoError(t, err)
+	require.Len(t, unclaimed, 1)
+	require.Equal(t, "legacy-2", unclaimed[0].WalletId)
+	require.Len(t, store.auditActions(), 1)
+}
+
+// memoryUsers knows the users whose IDs it holds.
+type memoryUsers struct {
+	repositories.UserRepository
+	ids []string
+}
+
+func (u memoryUsers) GetUserByID(ctx context.Context, id string) (models.Users, error) {
+	for _, userId := range u.ids {
+		if userId == id {
+			return models.Users{UserId: id}, nil
+		}
+	}
+	return models.Users{}, gorm.ErrRecordNotFound
+}
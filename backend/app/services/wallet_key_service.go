@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
+	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+)
+
+// rewrapBatchSize is the number of wallets loaded per page during a re-wrap.
+const rewrapBatchSize = 100
+
+type WalletKeyServiceImpl struct {
+	walletRepo repositories.WalletRepository
+	cryptoSvc  crypto.Service
+}
+
+func NewWalletKeyService(
+	walletRepo repositories.WalletRepository,
+	cryptoSvc crypto.Service,
+) services.WalletKeyService {
+	return &WalletKeyServiceImpl{
+		walletRepo: walletRepo,
+		cryptoSvc:  cryptoSvc,
+	}
+}
+
+// RewrapWallets implements [services.WalletKeyService].
+// Every wallet whose DEK is wrapped by an older KEK is re-wrapped under the
+// current one. Wallets without a passphrase whose record is outdated are
+// re-sealed in the current format on the spot; passphrase-protected legacy
+// wallets stay pending until their owner next unlocks them. A failing wallet is logged and skipped so
+// one bad row cannot block the rotation.
+func (s *WalletKeyServiceImpl) RewrapWallets(ctx context.Context) (*dto.RewrapWalletsRes, error) {
+	res := &dto.RewrapWalletsRes{KekId: s.cryptoSvc.CurrentKEKId()}
+
+	after := ""
+	for {
+		wallets, err := s.walletRepo.ListNotWrappedBy(ctx, res.KekId, after, rewrapBatchSize)
+		if err != nil {
+			return res, err
+		}
+		if len(wallets) == 0 {
+			return res, nil
+		}
+
+		for i := range wallets {
+			wallet := &wallets[i]
+			after = wallet.WalletId
+
+			switch {
+			case wallet.PassphraseHash == "" &&
+				s.cryptoSvc.NeedsReencrypt(sealedMnemonic(wallet)):
+				if err := s.upgrade(ctx, wallet); err != nil {
+					log.Printf("upgrade wallet %s: %v", wallet.WalletId, err)
+					res.Failed++
+					continue
+				}
+				res.Upgraded++
+
+			case wallet.WrappedDek != "":
+				if err := s.rewrap(ctx, wallet); err != nil {
+					log.Printf("rewrap wallet %s: %v", wallet.WalletId, err)
+					res.Failed++
+					continue
+				}
+				res.Rewrapped++
+
+			default:
+				res.Pending++
+			}
+		}
+	}
+}
+
+// rewrap moves the wallet's DEK to the current KEK.
+func (s *WalletKeyServiceImpl) rewrap(ctx context.Context, wallet *models.Wallet) error {
+	sealed, err := s.cryptoSvc.RewrapMnemonic(ctx, sealedMnemonic(wallet))
+	if err != nil {
+		return err
+	}
+
+	return s.walletRepo.UpdateEncryptedMnemonic(
+		ctx,
+		wallet.WalletId,
+		sealed.Ciphertext,
+		sealed.WrappedKey,
+		sealed.KeyId,
+	)
+}
+
+// upgrade re-seals a passphrase-less wallet in the current format.
+func (s *WalletKeyServiceImpl) upgrade(ctx context.Context, wallet *models.Wallet) error {
+	mnemonic, err := s.cryptoSvc.DecryptMnemonic(ctx, sealedMnemonic(wallet), "", wallet.WalletId)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := s.cryptoSvc.EncryptMnemonic(ctx, mnemonic, "", wallet.WalletId)
+	if err != nil {
+		return err
+	}
+
+	return s.walletRepo.UpdateEncryptedMnemonic(
+		ctx,
+		wallet.WalletId,
+		sealed.Ciphertext,
+		sealed.WrappedKey,
+		sealed.KeyId,
+	)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReleaseNonce implements [services.WalletService].
+// It gives back a nonce leased while signing, once the caller decides not
+// to broadcast the transaction, so the allocator can reuse it.
+func (s *WalletServiceImpl) ReleaseNonce(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.ReleaseNonceReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Check the address belongs to the wallet
+	account, resp := s.nonceAccount(ctx, userId, walletId, req.Address)
+	if resp != nil {
+		return resp, nil
+	}
+
+	// 2️⃣ Release the lease
+	err := s.nonceManager.Release(ctx, account, *req.Nonce)
+	if errors.Is(err, blockchain.ErrNonceNotReserved) {
+		return core.Error(409, "nonce is not reserved", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot release nonce", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "nonce released", nil, nil), nil
+}
+
+// CommitNonce implements [services.WalletService].
+// It ends the lease of a nonce once its transaction was broadcast, so the
+// lease cannot run out and hand the nonce out a second time.
+func (s *WalletServiceImpl) CommitNonce(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.CommitNonceReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Check the address belongs to the wallet
+	account, resp := s.nonceAccount(ctx, userId, walletId, req.Address)
+	if resp != nil {
+		return resp, nil
+	}
+
+	// 2️⃣ Commit the lease
+	err := s.nonceManager.Commit(ctx, account, *req.Nonce)
+	if errors.Is(err, blockchain.ErrNonceNotReserved) {
+		return core.Error(409, "nonce is not reserved", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot commit nonce", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "nonce committed", nil, nil), nil
+}
+
+// ResyncNonce implements [services.WalletService].
+// It realigns the allocator with the chain's pending nonce, e.g. after a
+// transaction was sent from the address outside this service or a
+// broadcast transaction was dropped by the network.
+func (s *WalletServiceImpl) ResyncNonce(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.ResyncNonceReq,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Check the address belongs to the wallet
+	account, resp := s.nonceAccount(ctx, userId, walletId, req.Address)
+	if resp != nil {
+		return resp, nil
+	}
+
+	// 2️⃣ Resync with the chain
+	next, err := s.nonceManager.Resync(ctx, account)
+	if err != nil {
+		return core.Error(500, "cannot resync nonce", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "nonce resynced", dto.NonceRes{
+		Address:   account.Hex(),
+		NextNonce: next,
+	}, nil), nil
+}
+
+// nonceAccount returns address as an account of the wallet whose nonces
+// can be allocated, or the error response to send.
+func (s *WalletServiceImpl) nonceAccount(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	address string,
+) (common.Address, *core.ApiResponse) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return common.Address{}, core.Error(404, "wallet not found", nil, nil)
+	}
+	if err != nil {
+		return common.Address{}, core.Error(500, "cannot load wallet", err.Error(), nil)
+	}
+
+	found := false
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain == crypto.ChainETH && strings.EqualFold(addr.Address, address) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return common.Address{}, core.Error(404, "address not found in wallet", nil, nil)
+	}
+
+	network, err := walletNetwork(wallet, crypto.ChainETH)
+	if err != nil {
+		return common.Address{}, core.Error(500, "invalid wallet network", err.Error(), nil)
+	}
+	if resp := s.checkNonceManager(network); resp != nil {
+		return common.Address{}, resp
+	}
+
+	return common.HexToAddress(address), nil
+}
+
+// checkNonceManager returns an error response unless nonces can be
+// allocated on network.
+func (s *WalletServiceImpl) checkNonceManager(network *crypto.Network) *core.ApiResponse {
+	if s.nonceManager == nil || s.nonceManager.Network() != network.Name {
+		return core.Error(503, "no nonce allocator for wallet network", nil, map[string]any{
+			"network": network.Name,
+		})
+	}
+	return nil
+}
+
+// releaseNonce gives back a nonce after signing failed, also when the
+// failure was the request being cancelled. A failure only delays reuse
+// until the lease runs out, so it is logged.
+func (s *WalletServiceImpl) releaseNonce(ctx context.Context, account common.Address, nonce uint64) {
+	if err := s.nonceManager.Release(context.WithoutCancel(ctx), account, nonce); err != nil {
+		log.Printf("release nonce %d of %s: %v", nonce, account.Hex(), err)
+	}
+}
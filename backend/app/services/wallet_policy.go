@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+)
+
+// Spending policy rules, as named in violations.
+const (
+	ruleMaxPerTransaction = "max_per_transaction"
+	ruleDailyLimit        = "daily_limit"
+	ruleWeeklyLimit       = "weekly_limit"
+	ruleAllowedHours      = "allowed_hours"
+	ruleUnknownAsset      = "unknown_asset"
+)
+
+// Spending policy scopes.
+const (
+	policyScopeWallet = "wallet"
+	policyScopeUser   = "user"
+)
+
+const (
+	dailyWindow  = 24 * time.Hour
+	weeklyWindow = 7 * 24 * time.Hour
+)
+
+// enforcePolicies checks a signing request of wallet against the spending
+// policies of the wallet and its owner, and records transfers against
+// their rolling caps. It returns the reservation to release if signing
+// fails, or the response refusing the request.
+//
+// Every policy for "*" bounds the hours of any signing; the other rules
+// apply to transfers of their asset, allowances included. Tokens missing
+// from the registry and opaque calls cannot be matched to a policy, so
+// they are refused while any amount limit binds the wallet. The rolling
+// approval thresholds are held in the same step, see approvalCaps.
+func (s *WalletServiceImpl) enforcePolicies(
+	ctx context.Context,
+	wallet *models.Wallet,
+	transfers []outgoingTransfer,
+) (*SpendingReservation, *core.ApiResponse) {
+
+	policies, err := s.policyRepo.ListForWallet(ctx, wallet.UserId, wallet.WalletId)
+	if err != nil {
+		return nil, core.Error(500, "cannot load spending policies", err.Error(), nil)
+	}
+
+	// 1️⃣ What cannot be matched to an asset might be what a limit is for
+	if limited := amountLimited(policies); limited != nil {
+		for _, transfer := range transfers {
+			if transfer.opaque || (transfer.asset.Symbol == "" && transfer.value.Sign() > 0) {
+				value := transfer.value
+				return nil, policyViolation(limited, ruleUnknownAsset, dto.SpendingPolicyViolationRes{
+					Amount: &value,
+				})
+			}
+		}
+	}
+
+	// 2️⃣ Hours and per-transaction maximums
+	now := time.Now()
+	for i := range policies {
+		policy := &policies[i]
+		applies := policy.Asset == models.AnyAsset
+		for j := range transfers {
+			transfer := &transfers[j]
+			if transfer.asset.Symbol == "" || !policy.Covers(transfer.asset.Symbol) {
+				continue
+			}
+			applies = true
+
+			if policy.MaxPerTransaction != nil && transfer.value.Cmp(*policy.MaxPerTransaction) > 0 {
+				return nil, policyViolation(policy, ruleMaxPerTransaction, dto.SpendingPolicyViolationRes{
+					Asset:  transfer.asset.Symbol,
+					Amount: &transfer.value,
+					Limit:  policy.MaxPerTransaction,
+				})
+			}
+		}
+		if !applies {
+			continue
+		}
+
+		allowed, err := policy.HoursAllow(now)
+		if err != nil {
+			return nil, core.Error(500, "invalid spending policy timezone", err.Error(), nil)
+		}
+		if !allowed {
+			return nil, policyViolation(policy, ruleAllowedHours, dto.SpendingPolicyViolationRes{
+				Asset:           policy.Asset,
+				AllowedFromHour: policy.AllowedFromHour,
+				AllowedToHour:   policy.AllowedToHour,
+				Timezone:        policy.Timezone,
+			})
+		}
+	}
+
+	// 3️⃣ Rolling caps, checked and recorded in one step. Spending is
+	// always recorded, so a new cap counts the week before it
+	var uses []SpendingUse
+	for _, transfer := range transfers {
+		if transfer.asset.Symbol == "" || transfer.value.Sign() == 0 {
+			continue
+		}
+		uses = append(uses,
+			SpendingUse{Counter: spendingCounter(wallet.WalletId, transfer.asset.Symbol), Amount: transfer.value},
+			SpendingUse{Counter: spendingCounter("", transfer.asset.Symbol), Amount: transfer.value},
+		)
+	}
+	approvalUses, caps, err := s.approvalCaps(ctx, transfers)
+	if err != nil {
+		return nil, core.Error(500, "invalid withdrawal approval threshold", err.Error(), nil)
+	}
+	uses = append(uses, approvalUses...)
+	if len(uses) == 0 {
+		return nil, nil
+	}
+
+	type capRule struct {
+		policy *models.SpendingPolicy
+		rule   string
+	}
+	rules := make(map[string]capRule)
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Asset == models.AnyAsset {
+			continue
+		}
+		walletId := ""
+		if policy.WalletId != nil {
+			walletId = *policy.WalletId
+		}
+		counter := spendingCounter(walletId, policy.Asset)
+		for _, limit := range []struct {
+			rule   string
+			window time.Duration
+			limit  *amount.Int
+		}{
+			{ruleDailyLimit, dailyWindow, policy.DailyLimit},
+			{ruleWeeklyLimit, weeklyWindow, policy.WeeklyLimit},
+		} {
+			if limit.limit == nil {
+				continue
+			}
+			caps = append(caps, SpendingCap{Counter: counter, Window: limit.window, Limit: *limit.limit})
+			rules[capKey(counter, limit.window)] = capRule{policy, limit.rule}
+		}
+	}
+
+	reservation, exceeded, used, err := s.limiter.Reserve(ctx, wallet.UserId, uses, caps)
+	if err != nil {
+		return nil, core.Error(500, "cannot check spending limits", err.Error(), nil)
+	}
+	if exceeded != nil {
+		var value amount.Int
+		for _, use := range uses {
+			if use.Counter == exceeded.Counter {
+				value = value.Add(use.Amount)
+			}
+		}
+		// Approval thresholds are the caps without a policy
+		hit, ok := rules[capKey(exceeded.Counter, exceeded.Window)]
+		if !ok {
+			return nil, s.approvalRequired(exceeded, value, used)
+		}
+		return nil, policyViolation(hit.policy, hit.rule, dto.SpendingPolicyViolationRes{
+			Asset:  hit.policy.Asset,
+			Amount: &value,
+			Limit:  &exceeded.Limit,
+			Used:   &used,
+			Window: formatWindow(exceeded.Window),
+		})
+	}
+
+	return reservation, nil
+}
+
+// amountLimited returns the first of policies that limits amounts, if any.
+func amountLimited(policies []models.SpendingPolicy) *models.SpendingPolicy {
+	for i := range policies {
+		policy := &policies[i]
+		if policy.MaxPerTransaction != nil || policy.DailyLimit != nil || policy.WeeklyLimit != nil {
+			return policy
+		}
+	}
+	return nil
+}
+
+// releaseSpending takes back the spending of a signing that failed, also
+// when the failure was the request being cancelled.
+func (s *WalletServiceImpl) releaseSpending(ctx context.Context, reservation *SpendingReservation) {
+	if err := s.limiter.Release(context.WithoutCancel(ctx), reservation); err != nil {
+		log.Printf("release spending reservation: %v", err)
+	}
+}
+
+// spendingCounter names the counter of asset for a wallet, or for all of
+// the user's wallets when walletId is empty.
+func spendingCounter(walletId, asset string) string {
+	if walletId == "" {
+		return policyScopeUser + ":" + strings.ToUpper(asset)
+	}
+	return policyScopeWallet + ":" + walletId + ":" + strings.ToUpper(asset)
+}
+
+func capKey(counter string, window time.Duration) string {
+	return counter + "/" + window.String()
+}
+
+// policyViolation renders the refusal of a signing request by a rule of
+// policy.
+func policyViolation(
+	policy *models.SpendingPolicy,
+	rule string,
+	violation dto.SpendingPolicyViolationRes,
+) *core.ApiResponse {
+
+	violation.Rule = rule
+	violation.SpendingPolicyId = policy.SpendingPolicyId
+	violation.Scope = policyScope(policy)
+	return core.Error(403, "spending policy violated", violation, nil)
+}
+
+func policyScope(policy *models.SpendingPolicy) string {
+	if policy.WalletId == nil {
+		return policyScopeUser
+	}
+	return policyScopeWallet
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendingPolicyMaxPerTransaction(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+	policy := withPolicy(t, svc, models.SpendingPolicy{
+		UserId:            testUserId,
+		WalletId:          &wallet.walletId,
+		Asset:             "eth",
+		MaxPerTransaction: etherLimit(t, "1"),
+	})
+
+	require.Equal(t, 200, signEther(t, svc, wallet, "1").Code)
+
+	violation := requireViolation(t, signEther(t, svc, wallet, "1.5"), ruleMaxPerTransaction)
+	require.Equal(t, policy.SpendingPolicyId, violation.SpendingPolicyId)
+	require.Equal(t, policyScopeWallet, violation.Scope)
+	require.Equal(t, "ETH", violation.Asset)
+	require.Equal(t, ether(t, "1.5"), violation.Amount.String())
+	require.Equal(t, oneEther, violation.Limit.String())
+
+	// Other wallets of the user are not bound by it
+	other := createSigningWallet(t, svc)
+	require.Equal(t, 200, signEther(t, svc, other, "1.5").Code)
+}
+
+func TestSpendingPolicyRollingCaps(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+	other := createSigningWallet(t, svc)
+	withPolicy(t, svc, models.SpendingPolicy{
+		UserId:     testUserId,
+		WalletId:   &wallet.walletId,
+		Asset:      "ETH",
+		DailyLimit: etherLimit(t, "1"),
+	})
+	withPolicy(t, svc, models.SpendingPolicy{
+		UserId:      testUserId,
+		Asset:       "ETH",
+		WeeklyLimit: etherLimit(t, "2"),
+	})
+
+	// 1️⃣ The wallet's daily cap counts what it signed
+	require.Equal(t, 200, signEther(t, svc, wallet, "0.6").Code)
+	violation := requireViolation(t, signEther(t, svc, wallet, "0.6"), ruleDailyLimit)
+	require.Equal(t, policyScopeWallet, violation.Scope)
+	require.Equal(t, ether(t, "0.6"), violation.Used.String())
+	require.Equal(t, ether(t, "0.6"), violation.Amount.String())
+	require.Equal(t, "1d", violation.Window)
+
+	// 2️⃣ A signing that fails gives its spending back
+	sign := etherTransfer(t, wallet, "0.4")
+	sign.ReserveNonce = true
+	res, err := svc.SignEthTransaction(context.Background(), testUserId, wallet.walletId, &sign, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 503, res.Code)
+	require.Equal(t, 200, signEther(t, svc, wallet, "0.4").Code)
+
+	// 3️⃣ The user's weekly cap counts all their wallets
+	require.Equal(t, 200, signEther(t, svc, other, "0.9").Code)
+	violation = requireViolation(t, signEther(t, svc, other, "0.2"), ruleWeeklyLimit)
+	require.Equal(t, policyScopeUser, violation.Scope)
+	require.Equal(t, ether(t, "1.9"), violation.Used.String())
+	require.Equal(t, "7d", violation.Window)
+	require.Equal(t, 200, signEther(t, svc, other, "0.1").Code)
+}
+
+func TestSpendingPolicyAllowedHours(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	// A policy for any asset closes signing outside its hours
+	hour := time.Now().UTC().Hour()
+	from, to := (hour+1)%24, (hour+2)%24
+	withPolicy(t, svc, models.SpendingPolicy{
+		UserId:          testUserId,
+		Asset:           models.AnyAsset,
+		AllowedFromHour: &from,
+		AllowedToHour:   &to,
+		Timezone:        "UTC",
+	})
+
+	violation := requireViolation(t, signEther(t, svc, wallet, "0.1"), ruleAllowedHours)
+	require.Equal(t, models.AnyAsset, violation.Asset)
+	require.Equal(t, from, *violation.AllowedFromHour)
+	require.Equal(t, to, *violation.AllowedToHour)
+	require.Equal(t, "UTC", violation.Timezone)
+}
+
+func TestSpendingPolicyRefusesUnknownAssets(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	svc.tokenRepo = &memoryTokens{}
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	// Without amount limits a contract call is signed
+	call := etherTransfer(t, wallet, "0")
+	call.To = mainnetUSDC
+	call.Gas = 100000
+	call.Data = "0xdeadbeef"
+	signCall := func() *core.ApiResponse {
+		res, err := svc.SignEthTransaction(context.Background(), testUserId, wallet.walletId, &call, dto.RequestMeta{})
+		require.NoError(t, err)
+		return res
+	}
+	require.Equal(t, 200, signCall().Code)
+
+	// With one, what the call moves cannot be told, so it is refused
+	withPolicy(t, svc, models.SpendingPolicy{
+		UserId:     testUserId,
+		Asset:      "ETH",
+		DailyLimit: etherLimit(t, "1"),
+	})
+	requireViolation(t, signCall(), ruleUnknownAsset)
+}
+
+// withPolicy saves policy for the service's signings and returns it.
+func withPolicy(t *testing.T, svc *WalletServiceImpl, policy models.SpendingPolicy) models.SpendingPolicy {
+	t.Helper()
+
+	policy.SpendingPolicyId = "policy-" + policy.UserId + "-" + policy.Asset
+	if policy.WalletId != nil {
+		policy.SpendingPolicyId += "-" + *policy.WalletId
+	}
+	if policy.Timezone == "" {
+		policy.Timezone = defaultPolicyTimezone
+	}
+	require.NoError(t, svc.policyRepo.Create(context.Background(), &policy))
+	return policy
+}
+
+// etherLimit is a policy limit of value ether.
+func etherLimit(t *testing.T, value string) *amount.Int {
+	t.Helper()
+
+	limit, err := amount.ParseDecimal(value, 18)
+	require.NoError(t, err)
+	return &limit
+}
+
+func requireViolation(t *testing.T, res *core.ApiResponse, rule string) dto.SpendingPolicyViolationRes {
+	t.Helper()
+
+	require.Equal(t, 403, res.Code, res.Message)
+	require.Equal(t, "spending policy violated", res.Message)
+	violation := res.Error.(dto.SpendingPolicyViolationRes)
+	require.Equal(t, rule, violation.Rule)
+	return violation
+}
+
+// memoryPolicies keeps spending policies in memory. Each user has at most
+// one policy per wallet, or for all wallets, and asset.
+type memoryPolicies struct {
+	mu       sync.Mutex
+	policies []models.SpendingPolicy
+}
+
+func (r *memoryPolicies) Create(ctx context.Context, policy *models.SpendingPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.policies {
+		if p.UserId == policy.UserId && sameWallet(p.WalletId, policy.WalletId) && p.Asset == policy.Asset {
+			return domainerrors.ErrConflict
+		}
+	}
+	r.policies = append(r.policies, *policy)
+	return nil
+}
+
+func (r *memoryPolicies) GetById(ctx context.Context, spendingPolicyId string) (*models.SpendingPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.policies {
+		if p.SpendingPolicyId == spendingPolicyId {
+			return &p, nil
+		}
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (r *memoryPolicies) Update(ctx context.Context, policy *models.SpendingPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range r.policies {
+		if p.SpendingPolicyId == policy.SpendingPolicyId {
+			r.policies[i] = *policy
+			return nil
+		}
+	}
+	return domainerrors.ErrNotFound
+}
+
+func (r *memoryPolicies) Delete(ctx context.Context, spendingPolicyId string) (*models.SpendingPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, p := range r.policies {
+		if p.SpendingPolicyId == spendingPolicyId {
+			r.policies = append(r.policies[:i], r.policies[i+1:]...)
+			return &p, nil
+		}
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (r *memoryPolicies) ListByUser(ctx context.Context, userId string) ([]models.SpendingPolicy, error) {
+	return r.list(func(p *models.SpendingPolicy) bool { return p.UserId == userId }), nil
+}
+
+func (r *memoryPolicies) ListForWallet(ctx context.Context, userId, walletId string) ([]models.SpendingPolicy, error) {
+	return r.list(func(p *models.SpendingPolicy) bool {
+		return p.UserId == userId && (p.WalletId == nil || *p.WalletId == walletId)
+	}), nil
+}
+
+func (r *memoryPolicies) list(match func(p *models.SpendingPolicy) bool) []models.SpendingPolicy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var policies []models.SpendingPolicy
+	for i := range r.policies {
+		if match(&r.policies[i]) {
+			policies = append(policies, r.policies[i])
+		}
+	}
+	return policies
+}
+
+func sameWallet(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
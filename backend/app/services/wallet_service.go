@@ -2,181 +2,837 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
 	"github.com/create-go-app/fiber-go-template/app/dto"
 	models "github.com/create-go-app/fiber-go-template/app/entities"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
 	"github.com/create-go-app/fiber-go-template/pkg/core"
 	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
 	"github.com/google/uuid"
 )
 
+// defaultImportedWalletName names an imported wallet when the request has none.
+const defaultImportedWalletName = "Imported wallet"
+
+// Audit event actions.
+const (
+	auditPassphraseAdded        = "wallet.passphrase_added"
+	auditPassphraseChanged      = "wallet.passphrase_changed"
+	auditPassphraseChangeFailed = "wallet.passphrase_change_failed"
+	auditTransactionSigned      = "wallet.transaction_signed"
+	auditMessageSigned          = "wallet.message_signed"
+
+	auditWithdrawalAddressAdded    = "wallet.withdrawal_address_added"
+	auditWithdrawalAddressRemoved  = "wallet.withdrawal_address_removed"
+	auditAllowlistEnabled          = "wallet.allowlist_enabled"
+	auditAllowlistDisableRequested = "wallet.allowlist_disable_requested"
+
+	auditWithdrawalRequested = "wallet.withdrawal_requested"
+	auditWithdrawalApproved  = "withdrawal.approved"
+	auditWithdrawalRejected  = "withdrawal.rejected"
+	auditWithdrawalExecuted  = "wallet.withdrawal_executed"
+
+	auditWatchOnlyImported = "wallet.watch_only_imported"
+)
+
+var (
+	errInvalidPassphrase     = errors.New("invalid passphrase")
+	errInvalidSeedPassphrase = errors.New("invalid seed passphrase")
+	errWatchOnlyWallet       = errors.New("watch-only wallet cannot sign")
+)
+
 type WalletServiceImpl struct {
-	walletRepo  repositories.WalletRepository
-	addressRepo repositories.BlockchainAddressRepository
-	cryptoSvc   crypto.Service
-	txManager   repositories.TransactionManager
+	walletRepo            repositories.WalletRepository
+	addressRepo           repositories.BlockchainAddressRepository
+	auditRepo             repositories.AuditEventRepository
+	tokenRepo             repositories.Erc20TokenRepository
+	withdrawalRepo        repositories.WithdrawalAddressRepository
+	withdrawalRequestRepo repositories.WithdrawalRequestRepository
+	cryptoSvc             crypto.Service
+	txManager             repositories.TransactionManager
+	nonceManager          *blockchain.NonceManager
+	notifier              services.Notifier
+	allowlistDelay        time.Duration
+	approvals             WithdrawalApprovalConfig
+	policyRepo            repositories.SpendingPolicyRepository
+	limiter               *SpendingLimiter
 }
 
 func NewWalletService(
 	walletRepo repositories.WalletRepository,
 	addressRepo repositories.BlockchainAddressRepository,
+	auditRepo repositories.AuditEventRepository,
+	tokenRepo repositories.Erc20TokenRepository,
+	withdrawalRepo repositories.WithdrawalAddressRepository,
+	withdrawalRequestRepo repositories.WithdrawalRequestRepository,
 	cryptoSvc crypto.Service,
 	txManager repositories.TransactionManager,
+	nonceManager *blockchain.NonceManager,
+	notifier services.Notifier,
+	allowlistDelay time.Duration,
+	approvals WithdrawalApprovalConfig,
+	policyRepo repositories.SpendingPolicyRepository,
+	limiter *SpendingLimiter,
 ) services.WalletService {
 	return &WalletServiceImpl{
-		walletRepo:  walletRepo,
-		addressRepo: addressRepo,
-		cryptoSvc:   cryptoSvc,
-		txManager:   txManager,
+		walletRepo:            walletRepo,
+		addressRepo:           addressRepo,
+		auditRepo:             auditRepo,
+		tokenRepo:             tokenRepo,
+		withdrawalRepo:        withdrawalRepo,
+		withdrawalRequestRepo: withdrawalRequestRepo,
+		cryptoSvc:             cryptoSvc,
+		txManager:             txManager,
+		nonceManager:          nonceManager,
+		notifier:              notifier,
+		allowlistDelay:        allowlistDelay,
+		approvals:             approvals.withDefaults(),
+		policyRepo:            policyRepo,
+		limiter:               limiter,
 	}
 }
 
 func (s *WalletServiceImpl) CreateWallet(
 	ctx context.Context,
+	userId string,
 	req *dto.CreateWalletReq,
 ) (*dto.CreateWalletRes, error) {
 
 	var (
-		walletId string
-		address  string
+		walletId     string
+		addresses    []dto.AddressRes
+		secretPhrase string
 	)
-	var secretPhrase string
 
 	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 
-		// 1️⃣ Wallet ID
-		walletId = uuid.New().String()
-
-		// 2️⃣ Generate mnemonic
-		mnemonic, err := s.cryptoSvc.GenerateMnemonic()
+		// 1️⃣ Generate mnemonic
+		mnemonic, err := s.cryptoSvc.GenerateMnemonic(req.WordCount, req.Language)
 		if err != nil {
 			return err
 		}
 		secretPhrase = mnemonic
-		// 3️⃣ Encrypt mnemonic
-		encryptedMnemonic, err := s.cryptoSvc.EncryptMnemonic(
-			mnemonic,
-			req.Passphrase,
-			walletId,
-		)
+
+		// 2️⃣ Persist wallet and first addresses
+		wallet, created, err := s.persistWallet(ctx, userId, mnemonic, walletSpec{
+			name:           req.WalletName,
+			passphrase:     req.Passphrase,
+			seedPassphrase: req.SeedPassphrase,
+			chains:         req.Chains,
+			ethNetwork:     req.EthNetwork,
+			btcNetwork:     req.BtcNetwork,
+		})
 		if err != nil {
 			return err
 		}
 
-		// 4️⃣ Hash passphrase (optional)
-		var passphraseHash string
-		if req.Passphrase != "" {
-			passphraseHash, err = s.cryptoSvc.HashPassphrase(req.Passphrase)
-			if err != nil {
-				return err
-			}
-		}
+		walletId = wallet.WalletId
+		addresses = created
 
-		now := time.Now()
+		// ✅ return nil → commit
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateWalletRes{
+		WalletId:     walletId,
+		Address:      addresses[0].Address,
+		Addresses:    addresses,
+		SecretPhrase: secretPhrase,
+	}, nil
+}
+
+// walletSpec carries the caller's choices for a wallet being persisted.
+type walletSpec struct {
+	name           string
+	passphrase     string
+	seedPassphrase string
+	chains         []string
+	ethNetwork     string
+	btcNetwork     string
+}
+
+// persistWallet encrypts mnemonic into a new wallet owned by userId and
+// derives the first address of every requested chain. It must run
+// inside a transaction.
+func (s *WalletServiceImpl) persistWallet(
+	ctx context.Context,
+	userId string,
+	mnemonic string,
+	spec walletSpec,
+) (*models.Wallet, []dto.AddressRes, error) {
+
+	// 1️⃣ Wallet ID
+	walletId := uuid.New().String()
+
+	// 2️⃣ Encrypt mnemonic
+	encryptedMnemonic, err := s.cryptoSvc.EncryptMnemonic(
+		ctx,
+		mnemonic,
+		spec.passphrase,
+		walletId,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		// 5️⃣ Create wallet
-		wallet := &models.Wallet{
-			WalletId:         walletId,
-			WalletName:       req.WalletName,
-			SecretPhraseHash: encryptedMnemonic,
-			PassphraseHash:   passphraseHash,
-			CreateDate:       now,
-			UpdateDate:       now,
+	// 3️⃣ Hash passphrase (optional)
+	var passphraseHash string
+	if spec.passphrase != "" {
+		passphraseHash, err = s.cryptoSvc.HashPassphrase(spec.passphrase)
+		if err != nil {
+			return nil, nil, err
 		}
+	}
 
-		if err := s.walletRepo.Create(ctx, wallet); err != nil {
-			return err
+	fingerprint, err := s.cryptoSvc.Fingerprint(mnemonic, spec.seedPassphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+
+	// 4️⃣ Create wallet
+	wallet := &models.Wallet{
+		WalletId:          walletId,
+		UserId:            userId,
+		WalletName:        spec.name,
+		EthNetwork:        defaultNetwork(spec.ethNetwork),
+		BtcNetwork:        defaultNetwork(spec.btcNetwork),
+		Fingerprint:       fingerprint,
+		SecretPhraseHash:  encryptedMnemonic.Ciphertext,
+		WrappedDek:        encryptedMnemonic.WrappedKey,
+		KekId:             encryptedMnemonic.KeyId,
+		PassphraseHash:    passphraseHash,
+		HasSeedPassphrase: spec.seedPassphrase != "",
+		CreateDate:        now,
+		UpdateDate:        now,
+	}
+
+	if err := s.walletRepo.Create(ctx, wallet); err != nil {
+		return nil, nil, err
+	}
+
+	// 5️⃣ Generate first address of every requested chain
+	chains := spec.chains
+	if len(chains) == 0 {
+		chains = []string{crypto.ChainETH}
+	}
+
+	addresses := make([]dto.AddressRes, 0, len(chains))
+	for _, chain := range chains {
+		network, err := walletNetwork(wallet, chain)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		// 6️⃣ Generate first address
-		address, err = s.cryptoSvc.GenerateAddress(mnemonic)
+		path, err := crypto.PathFor(network, "", 0, 0, 0)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
-		addr := &models.BlockchainAddress{
-			AddressId:  uuid.New().String(),
-			WalletId:   walletId,
-			Address:    address,
-			CreateDate: now,
-			UpdateDate: now,
+		address, err := s.cryptoSvc.GenerateAddress(mnemonic, spec.seedPassphrase, network, path)
+		if err != nil {
+			return nil, nil, err
 		}
 
+		addr := newBlockchainAddress(walletId, address, chain, path, now)
 		if err := s.addressRepo.Create(ctx, addr); err != nil {
-			return err
+			return nil, nil, err
 		}
 
-		// ✅ return nil → commit
-		return nil
+		addresses = append(addresses, toAddressRes(addr))
+	}
+
+	return wallet, addresses, nil
+}
+
+// RestoreWallet implements [services.WalletService].
+func (s *WalletServiceImpl) RestoreWallet(
+	ctx context.Context,
+	userId string,
+	req *dto.RestoreWalletReq,
+) (*core.ApiResponse, error) {
+
+	// 0️⃣ The wordlist language is detected from the phrase itself; a typo
+	// is reported with its position and the closest wordlist entries
+	if _, err := s.cryptoSvc.DetectLanguage(req.SecretPhrase); err != nil {
+		var mnemonicErr *crypto.MnemonicError
+		if errors.As(err, &mnemonicErr) {
+			return core.Error(400, "invalid secret phrase", mnemonicErr, nil), nil
+		}
+		return core.Error(400, "restore failed", "invalid secret phrase or passphrase", nil), nil
+	}
+
+	fingerprint, err := s.cryptoSvc.Fingerprint(req.SecretPhrase, req.SeedPassphrase)
+	if err != nil {
+		return core.Error(400, "restore failed", "invalid secret phrase or passphrase", nil), nil
+	}
+
+	// 1️⃣ Indexed lookup by fingerprint
+	wallet, err := s.walletRepo.GetByFingerprint(ctx, userId, fingerprint)
+	if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Fall back to wallets created before fingerprints existed,
+	// which never had a seed passphrase
+	if wallet == nil && req.SeedPassphrase == "" {
+		wallet, err = s.findLegacyWallet(ctx, userId, req)
+		if err != nil {
+			return core.Error(500, "cannot load wallets", err.Error(), nil), nil
+		}
+	}
+
+	// 3️⃣ No wallet holds this seed: import it when the caller asked to.
+	// A fingerprinted seed always matches above, so the same seed is
+	// never imported twice under one account
+	if wallet == nil {
+		if !req.Import {
+			return core.Error(400, "restore failed", "invalid secret phrase or passphrase", nil), nil
+		}
+		return s.importWallet(ctx, userId, req)
+	}
+
+	// 4️⃣ Verify passphrase and compare secret phrase
+	mnemonic, err := s.unlockMnemonic(ctx, wallet, req.Passphrase)
+	if err != nil || !sameMnemonic(mnemonic, req.SecretPhrase) {
+		return core.Error(400, "restore failed", "invalid secret phrase or passphrase", nil), nil
+	}
+
+	// ✅ SUCCESS
+	addresses := make([]string, 0)
+	for _, addr := range wallet.BlockchainAddresses {
+		addresses = append(addresses, addr.Address)
+	}
+
+	return core.Success(200, "wallet restored", dto.RestoreWalletRes{
+		WalletId:  wallet.WalletId,
+		Addresses: addresses,
+	}, nil), nil
+}
+
+// importWallet stores an externally generated mnemonic as a new wallet
+// of userId, encrypted under the request passphrase.
+func (s *WalletServiceImpl) importWallet(
+	ctx context.Context,
+	userId string,
+	req *dto.RestoreWalletReq,
+) (*core.ApiResponse, error) {
+
+	name := req.WalletName
+	if name == "" {
+		name = defaultImportedWalletName
+	}
+
+	var (
+		wallet  *models.Wallet
+		created []dto.AddressRes
+	)
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		wallet, created, err = s.persistWallet(
+			ctx,
+			userId,
+			crypto.NormalizeMnemonic(req.SecretPhrase),
+			walletSpec{
+				name:           name,
+				passphrase:     req.Passphrase,
+				seedPassphrase: req.SeedPassphrase,
+				chains:         req.Chains,
+				ethNetwork:     req.EthNetwork,
+				btcNetwork:     req.BtcNetwork,
+			},
+		)
+		return err
 	})
 
+	// A concurrent import of the same seed loses on the fingerprint index
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "import failed", "wallet already exists", nil), nil
+	}
 	if err != nil {
-		return nil, err
+		return core.Error(500, "cannot import wallet", err.Error(), nil), nil
 	}
 
-	return &dto.CreateWalletRes{
-		WalletId:     walletId,
-		Address:      address,
-		SecretPhrase: secretPhrase,
-	}, nil
+	addresses := make([]string, 0, len(created))
+	for _, addr := range created {
+		addresses = append(addresses, addr.Address)
+	}
+
+	return core.Success(201, "wallet imported", dto.RestoreWalletRes{
+		WalletId:  wallet.WalletId,
+		Addresses: addresses,
+		Imported:  true,
+	}, nil), nil
 }
 
-// RestoreWallet implements [services.WalletService].
-func (s *WalletServiceImpl) RestoreWallet(
+// findLegacyWallet scans the user's wallets that have no fingerprint yet.
+// The matching wallet gets its fingerprint backfilled by unlockMnemonic,
+// so this set shrinks to nothing as legacy wallets are used.
+func (s *WalletServiceImpl) findLegacyWallet(
 	ctx context.Context,
+	userId string,
 	req *dto.RestoreWalletReq,
+) (*models.Wallet, error) {
+
+	wallets, err := s.walletRepo.ListWithoutFingerprint(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range wallets {
+		wallet := &wallets[i]
+
+		if wallet.PassphraseHash != "" &&
+			!s.cryptoSvc.VerifyPassphrase(wallet.PassphraseHash, req.Passphrase) {
+			continue
+		}
+
+		mnemonic, err := s.cryptoSvc.DecryptMnemonic(
+			ctx,
+			sealedMnemonic(wallet),
+			req.Passphrase,
+			wallet.WalletId,
+		)
+		if err != nil || !sameMnemonic(mnemonic, req.SecretPhrase) {
+			continue
+		}
+
+		return wallet, nil
+	}
+
+	return nil, nil
+}
+
+// GetWallets implements [services.WalletService].
+func (s *WalletServiceImpl) GetWallets(
+	ctx context.Context,
+	userId string,
 ) (*core.ApiResponse, error) {
 
-	wallets, err := s.walletRepo.ListAll(ctx)
+	wallets, err := s.walletRepo.ListByUser(ctx, userId)
 	if err != nil {
 		return core.Error(500, "cannot load wallets", err.Error(), nil), nil
 	}
 
-	for _, wallet := range wallets {
+	res := make([]dto.WalletRes, 0, len(wallets))
+	for i := range wallets {
+		res = append(res, toWalletRes(&wallets[i]))
+	}
+
+	return core.Success(200, "ok", res, nil), nil
+}
 
-		// 1️⃣ Verify passphrase (nếu wallet có passphrase)
-		if wallet.PassphraseHash != "" {
-			if req.Passphrase == "" {
-				continue
-			}
+// GetWallet implements [services.WalletService].
+func (s *WalletServiceImpl) GetWallet(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) (*core.ApiResponse, error) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "ok", toWalletRes(wallet), nil), nil
+}
 
-			if !s.cryptoSvc.VerifyPassphrase(
-				wallet.PassphraseHash,
-				req.Passphrase,
-			) {
-				continue
-			}
+// DeriveAddress implements [services.WalletService].
+func (s *WalletServiceImpl) DeriveAddress(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.DeriveAddressReq,
+) (*core.ApiResponse, error) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	// Watch-only wallets derive from their extended public key, unlocked
+	if wallet.WatchOnly() {
+		return s.deriveWatchOnlyAddress(ctx, wallet, req)
+	}
+
+	mnemonic, err := s.unlockMnemonic(ctx, wallet, req.Passphrase)
+	if errors.Is(err, errInvalidPassphrase) {
+		return core.Error(400, "invalid passphrase", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot unlock wallet", err.Error(), nil), nil
+	}
+
+	if err := s.verifySeedPassphrase(wallet, mnemonic, req.SeedPassphrase); err != nil {
+		if errors.Is(err, errInvalidSeedPassphrase) {
+			return core.Error(400, "invalid seed passphrase", nil, nil), nil
+		}
+		return core.Error(500, "cannot unlock wallet", err.Error(), nil), nil
+	}
+
+	chain := req.Chain
+	if chain == "" {
+		chain = crypto.ChainETH
+	}
+
+	network, err := walletNetwork(wallet, chain)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+
+	// Index is resolved below; purpose and coin type only depend on network and type.
+	path, err := crypto.PathFor(network, req.AddressType, req.Account, req.Change, 0)
+	if err != nil {
+		return core.Error(400, "invalid derivation path", err.Error(), nil), nil
+	}
+
+	var addr *models.BlockchainAddress
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		// Derivations of one wallet queue on its row, so two of them
+		// never read the same next index
+		if _, err := s.walletRepo.GetByIdForUpdate(ctx, wallet.WalletId); err != nil {
+			return err
+		}
+
+		index, err := s.addressRepo.NextIndex(ctx, wallet.WalletId, chain, path.Purpose, path.Account, path.Change)
+		if err != nil {
+			return err
+		}
+		path.Index = index
+
+		address, err := s.cryptoSvc.GenerateAddress(mnemonic, req.SeedPassphrase, network, path)
+		if err != nil {
+			return err
+		}
+
+		addr = newBlockchainAddress(wallet.WalletId, address, chain, path, time.Now())
+		return s.addressRepo.Create(ctx, addr)
+	})
+	if err != nil {
+		return core.Error(500, "derive address failed", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "address created", toAddressRes(addr), nil), nil
+}
+
+// ChangePassphrase implements [services.WalletService].
+// It also adds a passphrase to a wallet created without one. The mnemonic
+// is re-sealed under a fresh DEK, so the old passphrase opens nothing.
+func (s *WalletServiceImpl) ChangePassphrase(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.ChangePassphraseReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	if req.NewPassphrase == req.CurrentPassphrase {
+		return core.Error(400, "new passphrase must differ from the current one", nil, nil), nil
+	}
+
+	var action string
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+
+		// 1️⃣ Load wallet
+		wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+		if err != nil {
+			return err
+		}
+		if wallet.WatchOnly() {
+			return errWatchOnlyWallet
+		}
+
+		// 2️⃣ Verify old passphrase and decrypt with the old key
+		if wallet.PassphraseHash != "" &&
+			!s.cryptoSvc.VerifyPassphrase(wallet.PassphraseHash, req.CurrentPassphrase) {
+			return errInvalidPassphrase
 		}
 
-		// 2️⃣ Try decrypt mnemonic
 		mnemonic, err := s.cryptoSvc.DecryptMnemonic(
-			wallet.SecretPhraseHash,
-			req.Passphrase,
+			ctx,
+			sealedMnemonic(wallet),
+			req.CurrentPassphrase,
 			wallet.WalletId,
 		)
 		if err != nil {
-			continue
+			return errInvalidPassphrase
 		}
 
-		// 3️⃣ Compare secret phrase
-		if mnemonic != req.SecretPhrase {
-			continue
+		// 3️⃣ Re-encrypt with the new passphrase
+		sealed, err := s.cryptoSvc.EncryptMnemonic(ctx, mnemonic, req.NewPassphrase, wallet.WalletId)
+		if err != nil {
+			return err
 		}
 
-		// ✅ SUCCESS
-		addresses := make([]string, 0)
-		for _, addr := range wallet.BlockchainAddresses {
-			addresses = append(addresses, addr.Address)
+		passphraseHash, err := s.cryptoSvc.HashPassphrase(req.NewPassphrase)
+		if err != nil {
+			return err
+		}
+
+		// 4️⃣ Update hash and ciphertext together
+		if err := s.walletRepo.UpdatePassphrase(
+			ctx,
+			wallet.WalletId,
+			passphraseHash,
+			sealed.Ciphertext,
+			sealed.WrappedKey,
+			sealed.KeyId,
+		); err != nil {
+			return err
+		}
+
+		// 5️⃣ Audit
+		action = auditPassphraseChanged
+		if wallet.PassphraseHash == "" {
+			action = auditPassphraseAdded
 		}
+		return s.audit(ctx, userId, walletId, action, meta, nil)
+	})
 
-		return core.Success(200, "wallet restored", dto.RestoreWalletRes{
-			WalletId:  wallet.WalletId,
-			Addresses: addresses,
-		}, nil), nil
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
 	}
+	if errors.Is(err, errWatchOnlyWallet) {
+		return core.Error(400, "watch-only wallet has no passphrase", nil, nil), nil
+	}
+	if errors.Is(err, errInvalidPassphrase) {
+		// Failed attempts are recorded outside the rolled-back transaction
+		if err := s.audit(ctx, userId, walletId, auditPassphraseChangeFailed, meta, nil); err != nil {
+			return core.Error(500, "cannot write audit event", err.Error(), nil), nil
+		}
+		return core.Error(400, "invalid passphrase", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot change passphrase", err.Error(), nil), nil
+	}
+
+	message := "passphrase changed"
+	if action == auditPassphraseAdded {
+		message = "passphrase added"
+	}
+	return core.Success(200, message, nil, nil), nil
+}
+
+// audit records a security-relevant action on a wallet, with optional
+// non-secret details stored as JSON.
+func (s *WalletServiceImpl) audit(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	action string,
+	meta dto.RequestMeta,
+	details map[string]string,
+) error {
+
+	var metadata string
+	if len(details) > 0 {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		metadata = string(raw)
+	}
+
+	return s.auditRepo.Create(ctx, &models.AuditEvent{
+		AuditEventId: uuid.New().String(),
+		UserId:       userId,
+		WalletId:     walletId,
+		Action:       action,
+		IpAddress:    meta.IpAddress,
+		UserAgent:    meta.UserAgent,
+		Metadata:     metadata,
+		CreateDate:   time.Now(),
+	})
+}
+
+// unlockMnemonic checks the wallet passphrase and decrypts the stored mnemonic.
+// Legacy wallets get their fingerprint backfilled on the first successful unlock.
+// Watch-only wallets have no mnemonic, so every signing path stops here.
+func (s *WalletServiceImpl) unlockMnemonic(
+	ctx context.Context,
+	wallet *models.Wallet,
+	passphrase string,
+) (string, error) {
+
+	if wallet.WatchOnly() {
+		return "", errWatchOnlyWallet
+	}
+
+	if wallet.PassphraseHash != "" &&
+		!s.cryptoSvc.VerifyPassphrase(wallet.PassphraseHash, passphrase) {
+		return "", errInvalidPassphrase
+	}
+
+	mnemonic, err := s.cryptoSvc.DecryptMnemonic(
+		ctx,
+		sealedMnemonic(wallet),
+		passphrase,
+		wallet.WalletId,
+	)
+	if err != nil {
+		return "", errInvalidPassphrase
+	}
+
+	if s.cryptoSvc.NeedsReencrypt(sealedMnemonic(wallet)) {
+		// Records in an older format or with a weaker KDF are re-sealed
+		// the first time the passphrase is available.
+		sealed, err := s.cryptoSvc.EncryptMnemonic(ctx, mnemonic, passphrase, wallet.WalletId)
+		if err != nil {
+			return "", err
+		}
+		if err := s.walletRepo.UpdateEncryptedMnemonic(
+			ctx,
+			wallet.WalletId,
+			sealed.Ciphertext,
+			sealed.WrappedKey,
+			sealed.KeyId,
+		); err != nil {
+			return "", err
+		}
+		wallet.SecretPhraseHash = sealed.Ciphertext
+		wallet.WrappedDek = sealed.WrappedKey
+		wallet.KekId = sealed.KeyId
+	}
+
+	if wallet.Fingerprint == "" {
+		// Legacy wallets predate seed passphrases, so their seed used none.
+		fingerprint, err := s.cryptoSvc.Fingerprint(mnemonic, "")
+		if err != nil {
+			return "", err
+		}
+		if err := s.walletRepo.UpdateFingerprint(ctx, wallet.WalletId, fingerprint); err != nil {
+			return "", err
+		}
+		wallet.Fingerprint = fingerprint
+	}
+
+	return mnemonic, nil
+}
+
+// sealedMnemonic collects the stored envelope of the wallet's mnemonic.
+func sealedMnemonic(wallet *models.Wallet) *crypto.EncryptedMnemonic {
+	return &crypto.EncryptedMnemonic{
+		Ciphertext: wallet.SecretPhraseHash,
+		WrappedKey: wallet.WrappedDek,
+		KeyId:      wallet.KekId,
+	}
+}
+
+// verifySeedPassphrase checks the BIP39 seed passphrase against the wallet
+// fingerprint. A wrong one would silently derive addresses of another wallet.
+func (s *WalletServiceImpl) verifySeedPassphrase(
+	wallet *models.Wallet,
+	mnemonic string,
+	seedPassphrase string,
+) error {
+
+	fingerprint, err := s.cryptoSvc.Fingerprint(mnemonic, seedPassphrase)
+	if err != nil {
+		return err
+	}
+	if fingerprint != wallet.Fingerprint {
+		return errInvalidSeedPassphrase
+	}
+	return nil
+}
 
-	return core.Error(400, "restore failed", "invalid secret phrase or passphrase", nil), nil
+// sameMnemonic compares two phrases after BIP39 normalization, so spacing
+// differences (e.g. the ideographic space used by Japanese) do not matter.
+func sameMnemonic(a, b string) bool {
+	return crypto.NormalizeMnemonic(a) == crypto.NormalizeMnemonic(b)
+}
+
+// walletNetwork resolves the network the wallet uses on the given chain.
+func walletNetwork(wallet *models.Wallet, chain string) (*crypto.Network, error) {
+	switch chain {
+	case crypto.ChainETH:
+		return crypto.LookupNetwork(chain, wallet.EthNetwork)
+	case crypto.ChainBTC:
+		return crypto.LookupNetwork(chain, wallet.BtcNetwork)
+	default:
+		return nil, fmt.Errorf("unsupported chain %q", chain)
+	}
+}
+
+func defaultNetwork(name string) string {
+	if name == "" {
+		return crypto.NetworkMainnet
+	}
+	return name
+}
+
+func newBlockchainAddress(
+	walletId string,
+	address string,
+	chain string,
+	path crypto.DerivationPath,
+	now time.Time,
+) *models.BlockchainAddress {
+	return &models.BlockchainAddress{
+		AddressId:      uuid.New().String(),
+		WalletId:       walletId,
+		Address:        address,
+		Chain:          chain,
+		DerivationPath: path.String(),
+		Purpose:        path.Purpose,
+		Account:        path.Account,
+		Change:         path.Change,
+		AddressIndex:   path.Index,
+		CreateDate:     now,
+		UpdateDate:     now,
+	}
+}
+
+func toAddressRes(addr *models.BlockchainAddress) dto.AddressRes {
+	return dto.AddressRes{
+		AddressId:      addr.AddressId,
+		Address:        addr.Address,
+		Chain:          addr.Chain,
+		DerivationPath: addr.DerivationPath,
+		Account:        addr.Account,
+		Change:         addr.Change,
+		Index:          addr.AddressIndex,
+		CreateDate:     addr.CreateDate,
+	}
+}
+
+func toWalletRes(wallet *models.Wallet) dto.WalletRes {
+	addresses := make([]dto.AddressRes, 0, len(wallet.BlockchainAddresses))
+	for i := range wallet.BlockchainAddresses {
+		addresses = append(addresses, toAddressRes(&wallet.BlockchainAddresses[i]))
+	}
+
+	return dto.WalletRes{
+		WalletId:          wallet.WalletId,
+		WalletName:        wallet.WalletName,
+		EthNetwork:        wallet.EthNetwork,
+		BtcNetwork:        wallet.BtcNetwork,
+		HasSeedPassphrase: wallet.HasSeedPassphrase,
+		WatchOnly:         wallet.WatchOnly(),
+		ExtendedPublicKey: wallet.ExtendedPublicKey,
+		Addresses:         addresses,
+		CreateDate:        wallet.CreateDate,
+		UpdateDate:        wallet.UpdateDate,
+	}
 }
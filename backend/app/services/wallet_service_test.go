@@ -0,0 +1,556 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	testUserId     = "user-1"
+	testMnemonic   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testPassphrase = "correct horse"
+
+	testKeyring = `{
+  "primary": "kek-test",
+  "keys": [
+    {"id": "kek-test", "key": "WlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlo=", "createDate": "2024-01-01T00:00:00Z"}
+  ]
+}`
+)
+
+func TestWalletsBelongToTheirCreator(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Main"})
+	require.NoError(t, err)
+
+	// 1️⃣ The owner lists and loads the wallet
+	res, err := svc.GetWallets(ctx, testUserId)
+	require.NoError(t, err)
+	require.Len(t, res.Data.([]dto.WalletRes), 1)
+
+	res, err = svc.GetWallet(ctx, testUserId, created.WalletId)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code)
+	require.Equal(t, created.Address, res.Data.(dto.WalletRes).Addresses[0].Address)
+
+	// 2️⃣ Other users see nothing of it
+	res, err = svc.GetWallets(ctx, "user-2")
+	require.NoError(t, err)
+	require.Empty(t, res.Data.([]dto.WalletRes))
+
+	res, err = svc.GetWallet(ctx, "user-2", created.WalletId)
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+}
+
+func TestCreateWalletMnemonicLengthAndLanguage(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{
+		WalletName: "Spanish",
+		WordCount:  24,
+		Language:   crypto.LanguageSpanish,
+	})
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(created.SecretPhrase), 24)
+
+	language, err := svc.cryptoSvc.DetectLanguage(created.SecretPhrase)
+	require.NoError(t, err)
+	require.Equal(t, crypto.LanguageSpanish, language)
+
+	_, err = svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Odd", WordCount: 13})
+	require.Error(t, err)
+}
+
+func TestRestoreWalletByFingerprint(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Main", Passphrase: testPassphrase})
+	require.NoError(t, err)
+	restore := &dto.RestoreWalletReq{SecretPhrase: created.SecretPhrase, Passphrase: testPassphrase}
+
+	// 1️⃣ The wallet is found by its fingerprint, without a scan
+	res, err := svc.RestoreWallet(ctx, testUserId, restore)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, created.WalletId, res.Data.(dto.RestoreWalletRes).WalletId)
+	require.Zero(t, store.legacyScans)
+
+	// 2️⃣ The passphrase must still open it, and only for its owner
+	res, err = svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{SecretPhrase: created.SecretPhrase, Passphrase: "wrong"})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+
+	res, err = svc.RestoreWallet(ctx, "user-2", restore)
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+}
+
+func TestRestoreWalletImportsExternalPhrase(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	// 1️⃣ Without the import flag an unknown phrase is not a wallet
+	res, err := svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{SecretPhrase: testMnemonic, Passphrase: testPassphrase})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Empty(t, store.wallets)
+
+	// 2️⃣ With it the phrase becomes a new wallet of the caller
+	importReq := &dto.RestoreWalletReq{
+		SecretPhrase: "  " + strings.ToUpper(testMnemonic),
+		Passphrase:   testPassphrase,
+		Import:       true,
+		Chains:       []string{crypto.ChainETH, crypto.ChainBTC},
+	}
+	res, err = svc.RestoreWallet(ctx, testUserId, importReq)
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+
+	imported := res.Data.(dto.RestoreWalletRes)
+	require.True(t, imported.Imported)
+	require.Equal(t, []string{
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+	}, imported.Addresses)
+	require.Equal(t, defaultImportedWalletName, store.wallets[imported.WalletId].WalletName)
+	require.Equal(t, testUserId, store.wallets[imported.WalletId].UserId)
+
+	// The mnemonic is sealed under the passphrase, normalized
+	mnemonic, err := svc.unlockMnemonic(ctx, store.wallets[imported.WalletId], testPassphrase)
+	require.NoError(t, err)
+	require.Equal(t, testMnemonic, mnemonic)
+
+	// 3️⃣ Importing the same seed again restores the first import
+	res, err = svc.RestoreWallet(ctx, testUserId, importReq)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, imported.WalletId, res.Data.(dto.RestoreWalletRes).WalletId)
+	require.False(t, res.Data.(dto.RestoreWalletRes).Imported)
+	require.Len(t, store.wallets, 1)
+
+	// 4️⃣ Another user imports a wallet of their own
+	res, err = svc.RestoreWallet(ctx, "user-2", importReq)
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	require.NotEqual(t, imported.WalletId, res.Data.(dto.RestoreWalletRes).WalletId)
+}
+
+func TestRestoreWalletExplainsTypos(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+
+	tests := []struct {
+		name   string
+		phrase string
+		code   string
+	}{
+		{"word count", "abandon abandon about", crypto.MnemonicErrWordCount},
+		{"unknown word", strings.Replace(testMnemonic, "about", "abuot", 1), crypto.MnemonicErrUnknownWord},
+		{"checksum", strings.Replace(testMnemonic, "about", "abandon", 1), crypto.MnemonicErrChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{SecretPhrase: tt.phrase})
+			require.NoError(t, err)
+			require.Equal(t, 400, res.Code)
+			require.Equal(t, "invalid secret phrase", res.Message)
+			require.Equal(t, tt.code, res.Error.(*crypto.MnemonicError).Code)
+		})
+	}
+
+	// A valid phrase with stray capitals and spaces only misses the wallet
+	res, err := svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{SecretPhrase: " " + strings.ToUpper(testMnemonic)})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "restore failed", res.Message)
+}
+
+func TestRestoreWalletBackfillsLegacyFingerprint(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Legacy"})
+	require.NoError(t, err)
+	fingerprint := store.wallets[created.WalletId].Fingerprint
+	store.wallets[created.WalletId].Fingerprint = ""
+	restore := &dto.RestoreWalletReq{SecretPhrase: created.SecretPhrase}
+
+	// A wallet from before fingerprints is found by scanning once, then
+	// by its backfilled fingerprint
+	for i := 0; i < 2; i++ {
+		res, err := svc.RestoreWallet(ctx, testUserId, restore)
+		require.NoError(t, err)
+		require.Equal(t, 200, res.Code, res.Message)
+		require.Equal(t, created.WalletId, res.Data.(dto.RestoreWalletRes).WalletId)
+		require.Equal(t, fingerprint, store.wallets[created.WalletId].Fingerprint)
+		require.Equal(t, 1, store.legacyScans)
+	}
+}
+
+func TestSeedPassphraseSelectsTheWallet(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{
+		WalletName:     "Hidden",
+		Passphrase:     testPassphrase,
+		SeedPassphrase: "TREZOR",
+	})
+	require.NoError(t, err)
+
+	// 1️⃣ Addresses come from the seed of phrase and seed passphrase
+	mainnet, err := crypto.LookupNetwork(crypto.ChainETH, crypto.NetworkMainnet)
+	require.NoError(t, err)
+	plain, err := svc.cryptoSvc.GenerateAddress(created.SecretPhrase, "", mainnet, crypto.EthereumPath(0, 0, 0))
+	require.NoError(t, err)
+	require.NotEqual(t, plain, created.Address)
+
+	res, err := svc.GetWallet(ctx, testUserId, created.WalletId)
+	require.NoError(t, err)
+	require.True(t, res.Data.(dto.WalletRes).HasSeedPassphrase)
+
+	// 2️⃣ Deriving needs the same seed passphrase
+	for _, seed := range []string{"", "trezor"} {
+		res, err = svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Passphrase: testPassphrase, SeedPassphrase: seed})
+		require.NoError(t, err)
+		require.Equal(t, 400, res.Code)
+		require.Equal(t, "invalid seed passphrase", res.Message)
+	}
+
+	res, err = svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Passphrase: testPassphrase, SeedPassphrase: "TREZOR"})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	want, err := svc.cryptoSvc.GenerateAddress(created.SecretPhrase, "TREZOR", mainnet, crypto.EthereumPath(0, 0, 1))
+	require.NoError(t, err)
+	require.Equal(t, want, res.Data.(dto.AddressRes).Address)
+
+	// 3️⃣ Restoring finds the wallet only with the seed passphrase
+	res, err = svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{SecretPhrase: created.SecretPhrase, Passphrase: testPassphrase})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+
+	res, err = svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{
+		SecretPhrase:   created.SecretPhrase,
+		Passphrase:     testPassphrase,
+		SeedPassphrase: "TREZOR",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, created.WalletId, res.Data.(dto.RestoreWalletRes).WalletId)
+}
+
+func TestDeriveAddressAdvancesIndex(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{
+		WalletName: "Main",
+		Passphrase: testPassphrase,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/0'/0/0", created.Addresses[0].DerivationPath)
+
+	for _, want := range []string{"m/44'/60'/0'/0/1", "m/44'/60'/0'/0/2"} {
+		res, err := svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Passphrase: testPassphrase})
+		require.NoError(t, err)
+		require.Equal(t, 201, res.Code, res.Message)
+		require.Equal(t, want, res.Data.(dto.AddressRes).DerivationPath)
+	}
+
+	// Change addresses count separately
+	res, err := svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Passphrase: testPassphrase, Change: 1})
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/0'/1/0", res.Data.(dto.AddressRes).DerivationPath)
+
+	res, err = svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Passphrase: "wrong"})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+
+	res, err = svc.DeriveAddress(ctx, "user-2", created.WalletId, &dto.DeriveAddressReq{Passphrase: testPassphrase})
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+}
+
+func TestDeriveBitcoinAddresses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{
+		WalletName: "Both",
+		Chains:     []string{crypto.ChainETH, crypto.ChainBTC},
+	})
+	require.NoError(t, err)
+	require.Len(t, created.Addresses, 2)
+	require.Equal(t, crypto.ChainBTC, created.Addresses[1].Chain)
+	require.Equal(t, "m/84'/0'/0'/0/0", created.Addresses[1].DerivationPath)
+	require.True(t, strings.HasPrefix(created.Addresses[1].Address, "bc1q"))
+
+	// Each address type counts its own indexes
+	tests := []struct {
+		addressType string
+		path        string
+		prefix      string
+	}{
+		{crypto.AddressTypeP2SHP2WPKH, "m/49'/0'/0'/0/0", "3"},
+		{crypto.AddressTypeP2PKH, "m/44'/0'/0'/0/0", "1"},
+		{"", "m/84'/0'/0'/0/1", "bc1q"},
+	}
+	for _, tt := range tests {
+		res, err := svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{
+			Chain:       crypto.ChainBTC,
+			AddressType: tt.addressType,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 201, res.Code, res.Message)
+		addr := res.Data.(dto.AddressRes)
+		require.Equal(t, tt.path, addr.DerivationPath)
+		require.True(t, strings.HasPrefix(addr.Address, tt.prefix), addr.Address)
+	}
+}
+
+func TestCreateWalletOnTestNetworks(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{
+		WalletName: "Testnets",
+		Chains:     []string{crypto.ChainETH, crypto.ChainBTC},
+		EthNetwork: crypto.NetworkSepolia,
+		BtcNetwork: crypto.NetworkTestnet,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/0'/0/0", created.Addresses[0].DerivationPath)
+	require.Equal(t, "m/84'/1'/0'/0/0", created.Addresses[1].DerivationPath)
+	require.True(t, strings.HasPrefix(created.Addresses[1].Address, "tb1q"))
+
+	res, err := svc.GetWallet(ctx, testUserId, created.WalletId)
+	require.NoError(t, err)
+	wallet := res.Data.(dto.WalletRes)
+	require.Equal(t, crypto.NetworkSepolia, wallet.EthNetwork)
+	require.Equal(t, crypto.NetworkTestnet, wallet.BtcNetwork)
+
+	// Later addresses stay on the wallet's networks
+	res, err = svc.DeriveAddress(ctx, testUserId, created.WalletId, &dto.DeriveAddressReq{Chain: crypto.ChainBTC})
+	require.NoError(t, err)
+	require.Equal(t, "m/84'/1'/0'/0/1", res.Data.(dto.AddressRes).DerivationPath)
+	require.True(t, strings.HasPrefix(res.Data.(dto.AddressRes).Address, "tb1q"))
+}
+
+func TestDeriveAddressConcurrently(t *testing.T) {
+	const derivations = 10
+
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	// A watch-only wallet derives without unlocking, so the requests
+	// contend on the index alone
+	res, err := svc.ImportWatchOnlyWallet(ctx, testUserId, &dto.ImportWatchOnlyWalletReq{
+		WalletName:        "Watched",
+		ExtendedPublicKey: accountXpub(t, 44, 60, 0),
+		Chain:             crypto.ChainETH,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	walletId := res.Data.(dto.WalletRes).WalletId
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		indexes []int
+	)
+	for i := 0; i < derivations; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := svc.DeriveAddress(ctx, testUserId, walletId, &dto.DeriveAddressReq{})
+			if !assertCreated(t, res, err) {
+				return
+			}
+			mu.Lock()
+			indexes = append(indexes, int(res.Data.(dto.AddressRes).Index))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Every request got its own index, right after the first address
+	sort.Ints(indexes)
+	want := make([]int, 0, derivations)
+	for i := 1; i <= derivations; i++ {
+		want = append(want, i)
+	}
+	require.Equal(t, want, indexes)
+}
+
+func TestChangePassphrase(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	meta := dto.RequestMeta{IpAddress: "127.0.0.1", UserAgent: "test"}
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Open"})
+	require.NoError(t, err)
+
+	// 1️⃣ A wallet created without a passphrase gets one
+	res, err := svc.ChangePassphrase(ctx, testUserId, created.WalletId, &dto.ChangePassphraseReq{NewPassphrase: testPassphrase}, meta)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, "passphrase added", res.Message)
+	requireUnlocks(t, svc, store, created.WalletId, testPassphrase, created.SecretPhrase)
+
+	// 2️⃣ Changing it needs the current one; a failure is audited
+	res, err = svc.ChangePassphrase(ctx, testUserId, created.WalletId, &dto.ChangePassphraseReq{
+		CurrentPassphrase: "wrong",
+		NewPassphrase:     "battery staple",
+	}, meta)
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "invalid passphrase", res.Message)
+	requireUnlocks(t, svc, store, created.WalletId, testPassphrase, created.SecretPhrase)
+
+	res, err = svc.ChangePassphrase(ctx, testUserId, created.WalletId, &dto.ChangePassphraseReq{
+		CurrentPassphrase: testPassphrase,
+		NewPassphrase:     "battery staple",
+	}, meta)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, "passphrase changed", res.Message)
+
+	// 3️⃣ The mnemonic is re-sealed: only the new passphrase opens it
+	requireUnlocks(t, svc, store, created.WalletId, "battery staple", created.SecretPhrase)
+	_, err = svc.unlockMnemonic(ctx, store.wallets[created.WalletId], testPassphrase)
+	require.ErrorIs(t, err, errInvalidPassphrase)
+
+	require.Equal(t, []string{
+		auditPassphraseAdded,
+		auditPassphraseChangeFailed,
+		auditPassphraseChanged,
+	}, store.auditActions())
+	require.Equal(t, "127.0.0.1", store.events[0].IpAddress)
+}
+
+func TestChangePassphraseRejects(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+
+	created, err := svc.CreateWallet(ctx, testUserId, &dto.CreateWalletReq{WalletName: "Main", Passphrase: testPassphrase})
+	require.NoError(t, err)
+
+	// The same passphrase again changes nothing
+	res, err := svc.ChangePassphrase(ctx, testUserId, created.WalletId, &dto.ChangePassphraseReq{
+		CurrentPassphrase: testPassphrase,
+		NewPassphrase:     testPassphrase,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+
+	// Other users cannot reach the wallet
+	res, err = svc.ChangePassphrase(ctx, "user-2", created.WalletId, &dto.ChangePassphraseReq{
+		CurrentPassphrase: testPassphrase,
+		NewPassphrase:     "battery staple",
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+	require.Empty(t, store.auditActions())
+}
+
+// assertCreated reports whether a call from another goroutine returned
+// 201, failing the test otherwise.
+func assertCreated(t *testing.T, res *core.ApiResponse, err error) bool {
+	t.Helper()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return false
+	}
+	if res.Code != 201 {
+		t.Errorf("unexpected response %d: %s %v", res.Code, res.Message, res.Error)
+		return false
+	}
+	return true
+}
+
+// newTestWalletService builds a wallet service over store, sealing
+// mnemonics under the test keyring. Features a test does not set up are
+// left nil.
+func newTestWalletService(t *testing.T, store *memoryStore) *WalletServiceImpl {
+	t.Helper()
+
+	return NewWalletService(
+		store.walletRepo(),
+		store.addressRepo(),
+		store.auditRepo(),
+		nil,
+		nil,
+		nil,
+		newTestCryptoService(t),
+		store,
+		nil,
+		nil,
+		0,
+		WithdrawalApprovalConfig{},
+		nil,
+		nil,
+	).(*WalletServiceImpl)
+}
+
+func newTestCryptoService(t *testing.T) crypto.Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, []byte(testKeyring), 0o600))
+	keyring, err := crypto.LoadKeyring(path)
+	require.NoError(t, err)
+
+	svc, err := crypto.NewCryptoService(crypto.Config{FingerprintKey: []byte("fingerprint-key"), KEK: keyring})
+	require.NoError(t, err)
+	return svc
+}
+
+// accountXpub exports the account key m/purpose'/coinType'/account' of
+// testMnemonic as a mainnet xpub.
+func accountXpub(t *testing.T, purpose, coinType, account uint32) string {
+	t.Helper()
+
+	key, err := hdkeychain.NewMaster(bip39.NewSeed(testMnemonic, ""), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	for _, index := range []uint32{purpose, coinType, account} {
+		key, err = key.Derive(hdkeychain.HardenedKeyStart + index)
+		require.NoError(t, err)
+	}
+	pub, err := key.Neuter()
+	require.NoError(t, err)
+	return pub.String()
+}
+
+func requireUnlocks(t *testing.T, svc *WalletServiceImpl, store *memoryStore, walletId, passphrase, mnemonic string) {
+	t.Helper()
+
+	unlocked, err := svc.unlockMnemonic(context.Background(), store.wallets[walletId], passphrase)
+	require.NoError(t, err)
+	require.Equal(t, mnemonic, unlocked)
+}
@@ -0,0 +1,596 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errAddressNotFound = errors.New("address not found in wallet")
+
+// messageScope is what a signed message lets others do with the wallet's
+// funds, as far as it can be read.
+type messageScope struct {
+	// transfers are the allowances it grants.
+	transfers []outgoingTransfer
+	// destinations are the accounts it is addressed to. When undirected,
+	// they cannot be read and the message could favour anyone.
+	destinations []string
+	undirected   bool
+}
+
+// signer is an unlocked wallet address, ready to sign with.
+type signer struct {
+	wallet   *models.Wallet
+	address  *models.BlockchainAddress
+	path     crypto.DerivationPath
+	mnemonic string
+}
+
+// unlockSigner loads the wallet, checks both passphrases and resolves the
+// derivation path of one of its addresses on the given chain.
+func (s *WalletServiceImpl) unlockSigner(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	chain string,
+	address string,
+	passphrase string,
+	seedPassphrase string,
+) (*signer, error) {
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var addr *models.BlockchainAddress
+	for i := range wallet.BlockchainAddresses {
+		candidate := &wallet.BlockchainAddresses[i]
+		if candidate.Chain == chain && strings.EqualFold(candidate.Address, address) {
+			addr = candidate
+			break
+		}
+	}
+	if addr == nil {
+		return nil, errAddressNotFound
+	}
+
+	path, err := crypto.ParseDerivationPath(addr.DerivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonic, err := s.unlockWithSeed(ctx, wallet, passphrase, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signer{wallet: wallet, address: addr, path: path, mnemonic: mnemonic}, nil
+}
+
+// unlockWithSeed decrypts the mnemonic and checks the BIP39 seed passphrase.
+func (s *WalletServiceImpl) unlockWithSeed(
+	ctx context.Context,
+	wallet *models.Wallet,
+	passphrase string,
+	seedPassphrase string,
+) (string, error) {
+
+	mnemonic, err := s.unlockMnemonic(ctx, wallet, passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.verifySeedPassphrase(wallet, mnemonic, seedPassphrase); err != nil {
+		return "", err
+	}
+
+	return mnemonic, nil
+}
+
+// unlockSignerError maps an unlockSigner failure to a response.
+func unlockSignerError(err error) *core.ApiResponse {
+	switch {
+	case errors.Is(err, domainerrors.ErrNotFound):
+		return core.Error(404, "wallet not found", nil, nil)
+	case errors.Is(err, errAddressNotFound):
+		return core.Error(404, "address not found in wallet", nil, nil)
+	case errors.Is(err, errWatchOnlyWallet):
+		return core.Error(403, "watch-only wallet cannot sign", nil, nil)
+	case errors.Is(err, errInvalidPassphrase):
+		return core.Error(400, "invalid passphrase", nil, nil)
+	case errors.Is(err, errInvalidSeedPassphrase):
+		return core.Error(400, "invalid seed passphrase", nil, nil)
+	default:
+		return core.Error(500, "cannot unlock wallet", err.Error(), nil)
+	}
+}
+
+// SignEthTransaction implements [services.WalletService].
+// The transaction is only signed; broadcasting is left to the caller.
+func (s *WalletServiceImpl) SignEthTransaction(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SignEthTransactionReq,
+	meta dto.RequestMeta,
+) (resp *core.ApiResponse, err error) {
+
+	// 1️⃣ Parse transaction fields
+	tx, err := newEthereumTx(req)
+	if err != nil {
+		return core.Error(400, "invalid transaction", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Unlock the key of the sending address
+	sgn, err := s.unlockSigner(
+		ctx,
+		userId,
+		walletId,
+		crypto.ChainETH,
+		req.From,
+		req.Passphrase,
+		req.SeedPassphrase,
+	)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	// 3️⃣ Refuse to sign for a network other than the wallet's
+	network, err := walletNetwork(sgn.wallet, crypto.ChainETH)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+	if network.ChainID.Cmp(tx.ChainID) != 0 {
+		return core.Error(400, "chain id does not match wallet network", nil, map[string]any{
+			"network":  network.Name,
+			"chain_id": network.ChainID.String(),
+		}), nil
+	}
+
+	// 4️⃣ Only pay destinations on the withdrawal allowlist
+	destinations, ok := ethDestinations(tx)
+	if !ok && sgn.wallet.AllowlistEnforced(time.Now()) {
+		return core.Error(403, "contract deployment blocked by withdrawal allowlist", nil, nil), nil
+	}
+	if resp := s.checkDestinations(ctx, sgn.wallet, crypto.ChainETH, destinations); resp != nil {
+		return resp, nil
+	}
+
+	// 5️⃣ Large payments out of the wallet need approved withdrawals
+	transfers, err := s.ethTransfers(ctx, network, tx)
+	if err != nil {
+		return core.Error(500, "cannot read transfer", err.Error(), nil), nil
+	}
+	if resp := s.checkApproval(ctx, transfers); resp != nil {
+		return resp, nil
+	}
+
+	// 6️⃣ Count it against the spending policies; given back if signing fails
+	reservation, denied := s.enforcePolicies(ctx, sgn.wallet, transfers)
+	if denied != nil {
+		return denied, nil
+	}
+	defer func() {
+		if resp == nil || resp.Code != 200 {
+			s.releaseSpending(ctx, reservation)
+			return
+		}
+		holdUntilCommit(ctx, func(ctx context.Context) {
+			s.releaseSpending(ctx, reservation)
+		})
+	}()
+
+	// 7️⃣ Lease a nonce when asked; it is given back if signing fails
+	if req.ReserveNonce {
+		if resp := s.checkNonceManager(network); resp != nil {
+			return resp, nil
+		}
+		from := common.HexToAddress(sgn.address.Address)
+		nonce, err := s.nonceManager.Reserve(ctx, from)
+		if err != nil {
+			return core.Error(502, "cannot reserve nonce", err.Error(), nil), nil
+		}
+		tx.Nonce = nonce
+
+		defer func() {
+			if resp == nil || resp.Code != 200 {
+				s.releaseNonce(ctx, from, nonce)
+				return
+			}
+			holdUntilCommit(ctx, func(ctx context.Context) {
+				s.releaseNonce(ctx, from, nonce)
+			})
+		}()
+	}
+
+	// 8️⃣ Sign
+	signed, err := s.cryptoSvc.SignEthereumTx(sgn.mnemonic, req.SeedPassphrase, sgn.path, tx)
+	if errors.Is(err, crypto.ErrInvalidTransaction) {
+		return core.Error(400, "invalid transaction", err.Error(), nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot sign transaction", err.Error(), nil), nil
+	}
+	if !strings.EqualFold(signed.From, sgn.address.Address) {
+		return core.Error(500, "cannot sign transaction", "derived key does not match address", nil), nil
+	}
+
+	// 9️⃣ Audit before releasing the signature
+	if err := s.audit(ctx, userId, walletId, auditTransactionSigned, meta, map[string]string{
+		"chain": crypto.ChainETH,
+		"from":  signed.From,
+		"hash":  signed.Hash,
+	}); err != nil {
+		return core.Error(500, "cannot write audit event", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "transaction signed", dto.SignedTransactionRes{
+		From:           signed.From,
+		Nonce:          tx.Nonce,
+		Hash:           signed.Hash,
+		RawTransaction: signed.Raw,
+		Type:           signed.Type,
+	}, nil), nil
+}
+
+// newEthereumTx converts the request into transaction fields. Amounts have
+// already passed the uint256 validation.
+func newEthereumTx(req *dto.SignEthTransactionReq) (*crypto.EthereumTx, error) {
+	tx := &crypto.EthereumTx{
+		ChainID: new(big.Int).SetUint64(req.ChainId),
+		Nonce:   req.Nonce,
+		Gas:     req.Gas,
+	}
+
+	if req.To != "" {
+		to := common.HexToAddress(req.To)
+		tx.To = &to
+	}
+
+	for _, field := range []struct {
+		value string
+		dest  **big.Int
+	}{
+		{req.Value, &tx.Value},
+		{req.GasPrice, &tx.GasPrice},
+		{req.MaxFeePerGas, &tx.MaxFeePerGas},
+		{req.MaxPriorityFeePerGas, &tx.MaxPriorityFeePerGas},
+	} {
+		if field.value == "" {
+			continue
+		}
+		n, err := utils.ParseUint256(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.dest = n
+	}
+
+	if req.Data != "" {
+		data := strings.TrimPrefix(strings.TrimPrefix(req.Data, "0x"), "0X")
+		raw, err := hex.DecodeString(data)
+		if err != nil {
+			return nil, errors.New("data must be an even-length hex string")
+		}
+		tx.Data = raw
+	}
+
+	return tx, nil
+}
+
+// SignMessage implements [services.WalletService].
+// It produces an EIP-191 personal_sign signature.
+func (s *WalletServiceImpl) SignMessage(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SignMessageReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	message, err := decodeMessage(req.Message, req.Encoding)
+	if err != nil {
+		return core.Error(400, "invalid message", err.Error(), nil), nil
+	}
+
+	// The EIP-191 prefix keeps a personal message from passing for a
+	// transaction or typed data, so it moves nothing
+	return s.signHash(ctx, userId, walletId, req.Address, req.Passphrase, req.SeedPassphrase,
+		crypto.PersonalMessageHash(message), "personal", messageScope{}, meta)
+}
+
+// SignTypedData implements [services.WalletService].
+// It produces an EIP-712 eth_signTypedData_v4 signature.
+func (s *WalletServiceImpl) SignTypedData(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SignTypedDataReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	hash, chainID, err := crypto.TypedDataHash(req.TypedData)
+	if err != nil {
+		return core.Error(400, "invalid typed data", err.Error(), nil), nil
+	}
+	parties, err := crypto.ParseTypedDataParties(req.TypedData)
+	if err != nil {
+		return core.Error(400, "invalid typed data", err.Error(), nil), nil
+	}
+
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+	network, err := walletNetwork(wallet, crypto.ChainETH)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+
+	// A domain bound to another chain could be replayed there
+	if chainID != nil && network.ChainID.Cmp(chainID) != 0 {
+		return core.Error(400, "typed data chain id does not match wallet network", nil, map[string]any{
+			"network":  network.Name,
+			"chain_id": network.ChainID.String(),
+		}), nil
+	}
+
+	// A permit grants an allowance; other typed data may grant anything
+	var scope messageScope
+	scope.transfers, err = s.typedDataTransfers(ctx, network, parties)
+	if err != nil {
+		return core.Error(500, "cannot read transfer", err.Error(), nil), nil
+	}
+	destinations, ok := typedDataDestinations(parties)
+	scope.destinations, scope.undirected = destinations, !ok
+
+	return s.signHash(ctx, userId, walletId, req.Address, req.Passphrase, req.SeedPassphrase,
+		hash, "typed_data", scope, meta)
+}
+
+// signHash unlocks the address key, signs a message hash and audits it.
+// scope is what the message lets others do with the wallet's funds.
+func (s *WalletServiceImpl) signHash(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	address string,
+	passphrase string,
+	seedPassphrase string,
+	hash []byte,
+	kind string,
+	scope messageScope,
+	meta dto.RequestMeta,
+) (resp *core.ApiResponse, err error) {
+
+	sgn, err := s.unlockSigner(ctx, userId, walletId, crypto.ChainETH, address, passphrase, seedPassphrase)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	// Only address destinations on the withdrawal allowlist
+	if scope.undirected && sgn.wallet.AllowlistEnforced(time.Now()) {
+		return core.Error(403, "typed data blocked by withdrawal allowlist", nil, nil), nil
+	}
+	if resp := s.checkDestinations(ctx, sgn.wallet, crypto.ChainETH, scope.destinations); resp != nil {
+		return resp, nil
+	}
+
+	// Permits and unreadable typed data need approved withdrawals
+	if resp := s.checkApproval(ctx, scope.transfers); resp != nil {
+		return resp, nil
+	}
+
+	// Allowances count against the spending policies; messages that move
+	// nothing are still bound to the allowed hours
+	reservation, denied := s.enforcePolicies(ctx, sgn.wallet, scope.transfers)
+	if denied != nil {
+		return denied, nil
+	}
+	defer func() {
+		if resp == nil || resp.Code != 200 {
+			s.releaseSpending(ctx, reservation)
+			return
+		}
+		holdUntilCommit(ctx, func(ctx context.Context) {
+			s.releaseSpending(ctx, reservation)
+		})
+	}()
+
+	sig, err := s.cryptoSvc.SignHash(sgn.mnemonic, seedPassphrase, sgn.path, hash)
+	if err != nil {
+		return core.Error(500, "cannot sign message", err.Error(), nil), nil
+	}
+	if !strings.EqualFold(sig.Address, sgn.address.Address) {
+		return core.Error(500, "cannot sign message", "derived key does not match address", nil), nil
+	}
+
+	if err := s.audit(ctx, userId, walletId, auditMessageSigned, meta, map[string]string{
+		"type":    kind,
+		"address": sig.Address,
+		"hash":    sig.Hash,
+	}); err != nil {
+		return core.Error(500, "cannot write audit event", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "message signed", dto.MessageSignatureRes{
+		Address:   sig.Address,
+		Hash:      sig.Hash,
+		Signature: sig.Signature,
+	}, nil), nil
+}
+
+// VerifySignature implements [services.WalletService].
+// It recovers the signer and compares it with the claimed address; no
+// wallet is involved, so anyone can verify.
+func (s *WalletServiceImpl) VerifySignature(
+	ctx context.Context,
+	req *dto.VerifySignatureReq,
+) (*core.ApiResponse, error) {
+
+	var hash []byte
+	switch req.Type {
+	case "personal":
+		message, err := decodeMessage(req.Message, req.Encoding)
+		if err != nil {
+			return core.Error(400, "invalid message", err.Error(), nil), nil
+		}
+		hash = crypto.PersonalMessageHash(message)
+
+	default:
+		var err error
+		hash, _, err = crypto.TypedDataHash(req.TypedData)
+		if err != nil {
+			return core.Error(400, "invalid typed data", err.Error(), nil), nil
+		}
+	}
+
+	signer, err := crypto.RecoverSigner(hash, req.Signature)
+	if err != nil {
+		return core.Error(400, "invalid signature", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "signature checked", dto.VerifySignatureRes{
+		Valid:  strings.EqualFold(signer, req.Address),
+		Signer: signer,
+	}, nil), nil
+}
+
+// decodeMessage returns the bytes to sign for a UTF-8 or hex message.
+func decodeMessage(message, encoding string) ([]byte, error) {
+	if encoding != "hex" {
+		return []byte(message), nil
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(message, "0x"), "0X"))
+	if err != nil {
+		return nil, errors.New("message must be an even-length hex string")
+	}
+	return raw, nil
+}
+
+// SignBtcPSBT implements [services.WalletService].
+// Inputs that belong to other signers are left for them; the PSBT is
+// returned so the coordinator can combine and broadcast it.
+func (s *WalletServiceImpl) SignBtcPSBT(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SignBtcPSBTReq,
+	meta dto.RequestMeta,
+) (resp *core.ApiResponse, err error) {
+
+	// 1️⃣ Collect the wallet's native SegWit paths
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+	if wallet.WatchOnly() {
+		return unlockSignerError(errWatchOnlyWallet), nil
+	}
+
+	var paths []crypto.DerivationPath
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain != crypto.ChainBTC || addr.Purpose != crypto.PurposeBIP84 {
+			continue
+		}
+		path, err := crypto.ParseDerivationPath(addr.DerivationPath)
+		if err != nil {
+			return core.Error(500, "invalid derivation path", err.Error(), nil), nil
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return core.Error(400, "wallet has no native segwit bitcoin addresses", nil, nil), nil
+	}
+
+	// 2️⃣ Only pay destinations on the withdrawal allowlist
+	network, err := walletNetwork(wallet, crypto.ChainBTC)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+	outputs, err := crypto.PSBTOutputs(req.Psbt, network)
+	if err != nil {
+		return core.Error(400, "invalid psbt", err.Error(), nil), nil
+	}
+	destinations := make([]string, 0, len(outputs))
+	for _, out := range outputs {
+		destinations = append(destinations, out.Address)
+	}
+	if resp := s.checkDestinations(ctx, wallet, crypto.ChainBTC, destinations); resp != nil {
+		return resp, nil
+	}
+
+	// 3️⃣ Large payments out of the wallet need approved withdrawals
+	transfer, _ := btcTransfer(wallet, network, outputs)
+	if resp := s.checkApproval(ctx, []outgoingTransfer{transfer}); resp != nil {
+		return resp, nil
+	}
+
+	// 4️⃣ Count it against the spending policies; given back if signing fails
+	reservation, denied := s.enforcePolicies(ctx, wallet, []outgoingTransfer{transfer})
+	if denied != nil {
+		return denied, nil
+	}
+	defer func() {
+		if resp == nil || resp.Code != 200 {
+			s.releaseSpending(ctx, reservation)
+			return
+		}
+		holdUntilCommit(ctx, func(ctx context.Context) {
+			s.releaseSpending(ctx, reservation)
+		})
+	}()
+
+	// 5️⃣ Unlock the mnemonic
+	mnemonic, err := s.unlockWithSeed(ctx, wallet, req.Passphrase, req.SeedPassphrase)
+	if err != nil {
+		return unlockSignerError(err), nil
+	}
+
+	// 6️⃣ Sign the inputs that derive from those paths
+	signed, err := s.cryptoSvc.SignBitcoinPSBT(mnemonic, req.SeedPassphrase, req.Psbt, paths, req.Finalize)
+	if errors.Is(err, crypto.ErrInvalidPSBT) {
+		return core.Error(400, "invalid psbt", err.Error(), nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot sign psbt", err.Error(), nil), nil
+	}
+	if len(signed.SignedInputs) == 0 {
+		return core.Error(400, "no psbt inputs belong to this wallet", nil, nil), nil
+	}
+
+	// 7️⃣ Audit before releasing the signatures
+	details := map[string]string{
+		"chain":  crypto.ChainBTC,
+		"inputs": strconv.Itoa(len(signed.SignedInputs)),
+	}
+	if signed.TxId != "" {
+		details["hash"] = signed.TxId
+	}
+	if err := s.audit(ctx, userId, walletId, auditTransactionSigned, meta, details); err != nil {
+		return core.Error(500, "cannot write audit event", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "psbt signed", dto.SignedPSBTRes{
+		Psbt:           signed.PSBT,
+		SignedInputs:   signed.SignedInputs,
+		Complete:       signed.Complete,
+		RawTransaction: signed.RawTx,
+		TxId:           signed.TxId,
+	}, nil), nil
+}
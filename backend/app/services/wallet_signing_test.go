@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestSignEthTransaction(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	tests := []struct {
+		name   string
+		req    dto.SignEthTransactionReq
+		txType uint8
+	}{
+		{
+			name:   "legacy",
+			req:    dto.SignEthTransactionReq{Nonce: 4, GasPrice: "1000000000"},
+			txType: types.LegacyTxType,
+		},
+		{
+			name:   "eip1559",
+			req:    dto.SignEthTransactionReq{Nonce: 5, MaxFeePerGas: "0x6fc23ac00", MaxPriorityFeePerGas: "1000000000"},
+			txType: types.DynamicFeeTxType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			req.Passphrase = testPassphrase
+			req.From = strings.ToLower(wallet.address)
+			req.ChainId = 1
+			req.To = ledgerPayee
+			req.Value = oneEther
+			req.Gas = 21000
+
+			res, err := svc.SignEthTransaction(ctx, testUserId, wallet.walletId, &req, dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, 200, res.Code, res.Message)
+			signed := res.Data.(dto.SignedTransactionRes)
+			require.Equal(t, wallet.address, signed.From)
+
+			// Signed by the wallet address, for mainnet, not broadcast
+			raw, err := hexutil.Decode(signed.RawTransaction)
+			require.NoError(t, err)
+			var tx types.Transaction
+			require.NoError(t, tx.UnmarshalBinary(raw))
+			require.Equal(t, tt.txType, tx.Type())
+			require.Equal(t, tt.req.Nonce, tx.Nonce())
+			require.Equal(t, oneEther, tx.Value().String())
+
+			sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+			require.NoError(t, err)
+			require.Equal(t, wallet.address, sender.Hex())
+			require.EqualValues(t, 1, tx.ChainId().Int64())
+		})
+	}
+	require.Equal(t, []string{auditTransactionSigned, auditTransactionSigned}, store.auditActions())
+}
+
+func TestSignEthTransactionRejects(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	valid := func() dto.SignEthTransactionReq {
+		return dto.SignEthTransactionReq{
+			Passphrase: testPassphrase,
+			From:       wallet.address,
+			ChainId:    1,
+			To:         ledgerPayee,
+			Gas:        21000,
+			GasPrice:   "1000000000",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		userId  string
+		edit    func(req *dto.SignEthTransactionReq)
+		code    int
+		message string
+	}{
+		{"other user", "user-2", func(req *dto.SignEthTransactionReq) {}, 404, "wallet not found"},
+		{"foreign address", testUserId, func(req *dto.SignEthTransactionReq) { req.From = ledgerPayee }, 404, "address not found in wallet"},
+		{"wrong passphrase", testUserId, func(req *dto.SignEthTransactionReq) { req.Passphrase = "wrong" }, 400, "invalid passphrase"},
+		{"other network", testUserId, func(req *dto.SignEthTransactionReq) { req.ChainId = 11155111 }, 400, "chain id does not match wallet network"},
+		{"mixed fees", testUserId, func(req *dto.SignEthTransactionReq) { req.MaxFeePerGas = "1000000000" }, 400, "invalid transaction"},
+		{"odd data", testUserId, func(req *dto.SignEthTransactionReq) { req.Data = "0xabc" }, 400, "invalid transaction"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.edit(&req)
+
+			res, err := svc.SignEthTransaction(ctx, tt.userId, wallet.walletId, &req, dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code)
+			require.Equal(t, tt.message, res.Message)
+		})
+	}
+	require.Empty(t, store.auditActions())
+}
+
+func TestSignAndVerifyMessages(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	// 1️⃣ A personal message, as text or as hex bytes
+	for _, encoding := range []string{"utf8", "hex"} {
+		message := "hello"
+		if encoding == "hex" {
+			message = "0x68656c6c6f"
+		}
+
+		res, err := svc.SignMessage(ctx, testUserId, wallet.walletId, &dto.SignMessageReq{
+			Passphrase: testPassphrase,
+			Address:    wallet.address,
+			Message:    message,
+			Encoding:   encoding,
+		}, dto.RequestMeta{})
+		require.NoError(t, err)
+		require.Equal(t, 200, res.Code, res.Message)
+		sig := res.Data.(dto.MessageSignatureRes)
+		require.Equal(t, wallet.address, sig.Address)
+
+		requireVerified(t, svc, &dto.VerifySignatureReq{
+			Type:      "personal",
+			Address:   wallet.address,
+			Signature: sig.Signature,
+			Message:   "hello",
+		}, wallet.address, true)
+	}
+
+	// 2️⃣ EIP-712 typed data for the wallet network
+	res, err := svc.SignTypedData(ctx, testUserId, wallet.walletId, &dto.SignTypedDataReq{
+		Passphrase: testPassphrase,
+		Address:    wallet.address,
+		TypedData:  greetingTypedData(1),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	sig := res.Data.(dto.MessageSignatureRes)
+
+	typed := &dto.VerifySignatureReq{
+		Type:      "typed_data",
+		Address:   wallet.address,
+		Signature: sig.Signature,
+		TypedData: greetingTypedData(1),
+	}
+	requireVerified(t, svc, typed, wallet.address, true)
+
+	// 3️⃣ A claimed address that did not sign is reported, not failed
+	typed.Address = ledgerPayee
+	requireVerified(t, svc, typed, wallet.address, false)
+
+	require.Equal(t, []string{auditMessageSigned, auditMessageSigned, auditMessageSigned}, store.auditActions())
+}
+
+func TestSignTypedDataRefusesOtherChains(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	res, err := svc.SignTypedData(ctx, testUserId, wallet.walletId, &dto.SignTypedDataReq{
+		Passphrase: testPassphrase,
+		Address:    wallet.address,
+		TypedData:  greetingTypedData(11155111),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "typed data chain id does not match wallet network", res.Message)
+
+	res, err = svc.SignTypedData(ctx, testUserId, wallet.walletId, &dto.SignTypedDataReq{
+		Passphrase: testPassphrase,
+		Address:    wallet.address,
+		TypedData:  json.RawMessage(`{"primaryType": "Greeting"}`),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "invalid typed data", res.Message)
+	require.Empty(t, store.auditActions())
+}
+
+func TestSignBtcPSBT(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+
+	res, err := svc.RestoreWallet(ctx, testUserId, &dto.RestoreWalletReq{
+		SecretPhrase: testMnemonic,
+		Passphrase:   testPassphrase,
+		Import:       true,
+		Chains:       []string{crypto.ChainETH, crypto.ChainBTC},
+	})
+	require.NoError(t, err)
+	walletId := res.Data.(dto.RestoreWalletRes).WalletId
+
+	// 1️⃣ The input paying the wallet's first address is signed
+	packet := walletPSBT(t, "m/84'/0'/0'/0/0")
+	res, err = svc.SignBtcPSBT(ctx, testUserId, walletId, &dto.SignBtcPSBTReq{
+		Passphrase: testPassphrase,
+		Psbt:       packet,
+		Finalize:   true,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	signed := res.Data.(dto.SignedPSBTRes)
+	require.Equal(t, []int{0}, signed.SignedInputs)
+	require.True(t, signed.Complete)
+	require.NotEmpty(t, signed.RawTransaction)
+	require.Equal(t, []string{auditTransactionSigned}, store.auditActions())
+
+	// 2️⃣ Inputs of addresses the wallet never derived are not its own
+	res, err = svc.SignBtcPSBT(ctx, testUserId, walletId, &dto.SignBtcPSBTReq{
+		Passphrase: testPassphrase,
+		Psbt:       walletPSBT(t, "m/84'/0'/0'/0/5"),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "no psbt inputs belong to this wallet", res.Message)
+
+	// 3️⃣ The passphrase still unlocks it, and only for its owner
+	res, err = svc.SignBtcPSBT(ctx, testUserId, walletId, &dto.SignBtcPSBTReq{Passphrase: "wrong", Psbt: packet}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "invalid passphrase", res.Message)
+
+	res, err = svc.SignBtcPSBT(ctx, "user-2", walletId, &dto.SignBtcPSBTReq{Passphrase: testPassphrase, Psbt: packet}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 404, res.Code)
+}
+
+func TestSignBtcPSBTNeedsSegwitAddresses(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	wallet := createSigningWallet(t, svc)
+
+	res, err := svc.SignBtcPSBT(ctx, testUserId, wallet.walletId, &dto.SignBtcPSBTReq{
+		Passphrase: testPassphrase,
+		Psbt:       walletPSBT(t, "m/84'/0'/0'/0/0"),
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "wallet has no native segwit bitcoin addresses", res.Message)
+}
+
+func requireVerified(t *testing.T, svc *WalletServiceImpl, req *dto.VerifySignatureReq, signer string, valid bool) {
+	t.Helper()
+
+	res, err := svc.VerifySignature(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, dto.VerifySignatureRes{Valid: valid, Signer: signer}, res.Data)
+}
+
+// greetingTypedData is EIP-712 typed data for chainId, verified by an
+// unrelated contract and moving no funds.
+func greetingTypedData(chainId int) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Greeting": [{"name": "contents", "type": "string"}]
+  },
+  "primaryType": "Greeting",
+  "domain": {"name": "Greeter", "chainId": %d, "verifyingContract": "%s"},
+  "message": {"contents": "gm"}
+}`, chainId, ledgerPayee))
+}
+
+// walletPSBT spends 50000 satoshi paid to the P2WPKH key of testMnemonic
+// at path into 40000 satoshi to m/84'/0'/0'/0/1 of the same phrase.
+func walletPSBT(t *testing.T, path string) string {
+	t.Helper()
+
+	master, err := hdkeychain.NewMaster(bip39.NewSeed(testMnemonic, ""), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	masterPub, err := master.ECPubKey()
+	require.NoError(t, err)
+
+	derivation, err := crypto.ParseDerivationPath(path)
+	require.NoError(t, err)
+	levels := []uint32{
+		hdkeychain.HardenedKeyStart + derivation.Purpose,
+		hdkeychain.HardenedKeyStart + derivation.CoinType,
+		hdkeychain.HardenedKeyStart + derivation.Account,
+		derivation.Change,
+		derivation.Index,
+	}
+	key := master
+	for _, level := range levels {
+		key, err = key.Derive(level)
+		require.NoError(t, err)
+	}
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+	compressed := pubKey.SerializeCompressed()
+
+	witnessProgram, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(compressed), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	prevScript, err := txscript.PayToAddrScript(witnessProgram)
+	require.NoError(t, err)
+	payee, err := btcutil.DecodeAddress("bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	payScript, err := txscript.PayToAddrScript(payee)
+	require.NoError(t, err)
+
+	p, err := psbt.New(
+		[]*wire.OutPoint{wire.NewOutPoint(&chainhash.Hash{1}, 0)},
+		[]*wire.TxOut{wire.NewTxOut(40_000, payScript)},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+	p.Inputs[0].WitnessUtxo = wire.NewTxOut(50_000, prevScript)
+	p.Inputs[0].Bip32Derivation = []*psbt.Bip32Derivation{{
+		PubKey:               compressed,
+		MasterKeyFingerprint: binary.LittleEndian.Uint32(btcutil.Hash160(masterPub.SerializeCompressed())[:4]),
+		Bip32Path:            levels,
+	}}
+
+	packet, err := p.B64Encode()
+	require.NoError(t, err)
+	return packet
+}
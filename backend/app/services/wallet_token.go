@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SignTokenTransfer implements [services.WalletService].
+// It encodes an ERC-20 transfer call to a registered token and signs it
+// as a transaction to the token contract carrying no ether.
+func (s *WalletServiceImpl) SignTokenTransfer(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.SignTokenTransferReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	// 1️⃣ Resolve the token on the wallet's network
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	network, err := walletNetwork(wallet, crypto.ChainETH)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+
+	token, err := s.tokenRepo.GetBySymbol(ctx, crypto.ChainETH, network.Name, req.Token)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "token not registered on wallet network", nil, map[string]any{
+			"network": network.Name,
+			"token":   req.Token,
+		}), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load token", err.Error(), nil), nil
+	}
+
+	// 2️⃣ Encode transfer(to, amount)
+	value, err := utils.ParseUint256(req.Amount)
+	if err != nil || value.Sign() == 0 {
+		return core.Error(400, "amount must be a positive integer in base units", nil, nil), nil
+	}
+
+	data, err := blockchain.PackERC20Transfer(common.HexToAddress(req.To), value)
+	if err != nil {
+		return core.Error(500, "cannot encode transfer", err.Error(), nil), nil
+	}
+
+	// 3️⃣ Sign the contract call
+	return s.SignEthTransaction(ctx, userId, walletId, &dto.SignEthTransactionReq{
+		Passphrase:           req.Passphrase,
+		SeedPassphrase:       req.SeedPassphrase,
+		From:                 req.From,
+		ChainId:              req.ChainId,
+		Nonce:                req.Nonce,
+		ReserveNonce:         req.ReserveNonce,
+		To:                   token.ContractAddress,
+		Gas:                  req.Gas,
+		GasPrice:             req.GasPrice,
+		MaxFeePerGas:         req.MaxFeePerGas,
+		MaxPriorityFeePerGas: req.MaxPriorityFeePerGas,
+		Data:                 hexutil.Encode(data),
+	}, meta)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const mainnetUSDC = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
+func TestSignTokenTransfer(t *testing.T) {
+	ctx := context.Background()
+	svc, wallet := newTokenWallet(t)
+
+	res, err := svc.SignTokenTransfer(ctx, testUserId, wallet.walletId, tokenTransfer(wallet, "USDC", "2500000"), dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+
+	// A call to the token contract carrying no ether
+	raw, err := hexutil.Decode(res.Data.(dto.SignedTransactionRes).RawTransaction)
+	require.NoError(t, err)
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(raw))
+	require.Equal(t, mainnetUSDC, tx.To().Hex())
+	require.Zero(t, tx.Value().Sign())
+
+	to, value, err := blockchain.UnpackERC20Transfer(tx.Data())
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress(ledgerPayee), to)
+	require.Equal(t, "2500000", value.String())
+
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), &tx)
+	require.NoError(t, err)
+	require.Equal(t, wallet.address, sender.Hex())
+}
+
+func TestSignTokenTransferRejects(t *testing.T) {
+	ctx := context.Background()
+	svc, wallet := newTokenWallet(t)
+
+	tests := []struct {
+		name    string
+		token   string
+		amount  string
+		code    int
+		message string
+	}{
+		{"unregistered token", "DAI", "1", 404, "token not registered on wallet network"},
+		{"registered on another network", "TEST", "1", 404, "token not registered on wallet network"},
+		{"zero amount", "USDC", "0", 400, "amount must be a positive integer in base units"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.SignTokenTransfer(ctx, testUserId, wallet.walletId, tokenTransfer(wallet, tt.token, tt.amount), dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code)
+			require.Equal(t, tt.message, res.Message)
+		})
+	}
+}
+
+// newTokenWallet returns a signing wallet on mainnet, where USDC is
+// registered; TEST is registered on sepolia only.
+func newTokenWallet(t *testing.T) (*WalletServiceImpl, signingWallet) {
+	t.Helper()
+
+	tokens := &memoryTokens{}
+	for _, token := range []models.Erc20Token{
+		{Chain: crypto.ChainETH, Network: crypto.NetworkMainnet, ContractAddress: mainnetUSDC, Symbol: "USDC", Decimals: 6},
+		{Chain: crypto.ChainETH, Network: "sepolia", ContractAddress: ledgerPayee, Symbol: "TEST", Decimals: 18},
+	} {
+		require.NoError(t, tokens.Create(context.Background(), &token))
+	}
+
+	svc := newTestWalletService(t, newMemoryStore())
+	svc.tokenRepo = tokens
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{})
+	return svc, createSigningWallet(t, svc)
+}
+
+func tokenTransfer(wallet signingWallet, token, amount string) *dto.SignTokenTransferReq {
+	return &dto.SignTokenTransferReq{
+		Passphrase: testPassphrase,
+		From:       wallet.address,
+		Token:      token,
+		To:         ledgerPayee,
+		Amount:     amount,
+		ChainId:    1,
+		Gas:        65000,
+		GasPrice:   "1000000000",
+	}
+}
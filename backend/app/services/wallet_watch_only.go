@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/google/uuid"
+)
+
+// ImportWatchOnlyWallet implements [services.WalletService].
+// The wallet keeps only the account's extended public key: its addresses
+// are derived and tracked like those of any other wallet, but it holds no
+// key material, so every signing path refuses it.
+func (s *WalletServiceImpl) ImportWatchOnlyWallet(
+	ctx context.Context,
+	userId string,
+	req *dto.ImportWatchOnlyWalletReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	now := time.Now()
+	wallet := &models.Wallet{
+		WalletId:       uuid.New().String(),
+		UserId:         userId,
+		WalletName:     req.WalletName,
+		EthNetwork:     defaultNetwork(req.EthNetwork),
+		BtcNetwork:     defaultNetwork(req.BtcNetwork),
+		WatchOnlyChain: req.Chain,
+		CreateDate:     now,
+		UpdateDate:     now,
+	}
+
+	// 1️⃣ Decode the key for the wallet's network
+	network, err := walletNetwork(wallet, req.Chain)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+	key, err := crypto.ParseExtendedPublicKey(network, req.ExtendedPublicKey)
+	if errors.Is(err, crypto.ErrInvalidExtendedKey) {
+		return core.Error(400, "invalid extended public key", err.Error(), nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot read extended public key", err.Error(), nil), nil
+	}
+	wallet.ExtendedPublicKey = key.String()
+
+	// 2️⃣ The fingerprint keeps one account from being imported twice
+	wallet.Fingerprint, err = s.cryptoSvc.ExtendedKeyFingerprint(key)
+	if err != nil {
+		return core.Error(500, "cannot fingerprint extended public key", err.Error(), nil), nil
+	}
+
+	// 3️⃣ First receive address
+	path := key.Path(0, 0)
+	address, err := key.Address(path)
+	if err != nil {
+		return core.Error(500, "derive address failed", err.Error(), nil), nil
+	}
+	addr := newBlockchainAddress(wallet.WalletId, address, req.Chain, path, now)
+
+	// 4️⃣ Save and audit together
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.walletRepo.Create(ctx, wallet); err != nil {
+			return err
+		}
+		if err := s.addressRepo.Create(ctx, addr); err != nil {
+			return err
+		}
+		return s.audit(ctx, userId, wallet.WalletId, auditWatchOnlyImported, meta, map[string]string{
+			"chain":   req.Chain,
+			"network": network.Name,
+			"purpose": strconv.FormatUint(uint64(key.Purpose), 10),
+			"account": strconv.FormatUint(uint64(key.Account), 10),
+		})
+	})
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "import failed", "wallet already exists", nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot import wallet", err.Error(), nil), nil
+	}
+
+	wallet.BlockchainAddresses = []models.BlockchainAddress{*addr}
+	return core.Success(201, "watch-only wallet imported", toWalletRes(wallet), nil), nil
+}
+
+// deriveWatchOnlyAddress derives the next address of a watch-only wallet
+// from its extended public key. Purpose and account are fixed by the key,
+// so only the change level can be chosen.
+func (s *WalletServiceImpl) deriveWatchOnlyAddress(
+	ctx context.Context,
+	wallet *models.Wallet,
+	req *dto.DeriveAddressReq,
+) (*core.ApiResponse, error) {
+
+	chain := req.Chain
+	if chain == "" {
+		chain = wallet.WatchOnlyChain
+	}
+	if chain != wallet.WatchOnlyChain {
+		return core.Error(400, "chain not tracked by watch-only wallet", nil, map[string]any{
+			"chain": wallet.WatchOnlyChain,
+		}), nil
+	}
+
+	network, err := walletNetwork(wallet, chain)
+	if err != nil {
+		return core.Error(500, "invalid wallet network", err.Error(), nil), nil
+	}
+	key, err := crypto.ParseExtendedPublicKey(network, wallet.ExtendedPublicKey)
+	if err != nil {
+		return core.Error(500, "invalid extended public key", err.Error(), nil), nil
+	}
+
+	// The requested type and account must be the ones the key was exported at
+	requested, err := crypto.PathFor(network, req.AddressType, req.Account, req.Change, 0)
+	if err != nil {
+		return core.Error(400, "invalid derivation path", err.Error(), nil), nil
+	}
+	if req.AddressType != "" && requested.Purpose != key.Purpose {
+		return core.Error(400, "address type differs from the extended public key", nil, nil), nil
+	}
+	if req.Account != 0 && req.Account != key.Account {
+		return core.Error(400, "account differs from the extended public key", nil, nil), nil
+	}
+	path := key.Path(req.Change, 0)
+
+	var addr *models.BlockchainAddress
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		// Derivations of one wallet queue on its row, so two of them
+		// never read the same next index
+		if _, err := s.walletRepo.GetByIdForUpdate(ctx, wallet.WalletId); err != nil {
+			return err
+		}
+
+		index, err := s.addressRepo.NextIndex(ctx, wallet.WalletId, chain, path.Purpose, path.Account, path.Change)
+		if err != nil {
+			return err
+		}
+		path.Index = index
+
+		address, err := key.Address(path)
+		if err != nil {
+			return err
+		}
+
+		addr = newBlockchainAddress(wallet.WalletId, address, chain, path, time.Now())
+		return s.addressRepo.Create(ctx, addr)
+	})
+	if err != nil {
+		return core.Error(500, "derive address failed", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "address created", toAddressRes(addr), nil), nil
+}
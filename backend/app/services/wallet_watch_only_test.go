@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestImportWatchOnlyWallet(t *testing.T) {
+	ctx := context.Background()
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	xpub := accountXpub(t, 44, 60, 0)
+
+	// 1️⃣ The account's first receive address is the mnemonic's, and no
+	// key material is kept
+	res := importWatchOnly(t, svc, xpub, crypto.ChainETH)
+	require.Equal(t, 201, res.Code, res.Message)
+	wallet := res.Data.(dto.WalletRes)
+	require.True(t, wallet.WatchOnly)
+	require.Equal(t, xpub, wallet.ExtendedPublicKey)
+	require.Len(t, wallet.Addresses, 1)
+	require.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", wallet.Addresses[0].Address)
+	require.Equal(t, "m/44'/60'/0'/0/0", wallet.Addresses[0].DerivationPath)
+
+	stored := store.wallets[wallet.WalletId]
+	require.Empty(t, stored.SecretPhraseHash)
+	require.Empty(t, stored.WrappedDek)
+	require.Empty(t, stored.PassphraseHash)
+	require.Equal(t, []string{auditWatchOnlyImported}, store.auditActions())
+
+	// 2️⃣ Further addresses come from the key without a passphrase
+	derived, err := svc.DeriveAddress(ctx, testUserId, wallet.WalletId, &dto.DeriveAddressReq{Change: 1})
+	require.NoError(t, err)
+	require.Equal(t, 201, derived.Code, derived.Message)
+	require.Equal(t, "m/44'/60'/0'/1/0", derived.Data.(dto.AddressRes).DerivationPath)
+
+	// 3️⃣ The same account cannot be imported twice
+	res = importWatchOnly(t, svc, xpub, crypto.ChainETH)
+	require.Equal(t, 409, res.Code)
+	require.Len(t, store.wallets, 1)
+}
+
+func TestImportWatchOnlyWalletRejectsKeys(t *testing.T) {
+	master, err := hdkeychain.NewMaster(bip39.NewSeed(testMnemonic, ""), &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	masterPub, err := master.Neuter()
+	require.NoError(t, err)
+	account, err := hdkeychain.NewKeyFromString(accountXpub(t, 44, 1, 0))
+	require.NoError(t, err)
+	tpub, err := account.CloneWithVersion(chaincfg.TestNet3Params.HDPublicKeyID[:])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		key   string
+		chain string
+	}{
+		{"not a key", "xpub-not-a-key", crypto.ChainETH},
+		{"private key", master.String(), crypto.ChainETH},
+		{"master key", masterPub.String(), crypto.ChainETH},
+		{"testnet key on mainnet", tpub.String(), crypto.ChainBTC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newMemoryStore()
+			svc := newTestWalletService(t, store)
+
+			res := importWatchOnly(t, svc, tt.key, tt.chain)
+			require.Equal(t, 400, res.Code)
+			require.Equal(t, "invalid extended public key", res.Message)
+			require.Empty(t, store.wallets)
+		})
+	}
+}
+
+func TestWatchOnlyDeriveFollowsTheKey(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestWalletService(t, newMemoryStore())
+
+	res := importWatchOnly(t, svc, accountXpub(t, 44, 0, 0), crypto.ChainBTC)
+	require.Equal(t, 201, res.Code, res.Message)
+	wallet := res.Data.(dto.WalletRes)
+	require.Equal(t, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", wallet.Addresses[0].Address)
+
+	tests := []struct {
+		name    string
+		req     dto.DeriveAddressReq
+		code    int
+		message string
+		path    string
+	}{
+		{"other chain", dto.DeriveAddressReq{Chain: crypto.ChainETH}, 400, "chain not tracked by watch-only wallet", ""},
+		{"other address type", dto.DeriveAddressReq{AddressType: crypto.AddressTypeP2WPKH}, 400, "address type differs from the extended public key", ""},
+		{"other account", dto.DeriveAddressReq{Account: 1}, 400, "account differs from the extended public key", ""},
+		{"key's address type", dto.DeriveAddressReq{Chain: crypto.ChainBTC, AddressType: crypto.AddressTypeP2PKH}, 201, "address created", "m/44'/0'/0'/0/1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := svc.DeriveAddress(ctx, testUserId, wallet.WalletId, &tt.req)
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code, res.Message)
+			require.Equal(t, tt.message, res.Message)
+			if tt.code == 201 {
+				require.Equal(t, tt.path, res.Data.(dto.AddressRes).DerivationPath)
+			}
+		})
+	}
+}
+
+func TestWatchOnlyWalletCannotSign(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newWithdrawalService(t)
+
+	res := importWatchOnly(t, svc, accountXpub(t, 44, 60, 0), crypto.ChainETH)
+	require.Equal(t, 201, res.Code, res.Message)
+	created := res.Data.(dto.WalletRes)
+	wallet := signingWallet{walletId: created.WalletId, address: created.Addresses[0].Address}
+
+	// 1️⃣ Every signing path refuses the wallet
+	requireCannotSign(t, signEther(t, svc, wallet, "0.1"))
+
+	res, err := svc.SignMessage(ctx, testUserId, wallet.walletId, &dto.SignMessageReq{
+		Address: wallet.address,
+		Message: "hello",
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	requireCannotSign(t, res)
+
+	res, err = svc.SignBtcPSBT(ctx, testUserId, wallet.walletId, &dto.SignBtcPSBTReq{Psbt: "cHNidP8="}, dto.RequestMeta{})
+	require.NoError(t, err)
+	requireCannotSign(t, res)
+
+	// 2️⃣ So does a withdrawal, which would be signed once approved
+	sign := etherTransfer(t, wallet, "2")
+	res, err = svc.CreateWithdrawal(ctx, testUserId, wallet.walletId, &dto.CreateWithdrawalReq{
+		Kind:           models.WithdrawalKindEthTransaction,
+		EthTransaction: &sign,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	requireCannotSign(t, res)
+	require.Empty(t, store.requests)
+
+	// 3️⃣ There is no passphrase to change either
+	res, err = svc.ChangePassphrase(ctx, testUserId, wallet.walletId, &dto.ChangePassphraseReq{NewPassphrase: testPassphrase}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res.Code)
+	require.Equal(t, "watch-only wallet has no passphrase", res.Message)
+}
+
+// importWatchOnly imports key as a watch-only wallet of chain on mainnet.
+func importWatchOnly(t *testing.T, svc *WalletServiceImpl, key, chain string) *core.ApiResponse {
+	t.Helper()
+
+	res, err := svc.ImportWatchOnlyWallet(context.Background(), testUserId, &dto.ImportWatchOnlyWalletReq{
+		WalletName:        "Watched",
+		ExtendedPublicKey: key,
+		Chain:             chain,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	return res
+}
+
+func requireCannotSign(t *testing.T, res *core.ApiResponse) {
+	t.Helper()
+
+	require.Equal(t, 403, res.Code, res.Message)
+	require.Equal(t, "watch-only wallet cannot sign", res.Message)
+}
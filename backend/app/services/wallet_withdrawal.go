@@ -0,0 +1,903 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	domainerrors "github.com/create-go-app/fiber-go-template/app/domain/errors"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// defaultWithdrawalRequestTTL is how long a withdrawal request may wait
+// for approval and execution.
+const defaultWithdrawalRequestTTL = 24 * time.Hour
+
+// defaultApprovalWindow is the rolling period over which transfers signed
+// without approval add up against the approval thresholds.
+const defaultApprovalWindow = 24 * time.Hour
+
+// anyAsset keys the approval threshold of assets without their own.
+const anyAsset = "*"
+
+// errWithdrawalNotExecuted rolls back an execution whose signing failed.
+var errWithdrawalNotExecuted = errors.New("withdrawal not executed")
+
+// WithdrawalApprovalConfig decides which outgoing transfers need approvals
+// before they are signed.
+type WithdrawalApprovalConfig struct {
+	// Approvals is how many approvers must approve a withdrawal; 0 turns
+	// the workflow off.
+	Approvals int
+	// Thresholds maps an asset symbol to the amount, in whole units, above
+	// which its transfers need approval. "*" covers assets not listed;
+	// without it, any transfer of an unlisted asset needs approval.
+	Thresholds map[string]string
+	// RequestTTL is how long a request may wait for approval and execution.
+	RequestTTL time.Duration
+	// Window is how far back transfers signed without approval count
+	// towards the thresholds, so a payout split into small transfers
+	// needs approval all the same. At most a week.
+	Window time.Duration
+}
+
+func (c WithdrawalApprovalConfig) withDefaults() WithdrawalApprovalConfig {
+	if c.RequestTTL == 0 {
+		c.RequestTTL = defaultWithdrawalRequestTTL
+	}
+	if c.Window == 0 {
+		c.Window = defaultApprovalWindow
+	}
+	return c
+}
+
+// threshold returns the amount of asset, in base units, that a transfer
+// may reach without approval. Tokens missing from the registry have no
+// known decimals, so none of their transfers is let through.
+func (c WithdrawalApprovalConfig) threshold(asset crypto.Asset) (amount.Int, error) {
+	if asset.Symbol == "" {
+		return amount.Int{}, nil
+	}
+
+	limit, ok := c.Thresholds[strings.ToUpper(asset.Symbol)]
+	if !ok {
+		limit, ok = c.Thresholds[anyAsset]
+	}
+	if !ok {
+		return amount.Int{}, nil
+	}
+	return amount.ParseDecimal(limit, asset.Decimals)
+}
+
+// outgoingTransfer is an amount of one asset leaving the wallet, or that
+// another account is allowed to take. An empty asset symbol is a token
+// missing from the registry. An opaque transfer is a contract call or
+// message whose effect cannot be read, so it may move anything.
+type outgoingTransfer struct {
+	asset  crypto.Asset
+	value  amount.Int
+	opaque bool
+}
+
+// approvedWithdrawalKey marks a context that executes an approved
+// withdrawal request.
+type approvedWithdrawalKey struct{}
+
+func withApprovedWithdrawal(ctx context.Context, request *models.WithdrawalRequest) context.Context {
+	return context.WithValue(ctx, approvedWithdrawalKey{}, request)
+}
+
+func approvedWithdrawal(ctx context.Context) *models.WithdrawalRequest {
+	request, _ := ctx.Value(approvedWithdrawalKey{}).(*models.WithdrawalRequest)
+	return request
+}
+
+// signingHoldsKey marks a context whose signing runs inside a database
+// transaction. What the signing reserves in Redis is recorded there, so
+// it can be given back if the transaction rolls back.
+type signingHoldsKey struct{}
+
+type signingHolds struct {
+	releases []func(context.Context)
+}
+
+func withSigningHolds(ctx context.Context, holds *signingHolds) context.Context {
+	return context.WithValue(ctx, signingHoldsKey{}, holds)
+}
+
+// holdUntilCommit records release, to be run if the transaction around
+// the signing in ctx fails. Outside such a transaction it does nothing.
+func holdUntilCommit(ctx context.Context, release func(context.Context)) {
+	if holds, ok := ctx.Value(signingHoldsKey{}).(*signingHolds); ok {
+		holds.releases = append(holds.releases, release)
+	}
+}
+
+// release gives back everything the signing reserved.
+func (h *signingHolds) release(ctx context.Context) {
+	for _, release := range h.releases {
+		release(ctx)
+	}
+	h.releases = nil
+}
+
+// checkApproval returns an error response when a transfer is above its
+// approval threshold and is not the execution of an approved withdrawal.
+// Transfers under it still add up to the rolling total that approvalCaps
+// holds to the same threshold.
+func (s *WalletServiceImpl) checkApproval(ctx context.Context, transfers []outgoingTransfer) *core.ApiResponse {
+	if s.approvals.Approvals == 0 || approvedWithdrawal(ctx) != nil {
+		return nil
+	}
+
+	for _, transfer := range transfers {
+		if transfer.opaque {
+			return core.Error(403, "withdrawal requires approval", nil, map[string]any{
+				"reason":    "contract call cannot be decoded",
+				"approvals": s.approvals.Approvals,
+			})
+		}
+		limit, err := s.approvals.threshold(transfer.asset)
+		if err != nil {
+			return core.Error(500, "invalid withdrawal approval threshold", err.Error(), nil)
+		}
+		if transfer.value.Cmp(limit) > 0 {
+			return core.Error(403, "withdrawal requires approval", nil, map[string]any{
+				"asset":     transfer.asset.Symbol,
+				"amount":    transfer.value.String(),
+				"threshold": limit.String(),
+				"approvals": s.approvals.Approvals,
+			})
+		}
+	}
+
+	return nil
+}
+
+// approvalCaps counts transfers signed without an approved withdrawal on
+// the signer's rolling unapproved total of each asset, across all of their
+// wallets, and caps that total at the asset's approval threshold. Executed
+// withdrawals were approved, so they are left out.
+func (s *WalletServiceImpl) approvalCaps(
+	ctx context.Context,
+	transfers []outgoingTransfer,
+) ([]SpendingUse, []SpendingCap, error) {
+
+	if s.approvals.Approvals == 0 || approvedWithdrawal(ctx) != nil {
+		return nil, nil, nil
+	}
+
+	var uses []SpendingUse
+	var caps []SpendingCap
+	capped := make(map[string]bool)
+	for _, transfer := range transfers {
+		if transfer.asset.Symbol == "" || transfer.value.Sign() == 0 {
+			continue
+		}
+		counter := approvalCounter(transfer.asset.Symbol)
+		uses = append(uses, SpendingUse{Counter: counter, Amount: transfer.value})
+		if capped[counter] {
+			continue
+		}
+		capped[counter] = true
+
+		limit, err := s.approvals.threshold(transfer.asset)
+		if err != nil {
+			return nil, nil, err
+		}
+		caps = append(caps, SpendingCap{Counter: counter, Window: s.approvals.Window, Limit: limit})
+	}
+	return uses, caps, nil
+}
+
+// approvalRequired refuses a signing that takes the unapproved total of
+// an asset over its threshold.
+func (s *WalletServiceImpl) approvalRequired(
+	exceeded *SpendingCap,
+	value amount.Int,
+	used amount.Int,
+) *core.ApiResponse {
+
+	return core.Error(403, "withdrawal requires approval", nil, map[string]any{
+		"asset":     strings.TrimPrefix(exceeded.Counter, approvalCounterPrefix),
+		"amount":    value.String(),
+		"used":      used.String(),
+		"threshold": exceeded.Limit.String(),
+		"window":    formatWindow(exceeded.Window),
+		"approvals": s.approvals.Approvals,
+	})
+}
+
+const approvalCounterPrefix = "approval:"
+
+// approvalCounter names the counter of asset signed for without approval.
+func approvalCounter(asset string) string {
+	return approvalCounterPrefix + strings.ToUpper(asset)
+}
+
+// ethTransfers returns what an Ethereum transaction moves out of the
+// wallet, or lets another account take: its ether value and the tokens of
+// an ERC-20 transfer, transferFrom, approve or increaseAllowance call. Any
+// other calldata is opaque. A deployment only moves its value: the new
+// contract's constructor is not the wallet, so it cannot spend for it.
+func (s *WalletServiceImpl) ethTransfers(
+	ctx context.Context,
+	network *crypto.Network,
+	tx *crypto.EthereumTx,
+) ([]outgoingTransfer, error) {
+
+	var transfers []outgoingTransfer
+
+	if tx.Value != nil && tx.Value.Sign() > 0 {
+		native, err := crypto.NativeAsset(crypto.ChainETH)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, outgoingTransfer{asset: native, value: amount.New(tx.Value)})
+	}
+
+	if tx.To == nil || len(tx.Data) == 0 {
+		return transfers, nil
+	}
+	call, err := blockchain.UnpackERC20Call(tx.Data)
+	if err != nil {
+		return append(transfers, outgoingTransfer{opaque: true}), nil
+	}
+
+	asset, err := s.tokenAsset(ctx, network, *tx.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(transfers, outgoingTransfer{asset: asset, value: amount.New(call.Value)}), nil
+}
+
+// typedDataTransfers returns what EIP-712 typed data lets another account
+// take from the wallet: the allowance of a token permit. Any other message
+// is opaque, since the verifying contract may give it any meaning.
+func (s *WalletServiceImpl) typedDataTransfers(
+	ctx context.Context,
+	network *crypto.Network,
+	parties *crypto.TypedDataParties,
+) ([]outgoingTransfer, error) {
+
+	if parties.Permit == nil {
+		return []outgoingTransfer{{opaque: true}}, nil
+	}
+
+	asset, err := s.tokenAsset(ctx, network, parties.Permit.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return []outgoingTransfer{{asset: asset, value: amount.New(parties.Permit.Value)}}, nil
+}
+
+// tokenAsset returns the registered asset of a token contract on network,
+// or an empty asset for a token missing from the registry.
+func (s *WalletServiceImpl) tokenAsset(
+	ctx context.Context,
+	network *crypto.Network,
+	contract common.Address,
+) (crypto.Asset, error) {
+
+	token, err := s.tokenRepo.GetByContract(ctx, crypto.ChainETH, network.Name, contract.Hex())
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return crypto.Asset{}, nil
+	}
+	if err != nil {
+		return crypto.Asset{}, err
+	}
+	return crypto.Asset{Symbol: token.Symbol, Decimals: token.Decimals}, nil
+}
+
+// btcTransfer returns the bitcoin a transaction with outputs pays to
+// anyone but the wallet itself, and the receiving addresses.
+func btcTransfer(wallet *models.Wallet, network *crypto.Network, outputs []crypto.TxOutput) (outgoingTransfer, []string) {
+	native, _ := crypto.NativeAsset(crypto.ChainBTC)
+	own := ownAddresses(wallet, network)
+
+	total := new(big.Int)
+	var destinations []string
+	for _, out := range outputs {
+		if own[out.Address] {
+			continue
+		}
+		total.Add(total, big.NewInt(out.Value))
+		destinations = append(destinations, out.Address)
+	}
+
+	return outgoingTransfer{asset: native, value: amount.New(total)}, destinations
+}
+
+// CreateWithdrawal implements [services.WalletService].
+// The transfer is checked and summarized for approvers now; it is only
+// signed once approved, by ExecuteWithdrawal.
+func (s *WalletServiceImpl) CreateWithdrawal(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	req *dto.CreateWithdrawalReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	if s.approvals.Approvals == 0 {
+		return core.Error(400, "withdrawal approvals are not enabled", nil, nil), nil
+	}
+
+	// 1️⃣ Load the wallet
+	wallet, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "wallet not found", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+	if wallet.WatchOnly() {
+		return unlockSignerError(errWatchOnlyWallet), nil
+	}
+
+	// 2️⃣ Summarize the transfer and keep it without passphrases
+	request := &models.WithdrawalRequest{
+		WithdrawalRequestId: uuid.New().String(),
+		WalletId:            walletId,
+		RequesterId:         userId,
+		Kind:                req.Kind,
+		Status:              models.WithdrawalStatusPending,
+		RequiredApprovals:   s.approvals.Approvals,
+	}
+	if resp := s.describeWithdrawal(ctx, wallet, req, request); resp != nil {
+		return resp, nil
+	}
+
+	// 3️⃣ Save and audit
+	now := time.Now()
+	request.ExpireDate = now.Add(s.approvals.RequestTTL)
+	request.CreateDate = now
+	request.UpdateDate = now
+
+	err = s.txManager.Do(ctx, func(ctx context.Context) error {
+		if err := s.withdrawalRequestRepo.Create(ctx, request); err != nil {
+			return err
+		}
+		return s.audit(ctx, userId, walletId, auditWithdrawalRequested, meta, map[string]string{
+			"withdrawal_id": request.WithdrawalRequestId,
+			"asset":         request.Asset,
+			"amount":        request.Amount.String(),
+			"destination":   request.Destination,
+		})
+	})
+	if err != nil {
+		return core.Error(500, "cannot create withdrawal", err.Error(), nil), nil
+	}
+
+	return core.Success(201, "withdrawal requested", toWithdrawalRes(request), nil), nil
+}
+
+// describeWithdrawal fills the chain, asset, amount, destination and
+// payload of request from req, or returns an error response.
+func (s *WalletServiceImpl) describeWithdrawal(
+	ctx context.Context,
+	wallet *models.Wallet,
+	req *dto.CreateWithdrawalReq,
+	request *models.WithdrawalRequest,
+) *core.ApiResponse {
+
+	var (
+		chain     string
+		payload   any
+		transfers []outgoingTransfer
+		to        []string
+	)
+
+	switch req.Kind {
+	case models.WithdrawalKindEthTransaction:
+		sign := *req.EthTransaction
+		sign.Passphrase, sign.SeedPassphrase = "", ""
+		chain, payload = crypto.ChainETH, sign
+
+		tx, err := newEthereumTx(&sign)
+		if err != nil {
+			return core.Error(400, "invalid transaction", err.Error(), nil)
+		}
+		network, err := walletNetwork(wallet, chain)
+		if err != nil {
+			return core.Error(500, "invalid wallet network", err.Error(), nil)
+		}
+		destinations, ok := ethDestinations(tx)
+		if !ok {
+			return core.Error(400, "contract deployment is not a withdrawal", nil, nil)
+		}
+		if transfers, err = s.ethTransfers(ctx, network, tx); err != nil {
+			return core.Error(500, "cannot read transfer", err.Error(), nil)
+		}
+		if !hasAddress(wallet, chain, sign.From) {
+			return core.Error(404, "address not found in wallet", nil, nil)
+		}
+		to = destinations
+
+	case models.WithdrawalKindTokenTransfer:
+		sign := *req.TokenTransfer
+		sign.Passphrase, sign.SeedPassphrase = "", ""
+		chain, payload = crypto.ChainETH, sign
+
+		network, err := walletNetwork(wallet, chain)
+		if err != nil {
+			return core.Error(500, "invalid wallet network", err.Error(), nil)
+		}
+		token, err := s.tokenRepo.GetBySymbol(ctx, chain, network.Name, sign.Token)
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return core.Error(404, "token not registered on wallet network", nil, nil)
+		}
+		if err != nil {
+			return core.Error(500, "cannot load token", err.Error(), nil)
+		}
+		value, err := utils.ParseUint256(sign.Amount)
+		if err != nil {
+			return core.Error(400, "invalid amount", err.Error(), nil)
+		}
+		if !hasAddress(wallet, chain, sign.From) {
+			return core.Error(404, "address not found in wallet", nil, nil)
+		}
+		transfers = []outgoingTransfer{{
+			asset: crypto.Asset{Symbol: token.Symbol, Decimals: token.Decimals},
+			value: amount.New(value),
+		}}
+		to = []string{sign.To}
+
+	case models.WithdrawalKindTypedData:
+		sign := *req.TypedData
+		sign.Passphrase, sign.SeedPassphrase = "", ""
+		chain, payload = crypto.ChainETH, sign
+
+		network, err := walletNetwork(wallet, chain)
+		if err != nil {
+			return core.Error(500, "invalid wallet network", err.Error(), nil)
+		}
+		if _, _, err := crypto.TypedDataHash(sign.TypedData); err != nil {
+			return core.Error(400, "invalid typed data", err.Error(), nil)
+		}
+		parties, err := crypto.ParseTypedDataParties(sign.TypedData)
+		if err != nil {
+			return core.Error(400, "invalid typed data", err.Error(), nil)
+		}
+		if transfers, err = s.typedDataTransfers(ctx, network, parties); err != nil {
+			return core.Error(500, "cannot read transfer", err.Error(), nil)
+		}
+		if !hasAddress(wallet, chain, sign.Address) {
+			return core.Error(404, "address not found in wallet", nil, nil)
+		}
+		to, _ = typedDataDestinations(parties)
+
+	default:
+		sign := *req.Psbt
+		sign.Passphrase, sign.SeedPassphrase = "", ""
+		chain, payload = crypto.ChainBTC, sign
+
+		network, err := walletNetwork(wallet, chain)
+		if err != nil {
+			return core.Error(500, "invalid wallet network", err.Error(), nil)
+		}
+		outputs, err := crypto.PSBTOutputs(sign.Psbt, network)
+		if err != nil {
+			return core.Error(400, "invalid psbt", err.Error(), nil)
+		}
+		transfer, destinations := btcTransfer(wallet, network, outputs)
+		transfers = []outgoingTransfer{transfer}
+		to = destinations
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return core.Error(500, "cannot encode withdrawal", err.Error(), nil)
+	}
+
+	// A token transfer is summarized by its tokens, not the ether it carries
+	summary := outgoingTransfer{}
+	if len(transfers) > 0 {
+		summary = transfers[len(transfers)-1]
+	} else {
+		summary.asset, _ = crypto.NativeAsset(chain)
+	}
+
+	request.Chain = chain
+	request.Asset = summary.asset.Symbol
+	request.Decimals = summary.asset.Decimals
+	request.Amount = summary.value
+	request.Destination = strings.Join(to, ",")
+	request.Payload = string(raw)
+	return nil
+}
+
+// hasAddress reports whether address is one of the wallet's on chain.
+func hasAddress(wallet *models.Wallet, chain, address string) bool {
+	for _, addr := range wallet.BlockchainAddresses {
+		if addr.Chain == chain && strings.EqualFold(addr.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListWithdrawals implements [services.WalletService].
+func (s *WalletServiceImpl) ListWithdrawals(
+	ctx context.Context,
+	userId string,
+	walletId string,
+) (*core.ApiResponse, error) {
+
+	if _, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId); err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return core.Error(404, "wallet not found", nil, nil), nil
+		}
+		return core.Error(500, "cannot load wallet", err.Error(), nil), nil
+	}
+
+	if err := s.withdrawalRequestRepo.ExpireDue(ctx, time.Now()); err != nil {
+		return core.Error(500, "cannot expire withdrawals", err.Error(), nil), nil
+	}
+	requests, err := s.withdrawalRequestRepo.ListByWallet(ctx, walletId)
+	if err != nil {
+		return core.Error(500, "cannot load withdrawals", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "ok", toWithdrawalResList(requests), nil), nil
+}
+
+// GetWithdrawal implements [services.WalletService].
+func (s *WalletServiceImpl) GetWithdrawal(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	withdrawalId string,
+) (*core.ApiResponse, error) {
+
+	request, resp := s.loadWithdrawal(ctx, userId, walletId, withdrawalId)
+	if resp != nil {
+		return resp, nil
+	}
+
+	return core.Success(200, "ok", toWithdrawalRes(request), nil), nil
+}
+
+// loadWithdrawal returns a withdrawal request of the user's wallet, after
+// expiring overdue requests.
+func (s *WalletServiceImpl) loadWithdrawal(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	withdrawalId string,
+) (*models.WithdrawalRequest, *core.ApiResponse) {
+
+	if _, err := s.walletRepo.GetByIdAndUser(ctx, walletId, userId); err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return nil, core.Error(404, "wallet not found", nil, nil)
+		}
+		return nil, core.Error(500, "cannot load wallet", err.Error(), nil)
+	}
+
+	if err := s.withdrawalRequestRepo.ExpireDue(ctx, time.Now()); err != nil {
+		return nil, core.Error(500, "cannot expire withdrawals", err.Error(), nil)
+	}
+	request, err := s.withdrawalRequestRepo.GetById(ctx, withdrawalId)
+	if errors.Is(err, domainerrors.ErrNotFound) || (err == nil && request.WalletId != walletId) {
+		return nil, core.Error(404, "withdrawal not found", nil, nil)
+	}
+	if err != nil {
+		return nil, core.Error(500, "cannot load withdrawal", err.Error(), nil)
+	}
+
+	return request, nil
+}
+
+// ExecuteWithdrawal implements [services.WalletService].
+// It signs the stored transfer of an approved request. The request is
+// locked while signing and only marked executed when signing succeeds, so
+// it is signed at most once.
+func (s *WalletServiceImpl) ExecuteWithdrawal(
+	ctx context.Context,
+	userId string,
+	walletId string,
+	withdrawalId string,
+	req *dto.ExecuteWithdrawalReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	if _, resp := s.loadWithdrawal(ctx, userId, walletId, withdrawalId); resp != nil {
+		return resp, nil
+	}
+
+	// Spending and nonces reserved while signing are given back if the
+	// request cannot be marked executed
+	var resp *core.ApiResponse
+	holds := &signingHolds{}
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+
+		// 1️⃣ Lock the request; only approved ones are signed
+		request, err := s.withdrawalRequestRepo.GetByIdForUpdate(ctx, withdrawalId)
+		if err != nil {
+			return err
+		}
+		if request.Status != models.WithdrawalStatusApproved {
+			resp = core.Error(409, "withdrawal is not approved", nil, map[string]any{
+				"status": request.Status,
+			})
+			return errWithdrawalNotExecuted
+		}
+
+		// 2️⃣ Sign what was approved
+		var txHash string
+		signCtx := withSigningHolds(withApprovedWithdrawal(ctx, request), holds)
+		resp, txHash, err = s.signWithdrawal(signCtx, userId, request, req, meta)
+		if err != nil {
+			return err
+		}
+		if resp.Code != 200 {
+			return errWithdrawalNotExecuted
+		}
+
+		// 3️⃣ Mark it executed
+		if err := s.withdrawalRequestRepo.UpdateStatus(
+			ctx, request.WithdrawalRequestId,
+			models.WithdrawalStatusApproved, models.WithdrawalStatusExecuted, txHash,
+		); err != nil {
+			return err
+		}
+		return s.audit(ctx, userId, walletId, auditWithdrawalExecuted, meta, map[string]string{
+			"withdrawal_id": request.WithdrawalRequestId,
+			"hash":          txHash,
+		})
+	})
+	if err != nil {
+		holds.release(ctx)
+	}
+	if errors.Is(err, errWithdrawalNotExecuted) {
+		return resp, nil
+	}
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "withdrawal changed concurrently", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot execute withdrawal", err.Error(), nil), nil
+	}
+
+	resp.Message = "withdrawal executed"
+	return resp, nil
+}
+
+// signWithdrawal runs the signing stored in request with the caller's
+// passphrases and returns its response and the transaction hash.
+func (s *WalletServiceImpl) signWithdrawal(
+	ctx context.Context,
+	userId string,
+	request *models.WithdrawalRequest,
+	req *dto.ExecuteWithdrawalReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, string, error) {
+
+	switch request.Kind {
+	case models.WithdrawalKindEthTransaction:
+		var sign dto.SignEthTransactionReq
+		if err := json.Unmarshal([]byte(request.Payload), &sign); err != nil {
+			return nil, "", err
+		}
+		sign.Passphrase, sign.SeedPassphrase = req.Passphrase, req.SeedPassphrase
+		resp, err := s.SignEthTransaction(ctx, userId, request.WalletId, &sign, meta)
+		return resp, signedHash(resp), err
+
+	case models.WithdrawalKindTokenTransfer:
+		var sign dto.SignTokenTransferReq
+		if err := json.Unmarshal([]byte(request.Payload), &sign); err != nil {
+			return nil, "", err
+		}
+		sign.Passphrase, sign.SeedPassphrase = req.Passphrase, req.SeedPassphrase
+		resp, err := s.SignTokenTransfer(ctx, userId, request.WalletId, &sign, meta)
+		return resp, signedHash(resp), err
+
+	case models.WithdrawalKindTypedData:
+		var sign dto.SignTypedDataReq
+		if err := json.Unmarshal([]byte(request.Payload), &sign); err != nil {
+			return nil, "", err
+		}
+		sign.Passphrase, sign.SeedPassphrase = req.Passphrase, req.SeedPassphrase
+		resp, err := s.SignTypedData(ctx, userId, request.WalletId, &sign, meta)
+		return resp, signedHash(resp), err
+
+	default:
+		var sign dto.SignBtcPSBTReq
+		if err := json.Unmarshal([]byte(request.Payload), &sign); err != nil {
+			return nil, "", err
+		}
+		sign.Passphrase, sign.SeedPassphrase = req.Passphrase, req.SeedPassphrase
+		resp, err := s.SignBtcPSBT(ctx, userId, request.WalletId, &sign, meta)
+		return resp, signedHash(resp), err
+	}
+}
+
+// signedHash returns the transaction hash of a successful signing
+// response, if it has one yet, or the hash a message signature covers.
+func signedHash(resp *core.ApiResponse) string {
+	if resp == nil {
+		return ""
+	}
+	switch data := resp.Data.(type) {
+	case dto.SignedTransactionRes:
+		return data.Hash
+	case dto.SignedPSBTRes:
+		return data.TxId
+	case dto.MessageSignatureRes:
+		return data.Hash
+	default:
+		return ""
+	}
+}
+
+// ListPendingWithdrawals implements [services.WalletService].
+// It is the approvers' queue across all wallets.
+func (s *WalletServiceImpl) ListPendingWithdrawals(
+	ctx context.Context,
+	req *dto.ListWithdrawalsReq,
+) (*core.ApiResponse, error) {
+
+	status := req.Status
+	if status == "" {
+		status = models.WithdrawalStatusPending
+	}
+
+	if err := s.withdrawalRequestRepo.ExpireDue(ctx, time.Now()); err != nil {
+		return core.Error(500, "cannot expire withdrawals", err.Error(), nil), nil
+	}
+	requests, err := s.withdrawalRequestRepo.ListByStatus(ctx, status)
+	if err != nil {
+		return core.Error(500, "cannot load withdrawals", err.Error(), nil), nil
+	}
+
+	return core.Success(200, "ok", toWithdrawalResList(requests), nil), nil
+}
+
+// DecideWithdrawal implements [services.WalletService].
+// Approvers other than the requester each decide once. The request is
+// approved when enough of them approve, and rejected by any rejection.
+func (s *WalletServiceImpl) DecideWithdrawal(
+	ctx context.Context,
+	approverId string,
+	withdrawalId string,
+	decision string,
+	req *dto.DecideWithdrawalReq,
+	meta dto.RequestMeta,
+) (*core.ApiResponse, error) {
+
+	if err := s.withdrawalRequestRepo.ExpireDue(ctx, time.Now()); err != nil {
+		return core.Error(500, "cannot expire withdrawals", err.Error(), nil), nil
+	}
+
+	var resp *core.ApiResponse
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+
+		// 1️⃣ Lock the request and check the approver may decide on it
+		request, err := s.withdrawalRequestRepo.GetByIdForUpdate(ctx, withdrawalId)
+		if err != nil {
+			return err
+		}
+		if request.RequesterId == approverId {
+			resp = core.Error(403, "requester cannot decide on own withdrawal", nil, nil)
+			return errWithdrawalNotExecuted
+		}
+		if request.Status != models.WithdrawalStatusPending {
+			resp = core.Error(409, "withdrawal is not pending", nil, map[string]any{
+				"status": request.Status,
+			})
+			return errWithdrawalNotExecuted
+		}
+
+		// 2️⃣ Record the decision
+		approval := models.WithdrawalApproval{
+			WithdrawalApprovalId: uuid.New().String(),
+			WithdrawalRequestId:  request.WithdrawalRequestId,
+			ApproverId:           approverId,
+			Decision:             decision,
+			Comment:              req.Comment,
+			CreateDate:           time.Now(),
+		}
+		if err := s.withdrawalRequestRepo.CreateApproval(ctx, &approval); err != nil {
+			return err
+		}
+		request.Approvals = append(request.Approvals, approval)
+
+		action := auditWithdrawalApproved
+		if decision == models.WithdrawalDecisionRejected {
+			action = auditWithdrawalRejected
+		}
+		if err := s.audit(ctx, approverId, request.WalletId, action, meta, map[string]string{
+			"withdrawal_id": request.WithdrawalRequestId,
+			"comment":       req.Comment,
+		}); err != nil {
+			return err
+		}
+
+		// 3️⃣ Settle the request once the outcome is known
+		next := ""
+		switch {
+		case decision == models.WithdrawalDecisionRejected:
+			next = models.WithdrawalStatusRejected
+		case request.ApprovalCount() >= request.RequiredApprovals:
+			next = models.WithdrawalStatusApproved
+		}
+		if next != "" {
+			if err := s.withdrawalRequestRepo.UpdateStatus(
+				ctx, request.WithdrawalRequestId, models.WithdrawalStatusPending, next, "",
+			); err != nil {
+				return err
+			}
+			request.Status = next
+		}
+
+		resp = core.Success(200, "withdrawal "+decision, toWithdrawalRes(request), nil)
+		return nil
+	})
+	if errors.Is(err, errWithdrawalNotExecuted) {
+		return resp, nil
+	}
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return core.Error(404, "withdrawal not found", nil, nil), nil
+	}
+	if errors.Is(err, domainerrors.ErrConflict) {
+		return core.Error(409, "approver already decided on this withdrawal", nil, nil), nil
+	}
+	if err != nil {
+		return core.Error(500, "cannot record decision", err.Error(), nil), nil
+	}
+
+	return resp, nil
+}
+
+func toWithdrawalResList(requests []models.WithdrawalRequest) []dto.WithdrawalRes {
+	res := make([]dto.WithdrawalRes, 0, len(requests))
+	for i := range requests {
+		res = append(res, toWithdrawalRes(&requests[i]))
+	}
+	return res
+}
+
+func toWithdrawalRes(request *models.WithdrawalRequest) dto.WithdrawalRes {
+	decisions := make([]dto.WithdrawalDecisionRes, 0, len(request.Approvals))
+	for _, approval := range request.Approvals {
+		decisions = append(decisions, dto.WithdrawalDecisionRes{
+			ApproverId: approval.ApproverId,
+			Decision:   approval.Decision,
+			Comment:    approval.Comment,
+			CreateDate: approval.CreateDate,
+		})
+	}
+
+	return dto.WithdrawalRes{
+		WithdrawalId:      request.WithdrawalRequestId,
+		WalletId:          request.WalletId,
+		RequesterId:       request.RequesterId,
+		Kind:              request.Kind,
+		Chain:             request.Chain,
+		Asset:             request.Asset,
+		Decimals:          request.Decimals,
+		Amount:            request.Amount,
+		Destination:       request.Destination,
+		Status:            request.Status,
+		RequiredApprovals: request.RequiredApprovals,
+		Approvals:         request.ApprovalCount(),
+		Decisions:         decisions,
+		ExpireDate:        request.ExpireDate,
+		TxHash:            request.TxHash,
+		CreateDate:        request.CreateDate,
+		UpdateDate:        request.UpdateDate,
+	}
+}
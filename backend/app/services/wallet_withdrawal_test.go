@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/create-go-app/fiber-go-template/app/dto"
+	models "github.com/create-go-app/fiber-go-template/app/entities"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+	"github.com/create-go-app/fiber-go-template/pkg/core"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const oneEther = "1000000000000000000"
+
+func TestSigningAddsUpTowardsApprovalThreshold(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{
+		Approvals:  2,
+		Thresholds: map[string]string{"ETH": "1"},
+	})
+	wallet := createSigningWallet(t, svc)
+
+	// 1️⃣ One transfer above the threshold needs approval on its own
+	requireApprovalRequired(t, signEther(t, svc, wallet, "2"), "")
+
+	// 2️⃣ Transfers under it add up over the window
+	require.Equal(t, 200, signEther(t, svc, wallet, "0.6").Code)
+	res := signEther(t, svc, wallet, "0.6")
+	requireApprovalRequired(t, res, ether(t, "0.6"))
+	require.Equal(t, "1d", res.Meta.(map[string]any)["window"])
+
+	// 3️⃣ What still fits under the threshold is signed
+	require.Equal(t, 200, signEther(t, svc, wallet, "0.4").Code)
+	requireApprovalRequired(t, signEther(t, svc, wallet, "0.000000000000000001"), oneEther)
+
+	// 4️⃣ The total is the signer's, across their wallets
+	other := createSigningWallet(t, svc)
+	requireApprovalRequired(t, signEther(t, svc, other, "0.001"), oneEther)
+}
+
+func TestApprovedWithdrawalsSkipApprovalTotal(t *testing.T) {
+	svc := newTestWalletService(t, newMemoryStore())
+	svc.approvals = WithdrawalApprovalConfig{Approvals: 2, Thresholds: map[string]string{"*": "1"}}.withDefaults()
+
+	native, err := crypto.NativeAsset(crypto.ChainETH)
+	require.NoError(t, err)
+	value, err := amount.Parse(oneEther)
+	require.NoError(t, err)
+	transfers := []outgoingTransfer{{asset: native, value: value}, {asset: native, value: value}}
+
+	uses, caps, err := svc.approvalCaps(context.Background(), transfers)
+	require.NoError(t, err)
+	require.Len(t, uses, 2)
+	require.Equal(t, []SpendingCap{{Counter: "approval:ETH", Window: defaultApprovalWindow, Limit: value}}, caps)
+
+	ctx := withApprovedWithdrawal(context.Background(), &models.WithdrawalRequest{})
+	uses, caps, err = svc.approvalCaps(ctx, transfers)
+	require.NoError(t, err)
+	require.Empty(t, uses)
+	require.Empty(t, caps)
+}
+
+func TestWithdrawalWorkflow(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newWithdrawalService(t)
+	wallet := createSigningWallet(t, svc)
+
+	// 1️⃣ The transfer is summarized for approvers, kept without the
+	// passphrase and waits for approval
+	withdrawal := requestEther(t, svc, wallet, "2")
+	require.Equal(t, models.WithdrawalStatusPending, withdrawal.Status)
+	require.Equal(t, testUserId, withdrawal.RequesterId)
+	require.Equal(t, "ETH", withdrawal.Asset)
+	require.Equal(t, ether(t, "2"), withdrawal.Amount.String())
+	require.Equal(t, common.HexToAddress(ledgerPayee).Hex(), withdrawal.Destination)
+	require.Equal(t, 2, withdrawal.RequiredApprovals)
+	require.NotContains(t, store.requests[withdrawal.WithdrawalId].Payload, testPassphrase)
+
+	res := executeWithdrawal(t, svc, wallet, withdrawal.WithdrawalId, testPassphrase)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, "withdrawal is not approved", res.Message)
+
+	// 2️⃣ It is in the approvers' queue
+	res, err := svc.ListPendingWithdrawals(ctx, &dto.ListWithdrawalsReq{})
+	require.NoError(t, err)
+	require.Len(t, res.Data.([]dto.WithdrawalRes), 1)
+
+	// 3️⃣ The requester cannot approve, and other approvers decide once
+	res = decideWithdrawal(t, svc, testUserId, withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 403, res.Code)
+	require.Equal(t, "requester cannot decide on own withdrawal", res.Message)
+
+	res = decideWithdrawal(t, svc, "approver-1", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 200, res.Code, res.Message)
+	decided := res.Data.(dto.WithdrawalRes)
+	require.Equal(t, models.WithdrawalStatusPending, decided.Status)
+	require.Equal(t, 1, decided.Approvals)
+
+	res = decideWithdrawal(t, svc, "approver-1", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, "approver already decided on this withdrawal", res.Message)
+
+	// 4️⃣ The last approval needed approves it
+	res = decideWithdrawal(t, svc, "approver-2", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 200, res.Code, res.Message)
+	decided = res.Data.(dto.WithdrawalRes)
+	require.Equal(t, models.WithdrawalStatusApproved, decided.Status)
+	require.Len(t, decided.Decisions, 2)
+	require.Equal(t, "approver-2", decided.Decisions[1].ApproverId)
+	require.Equal(t, "checked with treasury", decided.Decisions[1].Comment)
+
+	// 5️⃣ A failed signing leaves it approved
+	res = executeWithdrawal(t, svc, wallet, withdrawal.WithdrawalId, "wrong")
+	require.Equal(t, 400, res.Code)
+	requireWithdrawalStatus(t, svc, wallet, withdrawal.WithdrawalId, models.WithdrawalStatusApproved)
+
+	// 6️⃣ Executing signs what was approved, past the threshold, once
+	res = executeWithdrawal(t, svc, wallet, withdrawal.WithdrawalId, testPassphrase)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, "withdrawal executed", res.Message)
+	signed := res.Data.(dto.SignedTransactionRes)
+	raw, err := hexutil.Decode(signed.RawTransaction)
+	require.NoError(t, err)
+	var tx types.Transaction
+	require.NoError(t, tx.UnmarshalBinary(raw))
+	require.Equal(t, ether(t, "2"), tx.Value().String())
+	require.Equal(t, ledgerPayee, tx.To().Hex())
+
+	executed := requireWithdrawalStatus(t, svc, wallet, withdrawal.WithdrawalId, models.WithdrawalStatusExecuted)
+	require.Equal(t, signed.Hash, executed.TxHash)
+
+	res = executeWithdrawal(t, svc, wallet, withdrawal.WithdrawalId, testPassphrase)
+	require.Equal(t, 409, res.Code)
+	res = decideWithdrawal(t, svc, "approver-3", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, "withdrawal is not pending", res.Message)
+
+	// 7️⃣ Every step is audited
+	require.Equal(t, []string{
+		auditWithdrawalRequested,
+		auditWithdrawalApproved,
+		auditWithdrawalApproved,
+		auditTransactionSigned,
+		auditWithdrawalExecuted,
+	}, store.auditActions())
+}
+
+func TestWithdrawalRejection(t *testing.T) {
+	svc, store := newWithdrawalService(t)
+	wallet := createSigningWallet(t, svc)
+	withdrawal := requestEther(t, svc, wallet, "2")
+
+	// One rejection settles the request, whatever the approvals
+	res := decideWithdrawal(t, svc, "approver-1", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 200, res.Code, res.Message)
+	res = decideWithdrawal(t, svc, "approver-2", withdrawal.WithdrawalId, models.WithdrawalDecisionRejected)
+	require.Equal(t, 200, res.Code, res.Message)
+	require.Equal(t, "withdrawal rejected", res.Message)
+	require.Equal(t, models.WithdrawalStatusRejected, res.Data.(dto.WithdrawalRes).Status)
+
+	res = decideWithdrawal(t, svc, "approver-3", withdrawal.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 409, res.Code)
+	res = executeWithdrawal(t, svc, wallet, withdrawal.WithdrawalId, testPassphrase)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, models.WithdrawalStatusRejected, res.Meta.(map[string]any)["status"])
+	require.Contains(t, store.auditActions(), auditWithdrawalRejected)
+}
+
+func TestWithdrawalExpiry(t *testing.T) {
+	svc, store := newWithdrawalService(t)
+	wallet := createSigningWallet(t, svc)
+	pending := requestEther(t, svc, wallet, "2")
+	approved := requestEther(t, svc, wallet, "3")
+	for _, approver := range []string{"approver-1", "approver-2"} {
+		res := decideWithdrawal(t, svc, approver, approved.WithdrawalId, models.WithdrawalDecisionApproved)
+		require.Equal(t, 200, res.Code, res.Message)
+	}
+	require.WithinDuration(t, time.Now().Add(defaultWithdrawalRequestTTL), pending.ExpireDate, time.Minute)
+
+	// Pending and approved requests past their expiry can no longer be
+	// decided on or executed
+	store.mu.Lock()
+	for _, request := range store.requests {
+		request.ExpireDate = time.Now()
+	}
+	store.mu.Unlock()
+
+	res := decideWithdrawal(t, svc, "approver-1", pending.WithdrawalId, models.WithdrawalDecisionApproved)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, models.WithdrawalStatusExpired, res.Meta.(map[string]any)["status"])
+	res = executeWithdrawal(t, svc, wallet, approved.WithdrawalId, testPassphrase)
+	require.Equal(t, 409, res.Code)
+	require.Equal(t, models.WithdrawalStatusExpired, res.Meta.(map[string]any)["status"])
+
+	res, err := svc.ListWithdrawals(context.Background(), testUserId, wallet.walletId)
+	require.NoError(t, err)
+	for _, withdrawal := range res.Data.([]dto.WithdrawalRes) {
+		require.Equal(t, models.WithdrawalStatusExpired, withdrawal.Status)
+	}
+}
+
+func TestCreateWithdrawalRejects(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newWithdrawalService(t)
+	wallet := createSigningWallet(t, svc)
+
+	tests := []struct {
+		name    string
+		userId  string
+		edit    func(req *dto.SignEthTransactionReq)
+		code    int
+		message string
+	}{
+		{"other user", "user-2", func(req *dto.SignEthTransactionReq) {}, 404, "wallet not found"},
+		{"foreign address", testUserId, func(req *dto.SignEthTransactionReq) { req.From = ledgerPayee }, 404, "address not found in wallet"},
+		{"contract deployment", testUserId, func(req *dto.SignEthTransactionReq) { req.To = "" }, 400, "contract deployment is not a withdrawal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sign := etherTransfer(t, wallet, "2")
+			tt.edit(&sign)
+			res, err := svc.CreateWithdrawal(ctx, tt.userId, wallet.walletId, &dto.CreateWithdrawalReq{
+				Kind:           models.WithdrawalKindEthTransaction,
+				EthTransaction: &sign,
+			}, dto.RequestMeta{})
+			require.NoError(t, err)
+			require.Equal(t, tt.code, res.Code)
+			require.Equal(t, tt.message, res.Message)
+		})
+	}
+	require.Empty(t, store.requests)
+
+	t.Run("approvals off", func(t *testing.T) {
+		svc.approvals.Approvals = 0
+		sign := etherTransfer(t, wallet, "2")
+		res, err := svc.CreateWithdrawal(ctx, testUserId, wallet.walletId, &dto.CreateWithdrawalReq{
+			Kind:           models.WithdrawalKindEthTransaction,
+			EthTransaction: &sign,
+		}, dto.RequestMeta{})
+		require.NoError(t, err)
+		require.Equal(t, 400, res.Code)
+		require.Equal(t, "withdrawal approvals are not enabled", res.Message)
+	})
+}
+
+// withSpendingControls turns on withdrawal approvals and spending limits
+// backed by a fresh in-memory Redis. The signer has no spending policies
+// until a test adds them.
+func withSpendingControls(t *testing.T, svc *WalletServiceImpl, approvals WithdrawalApprovalConfig) {
+	t.Helper()
+
+	svc.approvals = approvals.withDefaults()
+	svc.policyRepo = &memoryPolicies{}
+	svc.limiter = NewSpendingLimiter(newTestCache(t, miniredis.RunT(t)))
+}
+
+// signingWallet is a wallet of testUserId and its first Ethereum address.
+type signingWallet struct {
+	walletId string
+	address  string
+}
+
+func createSigningWallet(t *testing.T, svc *WalletServiceImpl) signingWallet {
+	t.Helper()
+
+	created, err := svc.CreateWallet(context.Background(), testUserId, &dto.CreateWalletReq{
+		WalletName: "Signing",
+		Passphrase: testPassphrase,
+		Chains:     []string{crypto.ChainETH},
+		EthNetwork: crypto.NetworkMainnet,
+	})
+	require.NoError(t, err)
+	return signingWallet{walletId: created.WalletId, address: created.Addresses[0].Address}
+}
+
+// signEther signs a transfer of value ether from the wallet on mainnet.
+func signEther(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, value string) *core.ApiResponse {
+	t.Helper()
+
+	sign := etherTransfer(t, wallet, value)
+	res, err := svc.SignEthTransaction(context.Background(), testUserId, wallet.walletId, &sign, dto.RequestMeta{})
+	require.NoError(t, err)
+	return res
+}
+
+// etherTransfer is a transfer of value ether from the wallet on mainnet.
+func etherTransfer(t *testing.T, wallet signingWallet, value string) dto.SignEthTransactionReq {
+	t.Helper()
+
+	return dto.SignEthTransactionReq{
+		Passphrase: testPassphrase,
+		From:       wallet.address,
+		ChainId:    1,
+		To:         ledgerPayee,
+		Value:      ether(t, value),
+		Gas:        21000,
+		GasPrice:   "1000000000",
+	}
+}
+
+// ether renders an amount of ether in wei.
+func ether(t *testing.T, value string) string {
+	t.Helper()
+
+	wei, err := amount.ParseDecimal(value, 18)
+	require.NoError(t, err)
+	return wei.String()
+}
+
+// requireApprovalRequired checks that res refuses a signing for want of
+// approval, with used already signed for when it is not empty.
+func requireApprovalRequired(t *testing.T, res *core.ApiResponse, used string) {
+	t.Helper()
+
+	require.Equal(t, 403, res.Code, res.Message)
+	require.Equal(t, "withdrawal requires approval", res.Message)
+	meta := res.Meta.(map[string]any)
+	require.Equal(t, "ETH", meta["asset"])
+	require.Equal(t, oneEther, meta["threshold"])
+	if used != "" {
+		require.Equal(t, used, meta["used"])
+	}
+}
+
+// newWithdrawalService returns a wallet service on which ether transfers
+// above 1 ETH need two approvals.
+func newWithdrawalService(t *testing.T) (*WalletServiceImpl, *memoryStore) {
+	t.Helper()
+
+	store := newMemoryStore()
+	svc := newTestWalletService(t, store)
+	svc.withdrawalRequestRepo = store.withdrawalRequestRepo()
+	withSpendingControls(t, svc, WithdrawalApprovalConfig{
+		Approvals:  2,
+		Thresholds: map[string]string{"ETH": "1"},
+	})
+	return svc, store
+}
+
+// requestEther submits a transfer of value ether for approval.
+func requestEther(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, value string) dto.WithdrawalRes {
+	t.Helper()
+
+	sign := etherTransfer(t, wallet, value)
+	res, err := svc.CreateWithdrawal(context.Background(), testUserId, wallet.walletId, &dto.CreateWithdrawalReq{
+		Kind:           models.WithdrawalKindEthTransaction,
+		EthTransaction: &sign,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	require.Equal(t, 201, res.Code, res.Message)
+	return res.Data.(dto.WithdrawalRes)
+}
+
+func decideWithdrawal(t *testing.T, svc *WalletServiceImpl, approverId, withdrawalId, decision string) *core.ApiResponse {
+	t.Helper()
+
+	res, err := svc.DecideWithdrawal(context.Background(), approverId, withdrawalId, decision, &dto.DecideWithdrawalReq{
+		Comment: "checked with treasury",
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	return res
+}
+
+func executeWithdrawal(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, withdrawalId, passphrase string) *core.ApiResponse {
+	t.Helper()
+
+	res, err := svc.ExecuteWithdrawal(context.Background(), testUserId, wallet.walletId, withdrawalId, &dto.ExecuteWithdrawalReq{
+		Passphrase: passphrase,
+	}, dto.RequestMeta{})
+	require.NoError(t, err)
+	return res
+}
+
+func requireWithdrawalStatus(t *testing.T, svc *WalletServiceImpl, wallet signingWallet, withdrawalId, status string) dto.WithdrawalRes {
+	t.Helper()
+
+	res, err := svc.GetWithdrawal(context.Background(), testUserId, wallet.walletId, withdrawalId)
+	require.NoError(t, err)
+	require.Equal(t, 200, res.Code, res.Message)
+	withdrawal := res.Data.(dto.WithdrawalRes)
+	require.Equal(t, status, withdrawal.Status)
+	return withdrawal
+}
@@ -0,0 +1,79 @@
+// Command kek-rotate mints a new wallet key encryption key and re-wraps
+// every wallet's data encryption key under it.
+//
+// With the keyring provider a fresh key is added to WALLET_KEYRING_FILE and
+// made primary; the previous keys stay in the file so a re-run can finish
+// an interrupted rotation. With a KMS provider rotation happens in the KMS:
+// point WALLET_KMS_KEY_ID at the new key, then run with -rewrap-only.
+//
+// Usage:
+//
+//	go run ./cmd/kek-rotate [-rewrap-only]
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/create-go-app/fiber-go-template/app/repository"
+	"github.com/create-go-app/fiber-go-template/app/services"
+	"github.com/create-go-app/fiber-go-template/pkg/configs"
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+func main() {
+	rewrapOnly := flag.Bool("rewrap-only", false, "re-wrap under the current KEK without minting a new one")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	kek, err := configs.KEKProvider()
+	if err != nil {
+		log.Fatalf("load KEK provider: %v", err)
+	}
+
+	if !*rewrapOnly {
+		rotator, ok := kek.(crypto.KEKRotator)
+		if !ok {
+			log.Fatal("KEK provider cannot mint keys; rotate in the KMS and run with -rewrap-only")
+		}
+		keyId, err := rotator.Rotate(ctx)
+		if err != nil {
+			log.Fatalf("rotate KEK: %v", err)
+		}
+		log.Printf("new primary KEK %s", keyId)
+	}
+
+	cryptoService, err := crypto.NewCryptoService(crypto.Config{
+		FingerprintKey: []byte(os.Getenv("WALLET_FINGERPRINT_KEY")),
+		KEK:            kek,
+	})
+	if err != nil {
+		log.Fatalf("init crypto: %v", err)
+	}
+
+	db, err := database.OpenGORMDBConnection()
+	if err != nil {
+		log.Fatalf("open database: %v", err)
+	}
+
+	keyService := services.NewWalletKeyService(repository.NewWalletRepository(db), cryptoService)
+
+	res, err := keyService.RewrapWallets(ctx)
+	if err != nil {
+		log.Fatalf("rewrap wallets: %v", err)
+	}
+
+	log.Printf(
+		"KEK %s: %d rewrapped, %d upgraded, %d pending unlock, %d failed",
+		res.KekId, res.Rewrapped, res.Upgraded, res.Pending, res.Failed,
+	)
+	if res.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,70 @@
+// Command wallet-claim hands wallets created before migration 000001
+// bound wallets to users over to their owners.
+//
+// Such wallets have no owner and are unreachable through the API until
+// claimed. Without flags the command lists them; with -wallet and -user it
+// makes the user the owner of one of them. The claim is audited as
+// wallet.claimed.
+//
+// Usage:
+//
+//	go run ./cmd/wallet-claim [-wallet <walletId> -user <userId>]
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/create-go-app/fiber-go-template/app/repository"
+	"github.com/create-go-app/fiber-go-template/app/services"
+	"github.com/create-go-app/fiber-go-template/platform/database"
+
+	_ "github.com/joho/godotenv/autoload"
+)
+
+func main() {
+	walletId := flag.String("wallet", "", "unowned wallet to claim")
+	userId := flag.String("user", "", "user who becomes the wallet owner")
+	flag.Parse()
+
+	if (*walletId == "") != (*userId == "") {
+		log.Fatal("-wallet and -user go together")
+	}
+
+	ctx := context.Background()
+
+	db, err := database.OpenGORMDBConnection()
+	if err != nil {
+		log.Fatalf("open database: %v", err)
+	}
+
+	claimService := services.NewWalletClaimService(
+		repository.NewWalletRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewAuditEventRepository(db),
+		database.NewGormTransactionManager(db),
+	)
+
+	if *walletId == "" {
+		wallets, err := claimService.ListUnclaimed(ctx)
+		if err != nil {
+			log.Fatalf("list unowned wallets: %v", err)
+		}
+		for _, w := range wallets {
+			addresses := make([]string, 0, len(w.Addresses))
+			for _, a := range w.Addresses {
+				addresses = append(addresses, a.Address)
+			}
+			log.Printf("%s %q created %s: %s", w.WalletId, w.WalletName, w.CreateDate.Format("2006-01-02"), strings.Join(addresses, ", "))
+		}
+		log.Printf("%d unowned wallets", len(wallets))
+		return
+	}
+
+	if err := claimService.ClaimWallet(ctx, *walletId, *userId); err != nil {
+		log.Fatalf("claim wallet %s: %v", *walletId, err)
+	}
+	log.Printf("wallet %s now belongs to user %s", *walletId, *userId)
+}
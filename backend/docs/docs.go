@@ -23,48 +23,85 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/token/renew": {
-            "post": {
+        "/v1/policies": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Renew access and refresh tokens.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Return a user's policies, those over all of their wallets first. Requires the policy:manage\ncredential.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Token"
+                    "Policy"
                 ],
-                "summary": "renew access and refresh tokens",
+                "summary": "List the spending policies of a user",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "refresh_token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SpendingPolicyRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/user/sign/in": {
+            },
             "post": {
-                "description": "Auth user and return access and refresh token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Limit what can be signed for one of a user's wallets, or for all of them together: a maximum per\ntransaction, rolling daily and weekly caps of an asset, and allowed hours. Token allowances and\npermits count as transfers; while amounts are limited, unregistered tokens and calls that cannot be\ndecoded are refused. A signing request that breaks a rule is refused with 403 and the rule it hit.\nThe owner is notified. Requires the policy:manage credential.",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,62 +109,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Policy"
                 ],
-                "summary": "auth user and return access and refresh token",
+                "summary": "Create a spending policy",
                 "parameters": [
                     {
-                        "description": "Sign in payload",
+                        "description": "Policy to create",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SignIn"
+                            "$ref": "#/definitions/dto.CreateSpendingPolicyReq"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpendingPolicyRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limits",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User or wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Policy already exists for this scope and asset",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/user/sign/out": {
-            "post": {
+        "/v1/policies/{policyId}": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "De-authorize user and delete refresh token from Redis.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "de-authorize user and delete refresh token from Redis",
-                "responses": {
-                    "204": {
-                        "description": "ok",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/user/sign/up": {
-            "post": {
-                "description": "Create a new user",
+                "description": "Limits left out are switched off. The owner is notified. Requires the policy:manage credential.",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,17 +196,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Policy"
                 ],
-                "summary": "create a new user",
+                "summary": "Replace the limits of a spending policy",
                 "parameters": [
                     {
-                        "description": "Sign up payload",
+                        "type": "string",
+                        "description": "Spending policy ID",
+                        "name": "policyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New limits",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SignUp"
+                            "$ref": "#/definitions/dto.UpdateSpendingPolicyReq"
                         }
                     }
                 ],
@@ -153,50 +221,97 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Users"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpendingPolicyRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limits",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Spending policy not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/wallet": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new crypto wallet with generated mnemonic and first blockchain address",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "The owner is notified. Requires the policy:manage credential.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Wallet"
+                    "Policy"
                 ],
-                "summary": "Create a new wallet",
+                "summary": "Delete a spending policy",
                 "parameters": [
                     {
-                        "description": "Create wallet payload",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateWalletReq"
-                        }
+                        "type": "string",
+                        "description": "Spending policy ID",
+                        "name": "policyId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateWalletRes"
+                            "$ref": "#/definitions/core.ApiResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Spending policy not found",
                         "schema": {
                             "$ref": "#/definitions/core.ApiResponse"
                         }
@@ -210,14 +325,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/wallet/restore": {
+        "/v1/signatures/verify": {
             "post": {
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ],
-                "description": "Restore access to an existing wallet using secret phrase and optional passphrase.\nIf the wallet was protected with a passphrase, the correct passphrase must be provided.\nOn success, returns wallet identifier and associated blockchain addresses.",
+                "description": "Recover the signer of a personal message or EIP-712 typed data signature and compare it with the claimed address.",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,23 +335,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Wallet"
+                    "Signature"
                 ],
-                "summary": "Restore / Access existing wallet",
+                "summary": "Verify an Ethereum message signature",
                 "parameters": [
                     {
-                        "description": "Restore wallet payload (secret phrase and optional passphrase)",
+                        "description": "Signature to verify",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RestoreWalletReq"
+                            "$ref": "#/definitions/dto.VerifySignatureReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Wallet restored successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -251,7 +361,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.RestoreWalletRes"
+                                            "$ref": "#/definitions/dto.VerifySignatureRes"
                                         }
                                     }
                                 }
@@ -259,7 +369,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid secret phrase or passphrase",
+                        "description": "Invalid request or signature",
                         "schema": {
                             "$ref": "#/definitions/core.ApiResponse"
                         }
@@ -272,99 +382,4117 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "core.ApiResponse": {
+        },
+        "/v1/token/renew": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Renew access and refresh tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Token"
+                ],
+                "summary": "renew access and refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the ERC-20 tokens the wallet tracks on an Ethereum network (default mainnet).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Token"
+                ],
+                "summary": "List registered ERC-20 tokens",
+                "parameters": [
+                    {
+                        "enum": [
+                            "mainnet",
+                            "sepolia",
+                            "holesky",
+                            "dev"
+                        ],
+                        "type": "string",
+                        "description": "Ethereum network",
+                        "name": "network",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.Erc20TokenRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add an ERC-20 contract to the registry of an Ethereum network. Requires the token:manage credential.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Token"
+                ],
+                "summary": "Register an ERC-20 token",
+                "parameters": [
+                    {
+                        "description": "Token to register",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateErc20TokenReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.Erc20TokenRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Contract or symbol already registered",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sign/in": {
+            "post": {
+                "description": "Auth user and return access and refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "auth user and return access and refresh token",
+                "parameters": [
+                    {
+                        "description": "Sign in payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SignIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sign/out": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "De-authorize user and delete refresh token from Redis.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "de-authorize user and delete refresh token from Redis",
+                "responses": {
+                    "204": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sign/up": {
+            "post": {
+                "description": "Create a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "create a new user",
+                "parameters": [
+                    {
+                        "description": "Sign up payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SignUp"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Users"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallet": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new crypto wallet with generated mnemonic and the first address of every requested chain (ETH by default)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Create a new wallet",
+                "parameters": [
+                    {
+                        "description": "Create wallet payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWalletReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWalletRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallet/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore access to an existing wallet using secret phrase and optional passphrase.\nIf the wallet was protected with a passphrase, the correct passphrase must be provided.\nThe optional seed passphrase is the BIP39 \"25th word\"; it changes the derived keys and is never stored.\nThe phrase is normalized (NFKD, whitespace, case) and checked against the BIP39 wordlists.\nA malformed phrase is rejected with error code invalid_word_count, unknown_word or invalid_checksum;\nunknown words come with their position and the closest wordlist entries.\nWith import set, a valid phrase that matches no wallet is stored as a new wallet\nencrypted under the given passphrase, with the first address of each requested chain.\nOn success, returns wallet identifier and associated blockchain addresses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Restore / Access existing wallet",
+                "parameters": [
+                    {
+                        "description": "Restore wallet payload (secret phrase and optional passphrase)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RestoreWalletReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Wallet restored successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RestoreWalletRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Wallet imported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RestoreWalletRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid secret phrase or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Wallet already exists",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallet/watch-only": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register an account-level extended public key as a wallet without any mnemonic, for auditing and\ncold-storage monitoring. ETH accepts xpub keys (m/44'/60'/account'); BTC accepts xpub, ypub or zpub\n(tpub, upub or vpub on test networks), which derive BIP44 legacy, BIP49 P2SH-SegWit or BIP84 native\nSegWit addresses. Addresses, balances and deposits work as for any wallet, but signing is refused.\nPrivate extended keys are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Import a watch-only wallet",
+                "parameters": [
+                    {
+                        "description": "Extended public key to watch",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportWatchOnlyWalletReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WalletRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid extended public key",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Wallet already exists",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return every wallet owned by the authenticated user together with its blockchain addresses.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "List wallets of the current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WalletRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return a single wallet owned by the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get a wallet of the current user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WalletRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/addresses": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Derive the next unused address index under the given chain, address type, account and change levels.\nBitcoin addresses default to BIP84 native SegWit; BIP49 (p2sh-p2wpkh) and BIP44 (p2pkh) are also supported.\nThe wallet passphrase is required when the wallet was protected with one.\nWatch-only wallets derive from their extended public key without a passphrase, on its chain, address\ntype and account only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Derive a new wallet address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Derive address payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeriveAddressReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AddressRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/allowlist": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return whether allowlist mode is on and the wallet's approved destinations. Addresses become\nactive once their cooling-off period has passed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get the withdrawal allowlist of a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalAllowlistRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a labelled destination to the wallet's allowlist. It can only be paid to after the cooling-off\nperiod (WITHDRAWAL_ADDRESS_DELAY), and the owner is notified. The wallet passphrase is required when\nthe wallet was protected with one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Add a withdrawal address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address to approve",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddWithdrawalAddressReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalAddressRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Address already in allowlist",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/allowlist/mode": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "While allowlist mode is on, transactions and PSBTs paying anywhere but active withdrawal addresses\nor the wallet's own addresses are refused. Switching on is immediate. Switching off needs the wallet\npassphrase, notifies the owner and only takes effect after the cooling-off period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Switch allowlist mode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Allowlist mode",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetAllowlistModeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalAllowlistRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/allowlist/{addressId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove a destination from the wallet's allowlist. Removal is immediate.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Remove a withdrawal address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Withdrawal address ID",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or withdrawal address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/balances": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the native balance of every Ethereum address of the wallet, read from the configured node.\nBalances are in base units (wei) as strings, and are cached until the next block.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get on-chain wallet balances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.BalanceRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Node request failed",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No node configured for the wallet network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/btc/psbt/sign": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Co-sign a base64 BIP174 PSBT. Native SegWit (P2WPKH) inputs whose BIP32 derivation names this\nwallet's master fingerprint and one of its addresses are signed; other inputs are left untouched.\nWith finalize set, complete inputs are finalized and the raw transaction is extracted once all are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Sign a Bitcoin PSBT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "PSBT to sign",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignBtcPSBTReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SignedPSBTRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid PSBT, no matching inputs or invalid passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/eth/nonces/commit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "End the lease of a nonce reserved while signing (reserve_nonce) once the transaction was\nbroadcast, so the nonce is not handed out again when the lease runs out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Commit a reserved nonce",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nonce to commit",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CommitNonceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nonce is not reserved",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No nonce allocator for the wallet's network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/eth/nonces/release": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Give back a nonce reserved while signing (reserve_nonce) when the transaction will not be\nbroadcast, so it is handed out again before any higher nonce.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Release a reserved nonce",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nonce to release",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReleaseNonceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Nonce is not reserved",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No nonce allocator for the wallet's network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/eth/nonces/resync": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Realign the nonce allocator of an address with the chain's pending nonce and return the next\nnonce to hand out. With no reservation outstanding, nonces that never reached the node are reused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Resync an address's nonces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address to resync",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResyncNonceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.NonceRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No nonce allocator for the wallet's network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/passphrase": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Verify the current passphrase, then re-encrypt the secret phrase under the new one.\nLeave current_passphrase empty to add a passphrase to a wallet created without one.\nEvery attempt, successful or not, is written to the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Change or add the wallet passphrase",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Change passphrase payload",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePassphraseReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Passphrase changed",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/policies": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the policies that bind signing with the wallet: its own and those over all of the owner's\nwallets. Only administrators can change them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Policy"
+                ],
+                "summary": "List the spending policies of a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SpendingPolicyRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/sign/message": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign a message with personal_sign semantics (\"\\x19Ethereum Signed Message:\\n\" + length prefix)\nusing the key of one of the wallet's Ethereum addresses. Set encoding to hex for binary messages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Sign an EIP-191 personal message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message to sign",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignMessageReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageSignatureRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid message or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/sign/token-transfer": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Build transfer(to, amount) calldata for a registered token on the wallet's Ethereum network and\nsign it as a transaction from one of the wallet's addresses to the token contract. amount is in the\ntoken's base units. Gas, fee and nonce fields are as for signing a transaction. The transaction is not broadcast.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Sign an ERC-20 token transfer offline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Token transfer to sign",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignTokenTransferReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SignedTransactionRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet, address or token not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Nonce could not be reserved",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No nonce allocator for the wallet's network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/sign/transaction": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign a legacy (gas_price) or EIP-1559 (max_fee_per_gas, max_priority_fee_per_gas) transaction\nwith the key of one of the wallet's Ethereum addresses. Amounts are wei, as decimal or 0x-hex strings.\nchain_id must match the wallet's Ethereum network. The transaction is not broadcast.\nWith reserve_nonce, the nonce is leased from the shared allocator instead; commit it once the\ntransaction is broadcast, or release it if the transaction is abandoned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Sign an Ethereum transaction offline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction to sign",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignEthTransactionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SignedTransactionRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transaction or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Nonce could not be reserved",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "No nonce allocator for the wallet's network",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/sign/typed-data": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign typed data with eth_signTypedData_v4 semantics using the key of one of the wallet's Ethereum addresses.\nWhen the domain sets chainId it must match the wallet's Ethereum network.\nWith withdrawal approvals on, permits above the approval threshold and any other typed data need an\napproved withdrawal request of kind typed_data.\nWith the withdrawal allowlist on, the permit spender, or else the verifying contract and any spender the\nmessage names, must be on it; typed data naming neither is refused.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Sign EIP-712 typed data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Typed data to sign",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SignTypedDataReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageSignatureRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid typed data or passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the wallet's transactions, newest first. Pass meta.next_cursor back as cursor to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "List wallet transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "broadcast",
+                            "confirmed",
+                            "failed",
+                            "replaced"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "incoming",
+                            "outgoing"
+                        ],
+                        "type": "string",
+                        "description": "Direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender or recipient address",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest transaction date (RFC 3339, inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest transaction date (RFC 3339, inclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TransactionRes"
+                                            }
+                                        },
+                                        "meta": {
+                                            "$ref": "#/definitions/dto.CursorMeta"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add a transaction to the wallet ledger. The wallet's side of the transfer (from_address when outgoing,\nto_address when incoming) must be one of its addresses. Status defaults to pending; a transaction\nalready sent may be recorded as broadcast with its tx_hash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Record a wallet transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction to record",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RecordTransactionReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TransactionRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/transactions/{txId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return a single transaction of the wallet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Get a wallet transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "txId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TransactionRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/transactions/{txId}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move a transaction along pending → broadcast → confirmed, failed or replaced; a pending transaction may also fail.\nMarking a transaction broadcast needs its tx_hash. Any other change is rejected with 409.\nDeposits found on chain by the deposit watcher are confirmed or rolled back by the watcher only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Change a transaction status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "txId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTransactionStatusReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TransactionRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or transaction not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Status change not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/withdrawals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the wallet's withdrawal requests, newest first, with every approver decision.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "List withdrawal requests of a wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WithdrawalRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submit a transaction, token transfer, PSBT or EIP-712 typed data for approval. Transfers and token\nallowances (approve, increaseAllowance, permits) above the approval threshold\n(WITHDRAWAL_APPROVAL_THRESHOLDS), or that take the total signed without approval over\nWITHDRAWAL_APPROVAL_WINDOW above it, contract calls that cannot be decoded and typed data other than\npermits can only be signed through an approved request. Passphrases are not stored; they are given\nwhen the approved request is executed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "Request a withdrawal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer to approve",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWithdrawalReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transfer or approvals not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet, address or token not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/withdrawals/{withdrawalId}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return one of the wallet's withdrawal requests with every approver decision.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "Get a withdrawal request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Withdrawal request ID",
+                        "name": "withdrawalId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/wallets/{id}/withdrawals/{withdrawalId}/execute": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign the transfer of an approved withdrawal request. It is signed at most once; the response is the\none of the matching signing endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "Execute an approved withdrawal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Withdrawal request ID",
+                        "name": "withdrawalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Wallet passphrases",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExecuteWithdrawalReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid passphrase",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Destination not in withdrawal allowlist",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet or withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Withdrawal not approved",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/withdrawals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return withdrawal requests of all wallets in a status (default pending), oldest first. Requires the\nwithdrawal:approve credential.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "List withdrawal requests awaiting approvers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected",
+                            "expired",
+                            "executed"
+                        ],
+                        "type": "string",
+                        "description": "Request status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WithdrawalRes"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/withdrawals/{withdrawalId}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record an approval. The request is approved once WITHDRAWAL_APPROVALS approvers other than the\nrequester approve it. Requires the withdrawal:approve credential.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "Approve a withdrawal request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Withdrawal request ID",
+                        "name": "withdrawalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional comment",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DecideWithdrawalReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied or own request",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Withdrawal not pending or already decided",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/withdrawals/{withdrawalId}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record a rejection, which rejects the request. Requires the withdrawal:approve credential.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Withdrawal"
+                ],
+                "summary": "Reject a withdrawal request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Withdrawal request ID",
+                        "name": "withdrawalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional reason",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DecideWithdrawalReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/core.ApiResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WithdrawalRes"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied or own request",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Withdrawal not found",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Withdrawal not pending or already decided",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/core.ApiResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "amount.Int": {
+            "type": "object"
+        },
+        "core.ApiResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "error": {},
+                "message": {
+                    "type": "string"
+                },
+                "meta": {},
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AddWithdrawalAddressReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "chain",
+                "label"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "chain": {
+                    "type": "string",
+                    "enum": [
+                        "ETH",
+                        "BTC"
+                    ]
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AddressRes": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "integer"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "address_id": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "derivation_path": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BalanceRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1500000000000000000"
+                },
+                "balance_formatted": {
+                    "type": "string",
+                    "example": "1.5"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "network": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangePassphraseReq": {
+            "type": "object",
+            "required": [
+                "new_passphrase"
+            ],
+            "properties": {
+                "current_passphrase": {
+                    "description": "CurrentPassphrase is empty when the wallet has no passphrase yet.",
+                    "type": "string"
+                },
+                "new_passphrase": {
+                    "type": "string",
+                    "maxLength": 256
+                }
+            }
+        },
+        "dto.CommitNonceReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "nonce"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateErc20TokenReq": {
+            "type": "object",
+            "required": [
+                "contract_address",
+                "decimals",
+                "name",
+                "network",
+                "symbol"
+            ],
+            "properties": {
+                "contract_address": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer",
+                    "maximum": 77
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "sepolia",
+                        "holesky",
+                        "dev"
+                    ]
+                },
+                "symbol": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "dto.CreateSpendingPolicyReq": {
+            "type": "object",
+            "required": [
+                "asset",
+                "user_id"
+            ],
+            "properties": {
+                "allowed_from_hour": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "allowed_to_hour": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "asset": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "daily_limit": {
+                    "type": "string"
+                },
+                "max_per_transaction": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "wallet_id": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "weekly_limit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateWalletReq": {
+            "type": "object",
+            "required": [
+                "wallet_name"
+            ],
+            "properties": {
+                "btc_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "testnet",
+                        "signet",
+                        "regtest"
+                    ]
+                },
+                "chains": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "eth_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "sepolia",
+                        "holesky",
+                        "dev"
+                    ]
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "english",
+                        "japanese",
+                        "spanish",
+                        "french",
+                        "italian",
+                        "korean",
+                        "chinese_simplified",
+                        "chinese_traditional",
+                        "czech"
+                    ]
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                },
+                "wallet_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "word_count": {
+                    "type": "integer",
+                    "enum": [
+                        12,
+                        15,
+                        18,
+                        21,
+                        24
+                    ]
+                }
+            }
+        },
+        "dto.CreateWalletRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AddressRes"
+                    }
+                },
+                "secretPhrase": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateWithdrawalReq": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "eth_transaction": {
+                    "$ref": "#/definitions/dto.SignEthTransactionReq"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "eth_transaction",
+                        "token_transfer",
+                        "btc_psbt",
+                        "typed_data"
+                    ]
+                },
+                "psbt": {
+                    "$ref": "#/definitions/dto.SignBtcPSBTReq"
+                },
+                "token_transfer": {
+                    "$ref": "#/definitions/dto.SignTokenTransferReq"
+                },
+                "typed_data": {
+                    "$ref": "#/definitions/dto.SignTypedDataReq"
+                }
+            }
+        },
+        "dto.CursorMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DecideWithdrawalReq": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "dto.DeriveAddressReq": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "type": "integer"
+                },
+                "address_type": {
+                    "type": "string",
+                    "enum": [
+                        "p2wpkh",
+                        "p2sh-p2wpkh",
+                        "p2pkh"
+                    ]
+                },
+                "chain": {
+                    "type": "string",
+                    "enum": [
+                        "ETH",
+                        "BTC"
+                    ]
+                },
+                "change": {
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Erc20TokenRes": {
+            "type": "object",
+            "properties": {
+                "chain": {
+                    "type": "string"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "network": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "token_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExecuteWithdrawalReq": {
+            "type": "object",
+            "properties": {
+                "passphrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportWatchOnlyWalletReq": {
+            "type": "object",
+            "required": [
+                "chain",
+                "extended_public_key",
+                "wallet_name"
+            ],
+            "properties": {
+                "btc_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "testnet",
+                        "signet",
+                        "regtest"
+                    ]
+                },
+                "chain": {
+                    "type": "string",
+                    "enum": [
+                        "ETH",
+                        "BTC"
+                    ]
+                },
+                "eth_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "sepolia",
+                        "holesky",
+                        "dev"
+                    ]
+                },
+                "extended_public_key": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "wallet_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                }
+            }
+        },
+        "dto.MessageSignatureRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NonceRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "next_nonce": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RecordTransactionReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "chain",
+                "direction",
+                "from_address",
+                "to_address"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "asset": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "chain": {
+                    "type": "string",
+                    "enum": [
+                        "ETH",
+                        "BTC"
+                    ]
+                },
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "incoming",
+                        "outgoing"
+                    ]
+                },
+                "from_address": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "broadcast"
+                    ]
+                },
+                "to_address": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "dto.ReleaseNonceReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "nonce"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RestoreWalletReq": {
+            "type": "object",
+            "required": [
+                "secret_phrase"
+            ],
+            "properties": {
+                "btc_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "testnet",
+                        "signet",
+                        "regtest"
+                    ]
+                },
+                "chains": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "eth_network": {
+                    "type": "string",
+                    "enum": [
+                        "mainnet",
+                        "sepolia",
+                        "holesky",
+                        "dev"
+                    ]
+                },
+                "import": {
+                    "description": "Import creates a new wallet when no existing wallet matches the phrase.",
+                    "type": "boolean"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "secret_phrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                },
+                "wallet_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RestoreWalletRes": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imported": {
+                    "type": "boolean"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResyncNonceReq": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetAllowlistModeReq": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SignBtcPSBTReq": {
+            "type": "object",
+            "required": [
+                "psbt"
+            ],
+            "properties": {
+                "finalize": {
+                    "type": "boolean"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "psbt": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SignEthTransactionReq": {
+            "type": "object",
+            "required": [
+                "chain_id",
+                "from",
+                "gas"
+            ],
+            "properties": {
+                "chain_id": {
+                    "type": "integer"
+                },
+                "data": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "gas": {
+                    "type": "integer"
+                },
+                "gas_price": {
+                    "type": "string"
+                },
+                "max_fee_per_gas": {
+                    "type": "string"
+                },
+                "max_priority_fee_per_gas": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "reserve_nonce": {
+                    "type": "boolean"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SignMessageReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "message"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "encoding": {
+                    "type": "string",
+                    "enum": [
+                        "utf8",
+                        "hex"
+                    ]
+                },
+                "message": {
+                    "type": "string"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SignTokenTransferReq": {
+            "type": "object",
+            "required": [
+                "amount",
+                "chain_id",
+                "from",
+                "gas",
+                "to",
+                "token"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "chain_id": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "gas": {
+                    "type": "integer"
+                },
+                "gas_price": {
+                    "type": "string"
+                },
+                "max_fee_per_gas": {
+                    "type": "string"
+                },
+                "max_priority_fee_per_gas": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "reserve_nonce": {
+                    "type": "boolean"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "dto.SignTypedDataReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "typed_data"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "passphrase": {
+                    "type": "string"
+                },
+                "seed_passphrase": {
+                    "type": "string"
+                },
+                "typed_data": {
+                    "type": "object"
+                }
+            }
+        },
+        "dto.SignedPSBTRes": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "type": "boolean"
+                },
+                "psbt": {
+                    "type": "string"
+                },
+                "raw_transaction": {
+                    "type": "string"
+                },
+                "signed_inputs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "txid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SignedTransactionRes": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "integer"
+                },
+                "raw_transaction": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SpendingPolicyRes": {
+            "type": "object",
+            "properties": {
+                "allowed_from_hour": {
+                    "type": "integer"
+                },
+                "allowed_to_hour": {
+                    "type": "integer"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "daily_limit": {
+                    "type": "string"
+                },
+                "max_per_transaction": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "spending_policy_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "update_date": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                },
+                "weekly_limit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TransactionRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "1500000000000000000"
+                },
+                "amount_formatted": {
+                    "type": "string",
+                    "example": "1.5"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "contract_address": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "from_address": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_address": {
+                    "type": "string"
+                },
+                "transaction_date": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "update_date": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateSpendingPolicyReq": {
+            "type": "object",
+            "properties": {
+                "allowed_from_hour": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "allowed_to_hour": {
+                    "type": "integer",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "daily_limit": {
+                    "type": "string"
+                },
+                "max_per_transaction": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "weekly_limit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateTransactionStatusReq": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "broadcast",
+                        "confirmed",
+                        "failed",
+                        "replaced"
+                    ]
+                },
+                "tx_hash": {
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "dto.VerifySignatureReq": {
+            "type": "object",
+            "required": [
+                "address",
+                "signature",
+                "type"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "encoding": {
+                    "type": "string",
+                    "enum": [
+                        "utf8",
+                        "hex"
+                    ]
+                },
+                "message": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "personal",
+                        "typed_data"
+                    ]
+                },
+                "typed_data": {
+                    "type": "object"
+                }
+            }
+        },
+        "dto.VerifySignatureRes": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "data": {},
-                "error": {},
-                "message": {
+                "signer": {
                     "type": "string"
                 },
-                "meta": {},
-                "success": {
+                "valid": {
                     "type": "boolean"
                 }
             }
         },
-        "dto.CreateWalletReq": {
+        "dto.WalletRes": {
             "type": "object",
-            "required": [
-                "wallet_name"
-            ],
             "properties": {
-                "passphrase": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AddressRes"
+                    }
+                },
+                "btc_network": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "eth_network": {
+                    "type": "string"
+                },
+                "extended_public_key": {
+                    "type": "string"
+                },
+                "has_seed_passphrase": {
+                    "type": "boolean"
+                },
+                "update_date": {
+                    "type": "string"
+                },
+                "wallet_id": {
                     "type": "string"
                 },
                 "wallet_name": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                    "type": "string"
+                },
+                "watch_only": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.CreateWalletRes": {
+        "dto.WithdrawalAddressRes": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "active_date": {
+                    "type": "string"
+                },
                 "address": {
                     "type": "string"
                 },
-                "secretPhrase": {
+                "chain": {
                     "type": "string"
                 },
-                "wallet_id": {
+                "create_date": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "withdrawal_address_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.RestoreWalletReq": {
+        "dto.WithdrawalAllowlistRes": {
             "type": "object",
-            "required": [
-                "secret_phrase"
-            ],
             "properties": {
-                "passphrase": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WithdrawalAddressRes"
+                    }
+                },
+                "disable_date": {
                     "type": "string"
                 },
-                "secret_phrase": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.WithdrawalDecisionRes": {
+            "type": "object",
+            "properties": {
+                "approver_id": {
                     "type": "string"
                 },
-                "wallet_name": {
+                "comment": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "decision": {
                     "type": "string"
                 }
             }
         },
-        "dto.RestoreWalletRes": {
+        "dto.WithdrawalRes": {
             "type": "object",
             "properties": {
-                "addresses": {
+                "amount": {
+                    "type": "string",
+                    "example": "1500000000000000000"
+                },
+                "approvals": {
+                    "type": "integer"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "create_date": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "decisions": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dto.WithdrawalDecisionRes"
                     }
                 },
+                "destination": {
+                    "type": "string"
+                },
+                "expire_date": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "requester_id": {
+                    "type": "string"
+                },
+                "required_approvals": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "update_date": {
+                    "type": "string"
+                },
                 "wallet_id": {
                     "type": "string"
+                },
+                "withdrawal_id": {
+                    "type": "string"
                 }
             }
         },
         "models.BlockchainAddress": {
             "type": "object",
             "properties": {
+                "account": {
+                    "type": "integer"
+                },
                 "address": {
                     "type": "string"
                 },
                 "addressId": {
                     "type": "string"
                 },
+                "addressIndex": {
+                    "type": "integer"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "integer"
+                },
                 "createDate": {
                     "type": "string"
                 },
+                "derivationPath": {
+                    "type": "string"
+                },
+                "purpose": {
+                    "type": "integer"
+                },
                 "updateDate": {
                     "type": "string"
                 },
@@ -421,7 +4549,34 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "amount": {
-                    "type": "number"
+                    "$ref": "#/definitions/amount.Int"
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "blockHash": {
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "type": "integer"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "confirmations": {
+                    "type": "integer"
+                },
+                "contractAddress": {
+                    "type": "string"
+                },
+                "createDate": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "direction": {
+                    "type": "string"
                 },
                 "fromAddress": {
                     "type": "string"
@@ -438,6 +4593,12 @@ const docTemplate = `{
                 "transactionId": {
                     "type": "string"
                 },
+                "txHash": {
+                    "type": "string"
+                },
+                "updateDate": {
+                    "type": "string"
+                },
                 "wallet": {
                     "description": "🔗 Relation",
                     "allOf": [
@@ -495,6 +4656,12 @@ const docTemplate = `{
         "models.Wallet": {
             "type": "object",
             "properties": {
+                "allowlistDisableDate": {
+                    "type": "string"
+                },
+                "allowlistEnabled": {
+                    "type": "boolean"
+                },
                 "blockchainAddresses": {
                     "description": "🔗 Relations",
                     "type": "array",
@@ -502,9 +4669,27 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.BlockchainAddress"
                     }
                 },
+                "btcNetwork": {
+                    "type": "string"
+                },
                 "createDate": {
                     "type": "string"
                 },
+                "ethNetwork": {
+                    "type": "string"
+                },
+                "extendedPublicKey": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "hasSeedPassphrase": {
+                    "type": "boolean"
+                },
+                "kekId": {
+                    "type": "string"
+                },
                 "passphraseHash": {
                     "type": "string"
                 },
@@ -520,11 +4705,141 @@ const docTemplate = `{
                 "updateDate": {
                     "type": "string"
                 },
+                "userId": {
+                    "type": "string"
+                },
                 "walletId": {
                     "type": "string"
                 },
                 "walletName": {
                     "type": "string"
+                },
+                "watchOnlyChain": {
+                    "type": "string"
+                },
+                "withdrawalAddresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WithdrawalAddress"
+                    }
+                },
+                "withdrawalRequests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WithdrawalRequest"
+                    }
+                },
+                "wrappedDek": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WithdrawalAddress": {
+            "type": "object",
+            "properties": {
+                "activeDate": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "createDate": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "walletId": {
+                    "type": "string"
+                },
+                "withdrawalAddressId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WithdrawalApproval": {
+            "type": "object",
+            "properties": {
+                "approverId": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "createDate": {
+                    "type": "string"
+                },
+                "decision": {
+                    "type": "string"
+                },
+                "withdrawalApprovalId": {
+                    "type": "string"
+                },
+                "withdrawalRequestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WithdrawalRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "$ref": "#/definitions/amount.Int"
+                },
+                "approvals": {
+                    "description": "🔗 Relations",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WithdrawalApproval"
+                    }
+                },
+                "asset": {
+                    "type": "string"
+                },
+                "chain": {
+                    "type": "string"
+                },
+                "createDate": {
+                    "type": "string"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "expireDate": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "requesterId": {
+                    "type": "string"
+                },
+                "requiredApprovals": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                },
+                "updateDate": {
+                    "type": "string"
+                },
+                "walletId": {
+                    "type": "string"
+                },
+                "withdrawalRequestId": {
+                    "type": "string"
                 }
             }
         }
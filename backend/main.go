@@ -47,9 +47,18 @@ func main() {
 	routes.SwaggerRoute(app) // Register a route for API Docs (Swagger).
 	routes.HealthRoute(app, container)
 	routes.PublicRoutes(app, container.AuthController, container.WalletController)
-	routes.PrivateRoutes(app, container.JWTMiddleware, container.AuthController, container.TokenController, container.WalletController)
+	routes.PrivateRoutes(app, container.JWTMiddleware, container.AuthController, container.TokenController, container.WalletController, container.TransactionController, container.BalanceController, container.Erc20TokenController, container.PolicyController)
 	routes.NotFoundRoute(app) // Register route for 404 Error.
 
+	// Background workers.
+	if container.DepositWorker != nil {
+		if err := container.DepositWatcher.Schedule(); err != nil {
+			panic(err)
+		}
+		container.DepositWorker.Start()
+		defer container.DepositWorker.Stop()
+	}
+
 	// Start server (with or without graceful shutdown).
 	if os.Getenv("STAGE_STATUS") == "dev" {
 		utils.StartServer(app)
@@ -0,0 +1,178 @@
+// Package amount holds exact on-chain amounts. Values are integers in an
+// asset's base units (wei, satoshi) and are converted to decimal text only
+// for display, so nothing is ever rounded.
+package amount
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidAmount is returned for text that is not a valid amount.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Int is a non-negative amount in base units. The zero value is 0.
+// It is stored as numeric(78,0), which holds any uint256, and encoded in
+// JSON as a decimal string.
+type Int struct {
+	v *big.Int
+}
+
+// New returns an amount of n base units. n is copied.
+func New(n *big.Int) Int {
+	if n == nil {
+		return Int{}
+	}
+	return Int{v: new(big.Int).Set(n)}
+}
+
+// FromUint64 returns an amount of n base units.
+func FromUint64(n uint64) Int {
+	return Int{v: new(big.Int).SetUint64(n)}
+}
+
+// Parse reads a decimal integer of base units.
+func Parse(s string) (Int, error) {
+	if s == "" || strings.ContainsAny(s, "+-_") {
+		return Int{}, ErrInvalidAmount
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Int{}, ErrInvalidAmount
+	}
+	return Int{v: n}, nil
+}
+
+// ParseDecimal reads a display amount such as "1.5" and converts it to
+// base units for an asset with the given decimals. More fractional digits
+// than the asset has are rejected rather than rounded.
+func ParseDecimal(s string, decimals uint8) (Int, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if len(frac) > int(decimals) || strings.ContainsAny(frac, "+-_") {
+		return Int{}, ErrInvalidAmount
+	}
+
+	units := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	return Parse(units)
+}
+
+// BigInt returns a copy of the amount.
+func (a Int) BigInt() *big.Int {
+	if a.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.v)
+}
+
+// Sign returns 0 for a zero amount and 1 otherwise.
+func (a Int) Sign() int {
+	if a.v == nil {
+		return 0
+	}
+	return a.v.Sign()
+}
+
+// Cmp compares two amounts like [big.Int.Cmp].
+func (a Int) Cmp(b Int) int {
+	return a.BigInt().Cmp(b.BigInt())
+}
+
+// Add returns a + b.
+func (a Int) Add(b Int) Int {
+	return Int{v: new(big.Int).Add(a.BigInt(), b.BigInt())}
+}
+
+// String returns the amount in base units.
+func (a Int) String() string {
+	if a.v == nil {
+		return "0"
+	}
+	return a.v.String()
+}
+
+// Format renders the amount in whole units of an asset with the given
+// decimals, without trailing zeros: 1500000000000000000 wei is "1.5".
+func Format(a Int, decimals uint8) string {
+	units := a.String()
+	if decimals == 0 {
+		return units
+	}
+
+	d := int(decimals)
+	if len(units) <= d {
+		units = strings.Repeat("0", d-len(units)+1) + units
+	}
+
+	whole, frac := units[:len(units)-d], strings.TrimRight(units[len(units)-d:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// MarshalJSON encodes the amount as a decimal string, since JSON numbers
+// lose precision beyond 2^53 in most clients.
+func (a Int) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts a decimal string of base units.
+func (a *Int) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalidAmount
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// Value implements [driver.Valuer].
+func (a Int) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// Scan implements [sql.Scanner] for numeric columns.
+func (a *Int) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Int{}
+		return nil
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("%w: %d", ErrInvalidAmount, v)
+		}
+		*a = FromUint64(uint64(v))
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	default:
+		return fmt.Errorf("amount: cannot scan %T", src)
+	}
+}
+
+// scanString parses a numeric column value. Postgres may render whole
+// numerics with a zero fraction, such as "15.0", which is accepted.
+func (a *Int) scanString(s string) error {
+	if whole, frac, ok := strings.Cut(s, "."); ok && strings.Trim(frac, "0") == "" {
+		s = whole
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, s)
+	}
+	*a = parsed
+	return nil
+}
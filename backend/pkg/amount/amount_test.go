@@ -0,0 +1,121 @@
+package amount
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// maxUint256 is 2^256 - 1, the largest on-chain amount.
+const maxUint256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{"0", "1", "1000000000000000001", maxUint256} {
+		a, err := Parse(s)
+		require.NoError(t, err)
+		require.Equal(t, s, a.String())
+	}
+
+	for _, s := range []string{"", "-1", "+1", "1_000", "1.5", "0x10", " 1", "one"} {
+		_, err := Parse(s)
+		require.ErrorIs(t, err, ErrInvalidAmount, s)
+	}
+}
+
+func TestParseDecimalAndFormat(t *testing.T) {
+	tests := []struct {
+		display  string
+		decimals uint8
+		units    string
+		format   string
+	}{
+		{"1.5", 18, "1500000000000000000", "1.5"},
+		{"0.000000000000000001", 18, "1", "0.000000000000000001"},
+		{".25", 8, "25000000", "0.25"},
+		{"21000000", 8, "2100000000000000", "21000000"},
+		{"1.10", 6, "1100000", "1.1"},
+		{"42", 0, "42", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.display, func(t *testing.T) {
+			a, err := ParseDecimal(tt.display, tt.decimals)
+			require.NoError(t, err)
+			require.Equal(t, tt.units, a.String())
+			require.Equal(t, tt.format, Format(a, tt.decimals))
+		})
+	}
+
+	// Digits the asset cannot hold are refused, not rounded
+	for _, s := range []string{"0.0000000000000000001", "1.-5", "-1.5"} {
+		_, err := ParseDecimal(s, 18)
+		require.ErrorIs(t, err, ErrInvalidAmount, s)
+	}
+	_, err := ParseDecimal("1.5", 0)
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestZeroValueAndArithmetic(t *testing.T) {
+	var zero Int
+	require.Equal(t, "0", zero.String())
+	require.Zero(t, zero.Sign())
+	require.Equal(t, "0", Format(zero, 18))
+
+	one := FromUint64(1)
+	require.Equal(t, 1, one.Cmp(zero))
+	require.Equal(t, "2", one.Add(one).String())
+
+	// New and BigInt copy, so callers cannot change an amount
+	n := big.NewInt(5)
+	a := New(n)
+	n.SetInt64(6)
+	a.BigInt().SetInt64(7)
+	require.Equal(t, "5", a.String())
+	require.Equal(t, "0", New(nil).String())
+}
+
+func TestJSONUsesDecimalStrings(t *testing.T) {
+	a, err := Parse(maxUint256)
+	require.NoError(t, err)
+
+	raw, err := json.Marshal(struct{ Amount Int }{a})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Amount": "`+maxUint256+`"}`, string(raw))
+
+	var decoded struct{ Amount Int }
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	require.Equal(t, maxUint256, decoded.Amount.String())
+
+	// Numbers would already have lost precision in the client
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"Amount": 1500000000000000000}`), &decoded), ErrInvalidAmount)
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{nil, "0"},
+		{int64(42), "42"},
+		{[]byte(maxUint256), maxUint256},
+		{"15", "15"},
+		{"15.000", "15"},
+	}
+
+	for _, tt := range tests {
+		var a Int
+		require.NoError(t, a.Scan(tt.src))
+		require.Equal(t, tt.want, a.String())
+	}
+
+	var a Int
+	for _, src := range []any{int64(-1), "15.5", 1.5} {
+		require.Error(t, a.Scan(src), "%v", src)
+	}
+
+	value, err := FromUint64(7).Value()
+	require.NoError(t, err)
+	require.Equal(t, "7", value)
+}
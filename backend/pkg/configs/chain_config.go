@@ -0,0 +1,56 @@
+package configs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
+)
+
+// Supported values of ETH_CHAIN_CLIENT.
+const (
+	ChainClientRPC       = "rpc"
+	ChainClientSimulated = "simulated"
+)
+
+// EthChainClient func for building the Ethereum chain client.
+// "rpc" (default) dials ETH_RPC_URL and returns nil when it is empty, which
+// leaves on-chain features disabled; "simulated" runs an in-memory chain
+// for the "dev" network.
+func EthChainClient(ctx context.Context) (blockchain.ChainClient, error) {
+	switch kind := os.Getenv("ETH_CHAIN_CLIENT"); kind {
+	case "", ChainClientRPC:
+		url := os.Getenv("ETH_RPC_URL")
+		if url == "" {
+			return nil, nil
+		}
+		client, err := blockchain.DialEthClient(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	case ChainClientSimulated:
+		return blockchain.NewSimulatedClient(nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported ETH_CHAIN_CLIENT %q", kind)
+	}
+}
+
+// defaultNonceLease is how long a reserved nonce stays leased before it is
+// handed out again, unless ETH_NONCE_LEASE says otherwise.
+const defaultNonceLease = 2 * time.Minute
+
+// NonceLease func for reading how long a reserved nonce may stay unused.
+func NonceLease() (time.Duration, error) {
+	v := os.Getenv("ETH_NONCE_LEASE")
+	if v == "" {
+		return defaultNonceLease, nil
+	}
+	lease, err := time.ParseDuration(v)
+	if err != nil || lease <= 0 {
+		return 0, fmt.Errorf("invalid ETH_NONCE_LEASE %q", v)
+	}
+	return lease, nil
+}
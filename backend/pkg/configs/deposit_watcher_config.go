@@ -0,0 +1,50 @@
+package configs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/app/services"
+)
+
+// DepositWatcherEnabled func for checking whether the API process should
+// run the deposit watcher. It is on unless DEPOSIT_WATCHER_ENABLED is
+// "false", so replicas can leave the work to one of them.
+func DepositWatcherEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("DEPOSIT_WATCHER_ENABLED"))
+	return err != nil || enabled
+}
+
+// DepositWatcherConfig func for reading the deposit watcher settings.
+// Unset values keep the watcher's defaults.
+func DepositWatcherConfig() (services.DepositWatcherConfig, error) {
+	var config services.DepositWatcherConfig
+	var err error
+
+	if v := os.Getenv("DEPOSIT_CONFIRMATIONS"); v != "" {
+		if config.Confirmations, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return config, fmt.Errorf("invalid DEPOSIT_CONFIRMATIONS: %w", err)
+		}
+	}
+	if v := os.Getenv("DEPOSIT_REORG_DEPTH"); v != "" {
+		if config.ReorgDepth, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return config, fmt.Errorf("invalid DEPOSIT_REORG_DEPTH: %w", err)
+		}
+	}
+	if v := os.Getenv("DEPOSIT_POLL_INTERVAL"); v != "" {
+		if config.PollInterval, err = time.ParseDuration(v); err != nil {
+			return config, fmt.Errorf("invalid DEPOSIT_POLL_INTERVAL: %w", err)
+		}
+	}
+	if v := os.Getenv("DEPOSIT_START_BLOCK"); v != "" {
+		start, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return config, fmt.Errorf("invalid DEPOSIT_START_BLOCK: %w", err)
+		}
+		config.StartBlock = &start
+	}
+
+	return config, nil
+}
@@ -0,0 +1,40 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/create-go-app/fiber-go-template/pkg/crypto"
+)
+
+// Supported values of WALLET_KEK_PROVIDER.
+const (
+	KEKProviderKeyring  = "keyring"
+	KEKProviderLocalKMS = "kms-local"
+)
+
+// KEKProvider func for building the wallet key encryption key provider.
+// "keyring" (default) keeps KEKs in the WALLET_KEYRING_FILE JSON file;
+// "kms-local" goes through the KMS code path with that same file as a
+// stand-in key store and WALLET_KMS_KEY_ID as the active key.
+func KEKProvider() (crypto.KEKProvider, error) {
+	path := os.Getenv("WALLET_KEYRING_FILE")
+	if path == "" {
+		return nil, errors.New("WALLET_KEYRING_FILE is not set")
+	}
+
+	keyring, err := crypto.LoadKeyring(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch provider := os.Getenv("WALLET_KEK_PROVIDER"); provider {
+	case "", KEKProviderKeyring:
+		return keyring, nil
+	case KEKProviderLocalKMS:
+		return crypto.NewKMSProvider(crypto.NewLocalKMS(keyring), os.Getenv("WALLET_KMS_KEY_ID"))
+	default:
+		return nil, fmt.Errorf("unsupported WALLET_KEK_PROVIDER %q", provider)
+	}
+}
@@ -0,0 +1,75 @@
+package configs
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/app/services"
+	"github.com/create-go-app/fiber-go-template/pkg/amount"
+)
+
+// defaultWithdrawalAddressDelay is the cooling-off period of a new
+// withdrawal address, unless WITHDRAWAL_ADDRESS_DELAY says otherwise.
+const defaultWithdrawalAddressDelay = 24 * time.Hour
+
+// WithdrawalAddressDelay func for reading how long a newly added withdrawal
+// address, or a switch-off of the allowlist, waits before taking effect.
+func WithdrawalAddressDelay() (time.Duration, error) {
+	v := os.Getenv("WITHDRAWAL_ADDRESS_DELAY")
+	if v == "" {
+		return defaultWithdrawalAddressDelay, nil
+	}
+	delay, err := time.ParseDuration(v)
+	if err != nil || delay < 0 {
+		return 0, fmt.Errorf("invalid WITHDRAWAL_ADDRESS_DELAY %q", v)
+	}
+	return delay, nil
+}
+
+// WithdrawalApprovalConfig func for reading which withdrawals need
+// approvals: WITHDRAWAL_APPROVALS approvers must approve transfers above
+// the per-asset WITHDRAWAL_APPROVAL_THRESHOLDS, given in whole units as
+// "ETH=10,BTC=0.5,*=0", alone or added up over WITHDRAWAL_APPROVAL_WINDOW.
+// Unset values keep the workflow off.
+func WithdrawalApprovalConfig() (services.WithdrawalApprovalConfig, error) {
+	var config services.WithdrawalApprovalConfig
+	var err error
+
+	if v := os.Getenv("WITHDRAWAL_APPROVALS"); v != "" {
+		if config.Approvals, err = strconv.Atoi(v); err != nil || config.Approvals < 0 {
+			return config, fmt.Errorf("invalid WITHDRAWAL_APPROVALS %q", v)
+		}
+	}
+	if v := os.Getenv("WITHDRAWAL_APPROVAL_THRESHOLDS"); v != "" {
+		config.Thresholds = make(map[string]string)
+		for _, entry := range strings.Split(v, ",") {
+			symbol, limit, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			symbol, limit = strings.ToUpper(strings.TrimSpace(symbol)), strings.TrimSpace(limit)
+			if !ok || symbol == "" {
+				return config, fmt.Errorf("invalid WITHDRAWAL_APPROVAL_THRESHOLDS entry %q", entry)
+			}
+			if _, err := amount.ParseDecimal(limit, math.MaxUint8); err != nil {
+				return config, fmt.Errorf("invalid WITHDRAWAL_APPROVAL_THRESHOLDS amount for %s: %w", symbol, err)
+			}
+			config.Thresholds[symbol] = limit
+		}
+	}
+	if v := os.Getenv("WITHDRAWAL_REQUEST_TTL"); v != "" {
+		if config.RequestTTL, err = time.ParseDuration(v); err != nil || config.RequestTTL <= 0 {
+			return config, fmt.Errorf("invalid WITHDRAWAL_REQUEST_TTL %q", v)
+		}
+	}
+	if v := os.Getenv("WITHDRAWAL_APPROVAL_WINDOW"); v != "" {
+		// Spending counters keep one week
+		config.Window, err = time.ParseDuration(v)
+		if err != nil || config.Window <= 0 || config.Window > 7*24*time.Hour {
+			return config, fmt.Errorf("invalid WITHDRAWAL_APPROVAL_WINDOW %q", v)
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidAddress is returned for text that is not an address on the
+// expected network.
+var ErrInvalidAddress = errors.New("invalid address")
+
+// NormalizeAddress returns the canonical form of an address on network:
+// EIP-55 checksummed hex for Ethereum, the standard encoding for Bitcoin.
+// Equal addresses then compare equal as strings.
+func NormalizeAddress(network *Network, address string) (string, error) {
+	address = strings.TrimSpace(address)
+
+	switch network.Chain {
+	case ChainETH:
+		if !common.IsHexAddress(address) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidAddress, address)
+		}
+		return common.HexToAddress(address).Hex(), nil
+
+	case ChainBTC:
+		decoded, err := btcutil.DecodeAddress(address, network.Params)
+		if err != nil || !decoded.IsForNet(network.Params) {
+			return "", fmt.Errorf("%w: %q is not a %s %s address", ErrInvalidAddress, address, network.Chain, network.Name)
+		}
+		return decoded.EncodeAddress(), nil
+
+	default:
+		return "", fmt.Errorf("unsupported chain %q", network.Chain)
+	}
+}
@@ -0,0 +1,25 @@
+package crypto
+
+import "fmt"
+
+// Asset is a currency amounts are denominated in. Amounts are stored in
+// base units; Decimals is the number of base-unit digits in one whole unit.
+type Asset struct {
+	Symbol   string
+	Decimals uint8
+}
+
+var nativeAssets = map[string]Asset{
+	ChainETH: {Symbol: "ETH", Decimals: 18},
+	ChainBTC: {Symbol: "BTC", Decimals: 8},
+}
+
+// NativeAsset returns the currency a chain pays fees in: ether in wei,
+// bitcoin in satoshi.
+func NativeAsset(chain string) (Asset, error) {
+	asset, ok := nativeAssets[chain]
+	if !ok {
+		return Asset{}, fmt.Errorf("unsupported chain %q", chain)
+	}
+	return asset, nil
+}
@@ -0,0 +1,52 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// bitcoinAddress encodes the key's public key according to the path purpose:
+// BIP84 native SegWit (bech32), BIP49 P2SH-SegWit or BIP44 legacy P2PKH.
+func bitcoinAddress(
+	key *hdkeychain.ExtendedKey,
+	purpose uint32,
+	params *chaincfg.Params,
+) (string, error) {
+
+	pubKey, err := key.ECPubKey()
+	if err != nil {
+		return "", err
+	}
+	pubKeyHash := btcutil.Hash160(pubKey.SerializeCompressed())
+
+	switch purpose {
+	case PurposeBIP84:
+		addr, err := btcutil.NewAddressWitnessPubKeyHash(pubKeyHash, params)
+		if err != nil {
+			return "", err
+		}
+		return addr.EncodeAddress(), nil
+
+	case PurposeBIP49:
+		// redeemScript = OP_0 <20-byte pubkey hash>
+		redeemScript := append([]byte{0x00, 0x14}, pubKeyHash...)
+		addr, err := btcutil.NewAddressScriptHash(redeemScript, params)
+		if err != nil {
+			return "", err
+		}
+		return addr.EncodeAddress(), nil
+
+	case PurposeBIP44:
+		addr, err := btcutil.NewAddressPubKeyHash(pubKeyHash, params)
+		if err != nil {
+			return "", err
+		}
+		return addr.EncodeAddress(), nil
+
+	default:
+		return "", fmt.Errorf("unsupported bitcoin purpose %d'", purpose)
+	}
+}
@@ -0,0 +1,249 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrInvalidPSBT is returned when a PSBT cannot be parsed or signed.
+var ErrInvalidPSBT = errors.New("invalid psbt")
+
+// SignedPSBT is a PSBT after this wallet added its signatures.
+// RawTx and TxId are only set once the PSBT is finalized and every input
+// carries its final witness or scriptSig.
+type SignedPSBT struct {
+	PSBT         string
+	SignedInputs []int
+	Complete     bool
+	RawTx        string
+	TxId         string
+}
+
+// masterFingerprint returns the BIP32 fingerprint of the master key, as
+// used in PSBT derivation records: the first 4 bytes of HASH160 of the
+// master public key, read little-endian.
+func masterFingerprint(masterKey *hdkeychain.ExtendedKey) (uint32, error) {
+	pubKey, err := masterKey.ECPubKey()
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(btcutil.Hash160(pubKey.SerializeCompressed())[:4]), nil
+}
+
+// SignBitcoinPSBT signs every native SegWit (P2WPKH) input of a base64
+// BIP174 PSBT whose BIP32 derivation names this wallet's master fingerprint
+// and one of the given paths. Other inputs are left untouched, so external
+// coordinators can collect the remaining signatures. With finalize set,
+// inputs are finalized where possible and the raw transaction is extracted
+// once all of them are.
+func (c *CryptoServiceImpl) SignBitcoinPSBT(
+	mnemonic string,
+	seedPassphrase string,
+	packet string,
+	paths []DerivationPath,
+	finalize bool,
+) (*SignedPSBT, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(packet)), true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+	}
+
+	masterKey, err := newMasterKey(mnemonic, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, err := masterFingerprint(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		allowed[path.String()] = true
+	}
+
+	updater, err := psbt.NewUpdater(p)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+	}
+
+	tx := p.UnsignedTx
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, txIn := range tx.TxIn {
+		prevOut := inputUtxo(p, i)
+		if prevOut == nil {
+			prevOut = wire.NewTxOut(0, nil)
+		}
+		prevOuts.AddPrevOut(txIn.PreviousOutPoint, prevOut)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+
+	result := &SignedPSBT{SignedInputs: []int{}}
+	for i, input := range p.Inputs {
+		if len(input.FinalScriptWitness) > 0 || len(input.FinalScriptSig) > 0 {
+			continue
+		}
+
+		prevOut := inputUtxo(p, i)
+		if prevOut == nil || !txscript.IsPayToWitnessPubKeyHash(prevOut.PkScript) {
+			continue
+		}
+
+		for _, derivation := range input.Bip32Derivation {
+			if derivation.MasterKeyFingerprint != fingerprint {
+				continue
+			}
+
+			path, ok := pathFromBip32(derivation.Bip32Path)
+			if !ok || !allowed[path.String()] {
+				continue
+			}
+
+			addressKey, err := path.derive(masterKey)
+			if err != nil {
+				return nil, err
+			}
+			privKey, err := addressKey.ECPrivKey()
+			if err != nil {
+				return nil, err
+			}
+
+			pubKey := privKey.PubKey().SerializeCompressed()
+			if !bytes.Equal(pubKey, derivation.PubKey) ||
+				!bytes.Equal(prevOut.PkScript[2:], btcutil.Hash160(pubKey)) {
+				continue
+			}
+
+			hashType := input.SighashType
+			if hashType == 0 {
+				hashType = txscript.SigHashAll
+			}
+
+			sig, err := txscript.RawTxInWitnessSignature(
+				tx, sigHashes, i, prevOut.Value, prevOut.PkScript, hashType, privKey,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := updater.Sign(i, sig, pubKey, nil, nil); err != nil {
+				return nil, fmt.Errorf("%w: input %d: %v", ErrInvalidPSBT, i, err)
+			}
+			result.SignedInputs = append(result.SignedInputs, i)
+			break
+		}
+	}
+
+	if finalize {
+		for i := range p.Inputs {
+			_, err := psbt.MaybeFinalize(p, i)
+			if errors.Is(err, psbt.ErrNotFinalizable) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: input %d: %v", ErrInvalidPSBT, i, err)
+			}
+		}
+	}
+
+	result.Complete = p.IsComplete()
+	if result.Complete {
+		final, err := psbt.Extract(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+		}
+
+		var raw bytes.Buffer
+		if err := final.Serialize(&raw); err != nil {
+			return nil, err
+		}
+		result.RawTx = hex.EncodeToString(raw.Bytes())
+		result.TxId = final.TxHash().String()
+	}
+
+	if result.PSBT, err = p.B64Encode(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// TxOutput is an output of a Bitcoin transaction: Value satoshi paid to
+// Address.
+type TxOutput struct {
+	Address string
+	Value   int64
+}
+
+// PSBTOutputs returns the outputs of a base64 PSBT with the address each
+// pays to on network. Zero-value OP_RETURN outputs carry data, not funds,
+// and are skipped; any other output without a single address is rejected.
+func PSBTOutputs(packet string, network *Network) ([]TxOutput, error) {
+	p, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(packet)), true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPSBT, err)
+	}
+
+	outputs := make([]TxOutput, 0, len(p.UnsignedTx.TxOut))
+	for i, out := range p.UnsignedTx.TxOut {
+		class, addrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, network.Params)
+		if class == txscript.NullDataTy && out.Value == 0 {
+			continue
+		}
+		if err != nil || len(addrs) != 1 {
+			return nil, fmt.Errorf("%w: output %d does not pay to an address", ErrInvalidPSBT, i)
+		}
+		outputs = append(outputs, TxOutput{Address: addrs[0].EncodeAddress(), Value: out.Value})
+	}
+
+	return outputs, nil
+}
+
+// inputUtxo returns the output spent by input i, from either UTXO field.
+func inputUtxo(p *psbt.Packet, i int) *wire.TxOut {
+	input := p.Inputs[i]
+	if input.WitnessUtxo != nil {
+		return input.WitnessUtxo
+	}
+	if input.NonWitnessUtxo != nil {
+		outIndex := p.UnsignedTx.TxIn[i].PreviousOutPoint.Index
+		if int(outIndex) < len(input.NonWitnessUtxo.TxOut) {
+			return input.NonWitnessUtxo.TxOut[outIndex]
+		}
+	}
+	return nil
+}
+
+// pathFromBip32 converts a raw BIP32 path into a [DerivationPath]. Only
+// m/purpose'/coin_type'/account'/change/index paths are accepted.
+func pathFromBip32(levels []uint32) (DerivationPath, bool) {
+	if len(levels) != 5 {
+		return DerivationPath{}, false
+	}
+	for i, level := range levels {
+		hardened := level >= hdkeychain.HardenedKeyStart
+		if hardened != (i < 3) {
+			return DerivationPath{}, false
+		}
+	}
+
+	path := DerivationPath{
+		Purpose:  levels[0] - hdkeychain.HardenedKeyStart,
+		CoinType: levels[1] - hdkeychain.HardenedKeyStart,
+		Account:  levels[2] - hdkeychain.HardenedKeyStart,
+		Change:   levels[3],
+		Index:    levels[4],
+	}
+	return path, path.Validate() == nil
+}
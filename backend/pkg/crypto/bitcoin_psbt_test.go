@@ -0,0 +1,189 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignBitcoinPSBT(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	path := mustPath(t, "m/84'/0'/0'/0/0")
+	mine := fixtureInput(t, path, 50_000)
+
+	// 1️⃣ The wallet's input is signed and the transaction extracted
+	packet := newTestPSBT(t, mine)
+	signed, err := svc.SignBitcoinPSBT(fixtureMnemonic, "", packet, []DerivationPath{path}, true)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, signed.SignedInputs)
+	require.True(t, signed.Complete)
+	requireValidTx(t, signed.RawTx, signed.TxId, mine)
+
+	// 2️⃣ Without finalize the signature is only added to the PSBT
+	signed, err = svc.SignBitcoinPSBT(fixtureMnemonic, "", packet, []DerivationPath{path}, false)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, signed.SignedInputs)
+	require.False(t, signed.Complete)
+	require.Empty(t, signed.RawTx)
+
+	p, err := psbt.NewFromRawBytes(bytes.NewReader([]byte(signed.PSBT)), true)
+	require.NoError(t, err)
+	require.Len(t, p.Inputs[0].PartialSigs, 1)
+}
+
+func TestSignBitcoinPSBTLeavesOtherInputs(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	path := mustPath(t, "m/84'/0'/0'/0/0")
+
+	// Another signer's input, and one of ours at a path the wallet did
+	// not hand out
+	other, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	foreign := p2wpkhInput(t, other.PubKey().SerializeCompressed(), 1, path, 30_000)
+	unlisted := fixtureInput(t, mustPath(t, "m/84'/0'/0'/0/7"), 20_000)
+	mine := fixtureInput(t, path, 50_000)
+
+	signed, err := svc.SignBitcoinPSBT(fixtureMnemonic, "", newTestPSBT(t, foreign, unlisted, mine), []DerivationPath{path}, true)
+	require.NoError(t, err)
+	require.Equal(t, []int{2}, signed.SignedInputs)
+	require.False(t, signed.Complete)
+	require.Empty(t, signed.RawTx)
+
+	// Nothing of ours: nothing signed
+	signed, err = svc.SignBitcoinPSBT(fixtureMnemonic, "", newTestPSBT(t, foreign), []DerivationPath{path}, true)
+	require.NoError(t, err)
+	require.Empty(t, signed.SignedInputs)
+
+	_, err = svc.SignBitcoinPSBT(fixtureMnemonic, "", "not a psbt", []DerivationPath{path}, true)
+	require.ErrorIs(t, err, ErrInvalidPSBT)
+}
+
+func TestPSBTOutputs(t *testing.T) {
+	mainnet, err := LookupNetwork(ChainBTC, NetworkMainnet)
+	require.NoError(t, err)
+	packet := newTestPSBT(t, fixtureInput(t, mustPath(t, "m/84'/0'/0'/0/0"), 50_000))
+
+	outputs, err := PSBTOutputs(packet, mainnet)
+	require.NoError(t, err)
+	require.Equal(t, []TxOutput{{Address: "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", Value: 40_000}}, outputs)
+}
+
+// psbtInput is a P2WPKH output to spend, with the BIP32 derivation of its
+// key.
+type psbtInput struct {
+	prevOut    *wire.TxOut
+	derivation *psbt.Bip32Derivation
+}
+
+// fixtureInput is an output of value satoshi paid to the key of
+// fixtureMnemonic at path.
+func fixtureInput(t *testing.T, path DerivationPath, value int64) psbtInput {
+	t.Helper()
+
+	masterKey, err := newMasterKey(fixtureMnemonic, "")
+	require.NoError(t, err)
+	fingerprint, err := masterFingerprint(masterKey)
+	require.NoError(t, err)
+	key, err := path.derive(masterKey)
+	require.NoError(t, err)
+	pubKey, err := key.ECPubKey()
+	require.NoError(t, err)
+
+	return p2wpkhInput(t, pubKey.SerializeCompressed(), fingerprint, path, value)
+}
+
+func p2wpkhInput(t *testing.T, pubKey []byte, fingerprint uint32, path DerivationPath, value int64) psbtInput {
+	t.Helper()
+
+	pkScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(btcutil.Hash160(pubKey)).
+		Script()
+	require.NoError(t, err)
+
+	return psbtInput{
+		prevOut: wire.NewTxOut(value, pkScript),
+		derivation: &psbt.Bip32Derivation{
+			PubKey:               pubKey,
+			MasterKeyFingerprint: fingerprint,
+			Bip32Path: []uint32{
+				hdkeychain.HardenedKeyStart + path.Purpose,
+				hdkeychain.HardenedKeyStart + path.CoinType,
+				hdkeychain.HardenedKeyStart + path.Account,
+				path.Change,
+				path.Index,
+			},
+		},
+	}
+}
+
+// newTestPSBT spends inputs into one output to m/84'/0'/0'/0/1 of
+// fixtureMnemonic, leaving 10000 satoshi as fee.
+func newTestPSBT(t *testing.T, inputs ...psbtInput) string {
+	t.Helper()
+
+	outPoints := make([]*wire.OutPoint, 0, len(inputs))
+	sequences := make([]uint32, 0, len(inputs))
+	var total int64
+	for i, in := range inputs {
+		outPoints = append(outPoints, wire.NewOutPoint(&chainhash.Hash{byte(i + 1)}, uint32(i)))
+		sequences = append(sequences, wire.MaxTxInSequenceNum)
+		total += in.prevOut.Value
+	}
+
+	payee, err := btcutil.DecodeAddress("bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g", nil)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(payee)
+	require.NoError(t, err)
+
+	p, err := psbt.New(outPoints, []*wire.TxOut{wire.NewTxOut(total-10_000, pkScript)}, 2, 0, sequences)
+	require.NoError(t, err)
+	for i, in := range inputs {
+		p.Inputs[i].WitnessUtxo = in.prevOut
+		p.Inputs[i].Bip32Derivation = []*psbt.Bip32Derivation{in.derivation}
+	}
+
+	packet, err := p.B64Encode()
+	require.NoError(t, err)
+	return packet
+}
+
+// requireValidTx checks that the raw transaction spends inputs with
+// valid witnesses.
+func requireValidTx(t *testing.T, rawTx, txId string, inputs ...psbtInput) {
+	t.Helper()
+
+	raw, err := hex.DecodeString(rawTx)
+	require.NoError(t, err)
+	var tx wire.MsgTx
+	require.NoError(t, tx.Deserialize(bytes.NewReader(raw)))
+	require.Equal(t, txId, tx.TxHash().String())
+
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, in := range inputs {
+		prevOuts.AddPrevOut(tx.TxIn[i].PreviousOutPoint, in.prevOut)
+	}
+	sigHashes := txscript.NewTxSigHashes(&tx, prevOuts)
+	for i, in := range inputs {
+		engine, err := txscript.NewEngine(in.prevOut.PkScript, &tx, i, txscript.StandardVerifyFlags, nil, sigHashes, in.prevOut.Value, prevOuts)
+		require.NoError(t, err)
+		require.NoError(t, engine.Execute(), "input %d", i)
+	}
+}
+
+func mustPath(t *testing.T, s string) DerivationPath {
+	t.Helper()
+
+	path, err := ParseDerivationPath(s)
+	require.NoError(t, err)
+	return path
+}
@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Addresses of fixtureMnemonic from the BIP84, BIP49 and BIP44 test
+// vectors.
+func TestGenerateBitcoinAddress(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	mainnet, err := LookupNetwork(ChainBTC, NetworkMainnet)
+	require.NoError(t, err)
+
+	tests := []struct {
+		addressType string
+		change      uint32
+		index       uint32
+		path        string
+		address     string
+	}{
+		{"", 0, 0, "m/84'/0'/0'/0/0", "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{AddressTypeP2WPKH, 0, 1, "m/84'/0'/0'/0/1", "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+		{AddressTypeP2WPKH, 1, 0, "m/84'/0'/0'/1/0", "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"},
+		{AddressTypeP2SHP2WPKH, 0, 0, "m/49'/0'/0'/0/0", "37VucYSaXLCAsxYyAPfbSi9eh4iEcbShgf"},
+		{AddressTypeP2PKH, 0, 0, "m/44'/0'/0'/0/0", "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := PathFor(mainnet, tt.addressType, 0, tt.change, tt.index)
+			require.NoError(t, err)
+			require.Equal(t, tt.path, path.String())
+
+			address, err := svc.GenerateAddress(fixtureMnemonic, "", mainnet, path)
+			require.NoError(t, err)
+			require.Equal(t, tt.address, address)
+		})
+	}
+}
+
+func TestBitcoinPathRejectsUnknownAddressType(t *testing.T) {
+	_, err := BitcoinPath("p2tr", CoinTypeBTC, 0, 0, 0)
+	require.Error(t, err)
+
+	_, err = ParseDerivationPath("m/84'/0'/0'/0")
+	require.Error(t, err)
+}
@@ -0,0 +1,222 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Stored mnemonic ciphertext versions.
+//
+//   - v0: base64(nonce || AES-256-GCM), key = scrypt(passphrase, walletId)
+//     with N=32768, r=8, p=1 and no associated data.
+//   - v1: like v0, but the key is HMAC-SHA256(DEK, scrypt key) with the
+//     DEK wrapped by a KEK, and the wallet ID is associated data.
+//   - v2: self-describing "$v=2$cipher$kdf$params$salt$nonce$data" with a
+//     random salt. The header and the wallet ID are associated data.
+//
+// v0 and v1 are plain base64 and never start with "$".
+const (
+	cipherVersionLegacy   = 0
+	cipherVersionEnvelope = 1
+	cipherVersionCurrent  = 2
+)
+
+const (
+	cipherAES256GCM = "aes-256-gcm"
+
+	kdfArgon2id = "argon2id"
+	kdfScrypt   = "scrypt"
+)
+
+// kdfSaltSize is the length of the random per-record KDF salt in bytes.
+const kdfSaltSize = 16
+
+// kdfParams are the cost parameters of a passphrase KDF. Only the fields
+// of the named KDF are used.
+type kdfParams struct {
+	// argon2id
+	Memory  uint32 // KiB
+	Time    uint32
+	Threads uint8
+
+	// scrypt
+	N, R, P int
+}
+
+// defaultKDF and defaultKDFParams seal every new record. Raising them
+// makes older records eligible for re-encryption on their next unlock.
+const defaultKDF = kdfArgon2id
+
+var defaultKDFParams = kdfParams{Memory: 64 * 1024, Time: 3, Threads: 4}
+
+// legacyScryptParams are the costs hard-coded in v0 and v1 records.
+var legacyScryptParams = kdfParams{N: 32768, R: 8, P: 1}
+
+// sealedRecord is a parsed v2 ciphertext.
+type sealedRecord struct {
+	version int
+	cipher  string
+	kdf     string
+	params  kdfParams
+	salt    []byte
+	nonce   []byte
+	data    []byte
+}
+
+// header is the authenticated, non-secret prefix of the record.
+func (r *sealedRecord) header() string {
+	return fmt.Sprintf("$v=%d$%s$%s$%s", r.version, r.cipher, r.kdf, r.params.encode(r.kdf))
+}
+
+// associatedData binds the header and the owning wallet to the ciphertext.
+func (r *sealedRecord) associatedData(walletId string) []byte {
+	return []byte(r.header() + "$" + walletId)
+}
+
+func (r *sealedRecord) String() string {
+	enc := base64.RawStdEncoding
+	return strings.Join([]string{
+		r.header(),
+		enc.EncodeToString(r.salt),
+		enc.EncodeToString(r.nonce),
+		enc.EncodeToString(r.data),
+	}, "$")
+}
+
+// isVersioned reports whether a stored ciphertext uses the v2+ format.
+func isVersioned(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, "$")
+}
+
+// parseSealedRecord parses a v2 ciphertext.
+func parseSealedRecord(s string) (*sealedRecord, error) {
+	parts := strings.Split(s, "$")
+	if len(parts) != 8 || parts[0] != "" {
+		return nil, errors.New("malformed mnemonic ciphertext")
+	}
+
+	version, err := strconv.Atoi(strings.TrimPrefix(parts[1], "v="))
+	if err != nil || !strings.HasPrefix(parts[1], "v=") {
+		return nil, errors.New("malformed mnemonic ciphertext version")
+	}
+	if version != cipherVersionCurrent {
+		return nil, fmt.Errorf("unsupported mnemonic ciphertext version %d", version)
+	}
+
+	r := &sealedRecord{version: version, cipher: parts[2], kdf: parts[3]}
+	if r.cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported mnemonic cipher %q", r.cipher)
+	}
+
+	if r.params, err = parseKDFParams(r.kdf, parts[4]); err != nil {
+		return nil, err
+	}
+
+	enc := base64.RawStdEncoding
+	if r.salt, err = enc.DecodeString(parts[5]); err != nil {
+		return nil, err
+	}
+	if r.nonce, err = enc.DecodeString(parts[6]); err != nil {
+		return nil, err
+	}
+	if r.data, err = enc.DecodeString(parts[7]); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// encode renders the parameters of kdf as "k=v,k=v".
+func (p kdfParams) encode(kdf string) string {
+	switch kdf {
+	case kdfArgon2id:
+		return fmt.Sprintf("m=%d,t=%d,p=%d", p.Memory, p.Time, p.Threads)
+	case kdfScrypt:
+		return fmt.Sprintf("n=%d,r=%d,p=%d", p.N, p.R, p.P)
+	default:
+		return ""
+	}
+}
+
+// maxKDFParams caps the costs a stored record may ask for, at four times
+// defaultKDFParams and legacyScryptParams, so a tampered header cannot
+// make an unlock allocate gigabytes or spin for minutes.
+var maxKDFParams = kdfParams{
+	Memory: 4 * 64 * 1024, Time: 12, Threads: 16,
+	N: 4 * 32768, R: 32, P: 4,
+}
+
+// parseKDFParams parses and bounds the parameters of kdf. Records outside
+// maxKDFParams are rejected here, before any key is derived.
+func parseKDFParams(kdf, s string) (kdfParams, error) {
+	values := make(map[string]uint64)
+	for _, field := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return kdfParams{}, fmt.Errorf("malformed kdf parameter %q", field)
+		}
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || n == 0 {
+			return kdfParams{}, fmt.Errorf("malformed kdf parameter %q", field)
+		}
+		values[key] = n
+	}
+
+	var p kdfParams
+	switch kdf {
+	case kdfArgon2id:
+		m, t, threads := values["m"], values["t"], values["p"]
+		if m == 0 || t == 0 || threads == 0 {
+			return kdfParams{}, errors.New("incomplete argon2id parameters")
+		}
+		if m > uint64(maxKDFParams.Memory) || t > uint64(maxKDFParams.Time) || threads > uint64(maxKDFParams.Threads) {
+			return kdfParams{}, errors.New("argon2id parameters out of range")
+		}
+		p.Memory, p.Time, p.Threads = uint32(m), uint32(t), uint8(threads)
+	case kdfScrypt:
+		n, r, parallel := values["n"], values["r"], values["p"]
+		if n == 0 || r == 0 || parallel == 0 {
+			return kdfParams{}, errors.New("incomplete scrypt parameters")
+		}
+		if n > uint64(maxKDFParams.N) || r > uint64(maxKDFParams.R) || parallel > uint64(maxKDFParams.P) {
+			return kdfParams{}, errors.New("scrypt parameters out of range")
+		}
+		if n < 2 || n&(n-1) != 0 {
+			return kdfParams{}, errors.New("scrypt n must be a power of two")
+		}
+		p.N, p.R, p.P = int(n), int(r), int(parallel)
+	default:
+		return kdfParams{}, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+
+	return p, nil
+}
+
+// deriveKDFKey stretches the passphrase into a 256-bit key.
+func deriveKDFKey(kdf string, p kdfParams, passphrase string, salt []byte) ([]byte, error) {
+	switch kdf {
+	case kdfArgon2id:
+		return argon2.IDKey([]byte(passphrase), salt, p.Time, p.Memory, p.Threads, 32), nil
+	case kdfScrypt:
+		return scrypt.Key([]byte(passphrase), salt, p.N, p.R, p.P, 32)
+	default:
+		return nil, fmt.Errorf("unsupported kdf %q", kdf)
+	}
+}
+
+// weakerThanDefault reports whether a record's KDF falls short of the
+// current default, so it should be re-sealed on the next unlock.
+func weakerThanDefault(kdf string, p kdfParams) bool {
+	if kdf != defaultKDF {
+		return true
+	}
+	return p.Memory < defaultKDFParams.Memory ||
+		p.Time < defaultKDFParams.Time ||
+		p.Threads < defaultKDFParams.Threads
+}
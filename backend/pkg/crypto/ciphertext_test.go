@@ -0,0 +1,188 @@
+package crypto
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures sealed once with the code of each format and stored as they
+// would be in the database. They must keep opening after any change to
+// ciphertext.go; never regenerate them.
+const (
+	fixtureMnemonic   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	fixturePassphrase = "fixture passphrase"
+	fixtureWalletId   = "3f1c1a52-7d0e-4a8e-9d55-8a1f0f4b2c01"
+
+	// fixtureKeyring holds the KEK that wrapped the fixture DEK.
+	fixtureKeyring = `{
+  "primary": "kek-fixture",
+  "keys": [
+    {"id": "kek-fixture", "key": "WlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlpaWlo=", "createDate": "2024-01-01T00:00:00Z"}
+  ]
+}`
+	fixtureKeyId      = "kek-fixture"
+	fixtureWrappedKey = "a++5k5HtsdORqSXY3CzZzs9Itky7cGiwI9n7UTPk7XWwtT/Qo3RjG2MSN2c1rNS6lPb3G7/iroiNgZCS"
+)
+
+var ciphertextFixtures = []struct {
+	name           string
+	sealed         EncryptedMnemonic
+	needsReencrypt bool
+}{
+	{
+		name: "v0 scrypt, no envelope",
+		sealed: EncryptedMnemonic{
+			Ciphertext: "YCFJPv9GYH804xr77AdnvwhBb91+OC9TiM+/yc4JqvO9BpBr9MgPnLuV0AOpcSUyrRJ1N/7BoICLIHa56PWQ/whvhBSfJVtD3NNYit6Aj0VMtvYJW4oX7jiPpvvhh3jHvf6+dCybdKd+nwa4UYM/Iux4Tu1OE73qNg==",
+		},
+		needsReencrypt: true,
+	},
+	{
+		name: "v1 scrypt envelope",
+		sealed: EncryptedMnemonic{
+			Ciphertext: "QTRiGrzQvdR2mhqQDw5JR1I0iO6RMZ1V6siSmwycvv7qe5puQTenCznHUC1ms5RMaq2c3ipOp4JYfGJ0wT/c59UWCwlPlxY75HqWm0U/0O9bk0K7uJ4DrBbYojc1iZb4pqvKAW6E6C0wBeQKv1exR5UzgM0XZG6KGA==",
+			WrappedKey: fixtureWrappedKey,
+			KeyId:      fixtureKeyId,
+		},
+		needsReencrypt: true,
+	},
+	{
+		name: "v2 argon2id at default cost",
+		sealed: EncryptedMnemonic{
+			Ciphertext: "$v=2$aes-256-gcm$argon2id$m=65536,t=3,p=4$EREREREREREREREREREREQ$rkKDLlGVQB+aRwk8$6kQvxKy+8+hw2Wub9QDb6aLk+Bgg+ZprdYYqSEQlohbCLNRnHp51Ngr1LTzL8Tx2u9u4VnLhA070sDX7FIiFzRh6Jo7nTfzCMviWmjyJ8hDVDj3GNPQw2p4qd4nrSutvQlmjlTCCrzAiiHPbWg",
+			WrappedKey: fixtureWrappedKey,
+			KeyId:      fixtureKeyId,
+		},
+		needsReencrypt: false,
+	},
+	{
+		name: "v2 argon2id below default cost",
+		sealed: EncryptedMnemonic{
+			Ciphertext: "$v=2$aes-256-gcm$argon2id$m=19456,t=2,p=1$EREREREREREREREREREREQ$lYOuneUeoJ+z6aLo$0SyRMjueihGbZAj7bfAth2goWHCI/KitEB5lYOzRsP+04ffSt10HiRo1MRcZLQBFPL6L9ygKbknJhxil5Ge27wsASJytA6DQO4kto54oVCqIETDmvJKNuKAnI+yosrxOpoLRRQOFMFUwgtFxAg",
+			WrappedKey: fixtureWrappedKey,
+			KeyId:      fixtureKeyId,
+		},
+		needsReencrypt: true,
+	},
+	{
+		name: "v2 scrypt",
+		sealed: EncryptedMnemonic{
+			Ciphertext: "$v=2$aes-256-gcm$scrypt$n=16384,r=8,p=1$EREREREREREREREREREREQ$KaXrMewH3ITeli4w$qQq2uSmSLq0ALjo6obqBiR58N23biERv2sp+AML+5mPOidyXg//R2es2rfRdU9HFm4vgTvBtZtYs9ZNS1PCRWo74MsnDdVGcRQtaZ+1bH5bpMqWCwSFFNHE8BBZ+mIfjkjUiRIs0FTbM7Yvv0Q",
+			WrappedKey: fixtureWrappedKey,
+			KeyId:      fixtureKeyId,
+		},
+		needsReencrypt: true,
+	},
+}
+
+func fixtureCryptoService(t *testing.T) Service {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, []byte(fixtureKeyring), 0o600))
+	keyring, err := LoadKeyring(path)
+	require.NoError(t, err)
+
+	return newTestCryptoService(t, keyring)
+}
+
+func TestDecryptMnemonicFixtures(t *testing.T) {
+	ctx := context.Background()
+	svc := fixtureCryptoService(t)
+
+	for _, tt := range ciphertextFixtures {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed := tt.sealed
+
+			plain, err := svc.DecryptMnemonic(ctx, &sealed, fixturePassphrase, fixtureWalletId)
+			require.NoError(t, err)
+			require.Equal(t, fixtureMnemonic, plain)
+
+			_, err = svc.DecryptMnemonic(ctx, &sealed, "wrong passphrase", fixtureWalletId)
+			require.Error(t, err)
+
+			_, err = svc.DecryptMnemonic(ctx, &sealed, fixturePassphrase, "another-wallet")
+			require.Error(t, err)
+
+			require.Equal(t, tt.needsReencrypt, svc.NeedsReencrypt(&sealed))
+		})
+	}
+}
+
+func TestEncryptMnemonicRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	svc := fixtureCryptoService(t)
+
+	sealed, err := svc.EncryptMnemonic(ctx, fixtureMnemonic, fixturePassphrase, fixtureWalletId)
+	require.NoError(t, err)
+	require.Equal(t, fixtureKeyId, sealed.KeyId)
+	require.Regexp(t, `^\$v=2\$aes-256-gcm\$argon2id\$m=65536,t=3,p=4\$`, sealed.Ciphertext)
+	require.False(t, svc.NeedsReencrypt(sealed))
+
+	plain, err := svc.DecryptMnemonic(ctx, sealed, fixturePassphrase, fixtureWalletId)
+	require.NoError(t, err)
+	require.Equal(t, fixtureMnemonic, plain)
+}
+
+func TestParseSealedRecordRejectsMalformed(t *testing.T) {
+	valid := ciphertextFixtures[2].sealed.Ciphertext
+
+	tests := []struct {
+		name       string
+		ciphertext string
+	}{
+		{"too few fields", "$v=2$aes-256-gcm$argon2id$m=65536,t=3,p=4$salt"},
+		{"unknown version", "$v=3" + valid[len("$v=2"):]},
+		{"unknown cipher", "$v=2$chacha20$argon2id$m=65536,t=3,p=4$EREREREREREREREREREREQ$AA$AA"},
+		{"unknown kdf", "$v=2$aes-256-gcm$pbkdf2$i=1000$EREREREREREREREREREREQ$AA$AA"},
+		{"incomplete kdf parameters", "$v=2$aes-256-gcm$argon2id$m=65536,t=3$EREREREREREREREREREREQ$AA$AA"},
+		{"bad salt encoding", "$v=2$aes-256-gcm$argon2id$m=65536,t=3,p=4$!!$AA$AA"},
+		{"negative kdf parameter", "$v=2$aes-256-gcm$argon2id$m=-1,t=3,p=4$EREREREREREREREREREREQ$AA$AA"},
+		{"argon2id memory past uint32", "$v=2$aes-256-gcm$argon2id$m=4294967297,t=3,p=4$EREREREREREREREREREREQ$AA$AA"},
+		{"argon2id memory too high", "$v=2$aes-256-gcm$argon2id$m=262145,t=3,p=4$EREREREREREREREREREREQ$AA$AA"},
+		{"argon2id time too high", "$v=2$aes-256-gcm$argon2id$m=65536,t=13,p=4$EREREREREREREREREREREQ$AA$AA"},
+		{"argon2id parallelism too high", "$v=2$aes-256-gcm$argon2id$m=65536,t=3,p=256$EREREREREREREREREREREQ$AA$AA"},
+		{"scrypt n too high", "$v=2$aes-256-gcm$scrypt$n=262144,r=8,p=1$EREREREREREREREREREREQ$AA$AA"},
+		{"scrypt n not a power of two", "$v=2$aes-256-gcm$scrypt$n=16383,r=8,p=1$EREREREREREREREREREREQ$AA$AA"},
+		{"scrypt n of one", "$v=2$aes-256-gcm$scrypt$n=1,r=8,p=1$EREREREREREREREREREREQ$AA$AA"},
+		{"scrypt r too high", "$v=2$aes-256-gcm$scrypt$n=16384,r=33,p=1$EREREREREREREREREREREQ$AA$AA"},
+		{"scrypt p too high", "$v=2$aes-256-gcm$scrypt$n=16384,r=8,p=5$EREREREREREREREREREREQ$AA$AA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSealedRecord(tt.ciphertext)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseSealedRecordAcceptsBounds(t *testing.T) {
+	for _, params := range []string{
+		"argon2id$m=262144,t=12,p=16",
+		"scrypt$n=131072,r=32,p=4",
+		"scrypt$n=2,r=1,p=1",
+	} {
+		record, err := parseSealedRecord("$v=2$aes-256-gcm$" + params + "$EREREREREREREREREREREQ$AA$AA")
+		require.NoError(t, err, params)
+		require.Equal(t, params, record.kdf+"$"+record.params.encode(record.kdf))
+	}
+}
+
+func TestDecryptMnemonicRejectsCostlyParamsEarly(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	sealed := ciphertextFixtures[2].sealed
+	sealed.Ciphertext = strings.Replace(sealed.Ciphertext, "m=65536", "m=4194304", 1)
+
+	// Fails on the header; deriving a 4 GiB argon2id key would not return
+	// this quickly
+	start := time.Now()
+	_, err := svc.DecryptMnemonic(context.Background(), &sealed, fixturePassphrase, fixtureWalletId)
+	require.ErrorContains(t, err, "out of range")
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -1,14 +1,16 @@
 package crypto
 
+import "context"
+
 type Service interface {
-	// 1. Sinh mnemonic (BIP39)
-	GenerateMnemonic() (string, error)
+	// 1. Sinh mnemonic (BIP39) theo số từ và ngôn ngữ wordlist
+	GenerateMnemonic(wordCount int, language string) (string, error)
 
-	// 2. Mã hóa mnemonic bằng passphrase + walletId
-	EncryptMnemonic(mnemonic, passphrase, walletId string) (string, error)
+	// 2. Mã hóa mnemonic (envelope: DEK ngẫu nhiên + passphrase qua Argon2id, DEK được KEK bọc lại)
+	EncryptMnemonic(ctx context.Context, mnemonic, passphrase, walletId string) (*EncryptedMnemonic, error)
 
-	// 3. Giải mã mnemonic
-	DecryptMnemonic(cipher, passphrase, walletId string) (string, error)
+	// 3. Giải mã mnemonic (hỗ trợ mọi phiên bản ciphertext đã từng lưu)
+	DecryptMnemonic(ctx context.Context, sealed *EncryptedMnemonic, passphrase, walletId string) (string, error)
 
 	// 4. Hash passphrase để lưu DB
 	HashPassphrase(pass string) (string, error)
@@ -16,6 +18,34 @@ type Service interface {
 	// 5. Verify passphrase khi unlock
 	VerifyPassphrase(hash, pass string) bool
 
-	// 6. Sinh address từ mnemonic (HD wallet)
-	GenerateAddress(mnemonic string) (string, error)
+	// 6. Sinh address từ mnemonic + BIP39 seed passphrase theo network và derivation path (HD wallet)
+	GenerateAddress(mnemonic, seedPassphrase string, network *Network, path DerivationPath) (string, error)
+
+	// 7. Fingerprint không thể đảo ngược của ví (keyed hash của BIP32 master public key)
+	Fingerprint(mnemonic, seedPassphrase string) (string, error)
+
+	// 8. Nhận diện ngôn ngữ wordlist của mnemonic (kiểm tra cả checksum).
+	// Lỗi trả về là *MnemonicError với mã lỗi và gợi ý từ gần nhất
+	DetectLanguage(mnemonic string) (string, error)
+
+	// 9. Bọc lại DEK bằng KEK hiện tại (dùng khi xoay vòng KEK)
+	RewrapMnemonic(ctx context.Context, sealed *EncryptedMnemonic) (*EncryptedMnemonic, error)
+
+	// 10. Id của KEK đang dùng để bọc DEK mới
+	CurrentKEKId() string
+
+	// 11. Ciphertext cũ (phiên bản hoặc tham số KDF lỗi thời) cần mã hóa lại khi unlock
+	NeedsReencrypt(sealed *EncryptedMnemonic) bool
+
+	// 12. Ký giao dịch Ethereum (legacy hoặc EIP-1559) bằng key tại derivation path, không broadcast
+	SignEthereumTx(mnemonic, seedPassphrase string, path DerivationPath, tx *EthereumTx) (*SignedEthereumTx, error)
+
+	// 13. Ký hash 32 byte (EIP-191 personal_sign, EIP-712 typed data) bằng key tại derivation path
+	SignHash(mnemonic, seedPassphrase string, path DerivationPath, hash []byte) (*MessageSignature, error)
+
+	// 14. Ký các input P2WPKH của PSBT (BIP174) thuộc các derivation path cho trước
+	SignBitcoinPSBT(mnemonic, seedPassphrase, packet string, paths []DerivationPath, finalize bool) (*SignedPSBT, error)
+
+	// 15. Fingerprint không thể đảo ngược của extended public key (ví watch-only)
+	ExtendedKeyFingerprint(key *ExtendedPublicKey) (string, error)
 }
@@ -1,11 +1,14 @@
 package crypto
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
+	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
@@ -13,98 +16,276 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/bcrypt"
-	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
 )
 
-type CryptoServiceImpl struct{}
+// Config holds the secrets used by the crypto service.
+type Config struct {
+	// FingerprintKey is the HMAC key used to fingerprint wallets.
+	FingerprintKey []byte
+
+	// KEK wraps the per-wallet data encryption keys.
+	KEK KEKProvider
+}
+
+// dekSize is the length of a per-wallet data encryption key in bytes.
+const dekSize = 32
+
+// EncryptedMnemonic is a mnemonic sealed with envelope encryption.
+type EncryptedMnemonic struct {
+	// Ciphertext is the stored record in one of the versions listed
+	// in ciphertext.go.
+	Ciphertext string
+	// WrappedKey is the base64 DEK wrapped by the KEK; empty for
+	// mnemonics sealed before envelope encryption.
+	WrappedKey string
+	// KeyId names the KEK that wrapped the DEK.
+	KeyId string
+}
+
+type CryptoServiceImpl struct {
+	fingerprintKey []byte
+	kek            KEKProvider
+}
 
 // =======================
 // MNEMONIC
 // =======================
 
-func (c *CryptoServiceImpl) GenerateMnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(128) // 12 words
-	if err != nil {
-		return "", err
-	}
-	return bip39.NewMnemonic(entropy)
+func (c *CryptoServiceImpl) GenerateMnemonic(
+	wordCount int,
+	language string,
+) (string, error) {
+	return newMnemonic(wordCount, language)
+}
+
+func (c *CryptoServiceImpl) DetectLanguage(mnemonic string) (string, error) {
+	return detectLanguage(mnemonic)
 }
 
 // =======================
 // ENCRYPT / DECRYPT
 // =======================
 
+// EncryptMnemonic seals the mnemonic under a fresh random DEK mixed with
+// the passphrase-derived key, and wraps the DEK with the current KEK.
+// Without the KEK a database dump alone no longer opens any wallet.
+// The result is a v2 record using the default KDF.
 func (c *CryptoServiceImpl) EncryptMnemonic(
+	ctx context.Context,
 	mnemonic,
 	passphrase,
 	walletId string,
-) (string, error) {
+) (*EncryptedMnemonic, error) {
 
-	key, err := deriveKey(passphrase, walletId)
-	if err != nil {
-		return "", err
+	dek := make([]byte, dekSize)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return nil, err
 	}
 
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", err
+	record := &sealedRecord{
+		version: cipherVersionCurrent,
+		cipher:  cipherAES256GCM,
+		kdf:     defaultKDF,
+		params:  defaultKDFParams,
+		salt:    make([]byte, kdfSaltSize),
+	}
+	if _, err := io.ReadFull(rand.Reader, record.salt); err != nil {
+		return nil, err
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	passKey, err := deriveKDFKey(record.kdf, record.params, passphrase, record.salt)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", err
+	payload, err := sealAESGCM(envelopeKey(dek, passKey), []byte(mnemonic), record.associatedData(walletId))
+	if err != nil {
+		return nil, err
 	}
+	record.nonce, record.data = payload[:gcmNonceSize], payload[gcmNonceSize:]
 
-	cipherData := gcm.Seal(nil, nonce, []byte(mnemonic), nil)
-	payload := append(nonce, cipherData...)
+	keyId, wrapped, err := c.kek.Wrap(ctx, dek)
+	if err != nil {
+		return nil, err
+	}
 
-	return base64.StdEncoding.EncodeToString(payload), nil
+	return &EncryptedMnemonic{
+		Ciphertext: record.String(),
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+		KeyId:      keyId,
+	}, nil
 }
 
+// DecryptMnemonic opens every ciphertext version ever written.
 func (c *CryptoServiceImpl) DecryptMnemonic(
-	cipherText,
+	ctx context.Context,
+	sealed *EncryptedMnemonic,
 	passphrase,
 	walletId string,
 ) (string, error) {
 
-	raw, err := base64.StdEncoding.DecodeString(cipherText)
+	var (
+		plain []byte
+		err   error
+	)
+
+	switch cipherVersion(sealed) {
+	case cipherVersionLegacy:
+		plain, err = c.openLegacy(sealed, passphrase, walletId)
+	case cipherVersionEnvelope:
+		plain, err = c.openEnvelope(ctx, sealed, passphrase, walletId)
+	default:
+		plain, err = c.openRecord(ctx, sealed, passphrase, walletId)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	key, err := deriveKey(passphrase, walletId)
+	return string(plain), nil
+}
+
+// NeedsReencrypt reports whether the record predates the current format
+// or KDF cost, so it should be sealed again while the passphrase is known.
+func (c *CryptoServiceImpl) NeedsReencrypt(sealed *EncryptedMnemonic) bool {
+	if cipherVersion(sealed) != cipherVersionCurrent {
+		return true
+	}
+
+	record, err := parseSealedRecord(sealed.Ciphertext)
 	if err != nil {
-		return "", err
+		return false
 	}
+	return weakerThanDefault(record.kdf, record.params)
+}
 
-	block, err := aes.NewCipher(key)
+// cipherVersion tells the stored formats apart.
+func cipherVersion(sealed *EncryptedMnemonic) int {
+	switch {
+	case isVersioned(sealed.Ciphertext):
+		return cipherVersionCurrent
+	case sealed.WrappedKey != "":
+		return cipherVersionEnvelope
+	default:
+		return cipherVersionLegacy
+	}
+}
+
+// openLegacy opens a v0 record.
+func (c *CryptoServiceImpl) openLegacy(
+	sealed *EncryptedMnemonic,
+	passphrase,
+	walletId string,
+) ([]byte, error) {
+
+	raw, err := base64.StdEncoding.DecodeString(sealed.Ciphertext)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	key, err := deriveKDFKey(kdfScrypt, legacyScryptParams, passphrase, []byte(walletId))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if len(raw) < gcm.NonceSize() {
-		return "", errors.New("ciphertext too short")
+	return openAESGCM(key, raw, nil)
+}
+
+// openEnvelope opens a v1 record.
+func (c *CryptoServiceImpl) openEnvelope(
+	ctx context.Context,
+	sealed *EncryptedMnemonic,
+	passphrase,
+	walletId string,
+) ([]byte, error) {
+
+	raw, err := base64.StdEncoding.DecodeString(sealed.Ciphertext)
+	if err != nil {
+		return nil, err
 	}
 
-	nonce := raw[:gcm.NonceSize()]
-	cipherData := raw[gcm.NonceSize():]
+	dek, err := c.unwrapKey(ctx, sealed)
+	if err != nil {
+		return nil, err
+	}
 
-	plain, err := gcm.Open(nil, nonce, cipherData, nil)
+	passKey, err := deriveKDFKey(kdfScrypt, legacyScryptParams, passphrase, []byte(walletId))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return string(plain), nil
+	return openAESGCM(envelopeKey(dek, passKey), raw, []byte(walletId))
+}
+
+// openRecord opens a v2 record.
+func (c *CryptoServiceImpl) openRecord(
+	ctx context.Context,
+	sealed *EncryptedMnemonic,
+	passphrase,
+	walletId string,
+) ([]byte, error) {
+
+	record, err := parseSealedRecord(sealed.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	dek, err := c.unwrapKey(ctx, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	passKey, err := deriveKDFKey(record.kdf, record.params, passphrase, record.salt)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := append(append([]byte{}, record.nonce...), record.data...)
+	return openAESGCM(envelopeKey(dek, passKey), payload, record.associatedData(walletId))
+}
+
+// RewrapMnemonic re-wraps the DEK under the current KEK. The mnemonic
+// ciphertext is untouched, so no passphrase is needed.
+func (c *CryptoServiceImpl) RewrapMnemonic(
+	ctx context.Context,
+	sealed *EncryptedMnemonic,
+) (*EncryptedMnemonic, error) {
+
+	if cipherVersion(sealed) == cipherVersionLegacy {
+		return nil, errors.New("mnemonic is not envelope encrypted")
+	}
+
+	dek, err := c.unwrapKey(ctx, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	keyId, wrapped, err := c.kek.Wrap(ctx, dek)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedMnemonic{
+		Ciphertext: sealed.Ciphertext,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+		KeyId:      keyId,
+	}, nil
+}
+
+func (c *CryptoServiceImpl) CurrentKEKId() string {
+	return c.kek.CurrentKeyID()
+}
+
+func (c *CryptoServiceImpl) unwrapKey(
+	ctx context.Context,
+	sealed *EncryptedMnemonic,
+) ([]byte, error) {
+
+	wrapped, err := base64.StdEncoding.DecodeString(sealed.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return c.kek.Unwrap(ctx, sealed.KeyId, wrapped)
 }
 
 // =======================
@@ -121,77 +302,118 @@ func (c *CryptoServiceImpl) VerifyPassphrase(hash, pass string) bool {
 }
 
 // =======================
-// ADDRESS (BIP44 ETH)
+// ADDRESS (BIP44 ETH, BIP44/49/84 BTC)
 // =======================
 
-func (c *CryptoServiceImpl) GenerateAddress(mnemonic string) (string, error) {
-	seed := bip39.NewSeed(mnemonic, "")
-
-	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+func (c *CryptoServiceImpl) GenerateAddress(
+	mnemonic string,
+	seedPassphrase string,
+	network *Network,
+	path DerivationPath,
+) (string, error) {
+	masterKey, err := newMasterKey(mnemonic, seedPassphrase)
 	if err != nil {
 		return "", err
 	}
 
-	// m/44'
-	purpose, err := masterKey.Derive(hdkeychain.HardenedKeyStart + 44)
+	// m/purpose'/coin_type'/account'/change/index
+	addressKey, err := path.derive(masterKey)
 	if err != nil {
 		return "", err
 	}
 
-	// m/44'/60'
-	coinType, err := purpose.Derive(hdkeychain.HardenedKeyStart + 60)
-	if err != nil {
-		return "", err
-	}
+	return encodeAddress(addressKey, network, path.Purpose)
+}
 
-	// m/44'/60'/0'
-	account, err := coinType.Derive(hdkeychain.HardenedKeyStart + 0)
-	if err != nil {
-		return "", err
+// encodeAddress returns the address of the key's public key on network.
+func encodeAddress(key *hdkeychain.ExtendedKey, network *Network, purpose uint32) (string, error) {
+	switch network.Chain {
+	case ChainETH:
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return "", err
+		}
+		address := crypto.PubkeyToAddress(*pubKey.ToECDSA())
+		return address.Hex(), nil
+
+	case ChainBTC:
+		return bitcoinAddress(key, purpose, network.Params)
+
+	default:
+		return "", fmt.Errorf("unsupported chain %q", network.Chain)
 	}
+}
 
-	// m/44'/60'/0'/0
-	change, err := account.Derive(0)
-	if err != nil {
-		return "", err
-	}
+// =======================
+// FINGERPRINT
+// =======================
 
-	// m/44'/60'/0'/0/0
-	addressKey, err := change.Derive(0)
+// Fingerprint returns HMAC-SHA256(key, master pubkey || chain code) as hex.
+// It identifies the seed deterministically without revealing any key material.
+// The BIP39 seed passphrase takes part in seed derivation, so the same
+// mnemonic with a different seed passphrase yields a different fingerprint.
+func (c *CryptoServiceImpl) Fingerprint(mnemonic, seedPassphrase string) (string, error) {
+	masterKey, err := newMasterKey(mnemonic, seedPassphrase)
 	if err != nil {
 		return "", err
 	}
 
-	privKey, err := addressKey.ECPrivKey()
+	return c.keyFingerprint(masterKey)
+}
+
+// ExtendedKeyFingerprint identifies an imported extended public key the
+// same way. It hashes an account key rather than a master key, so it never
+// matches the fingerprint of the seed the account comes from.
+func (c *CryptoServiceImpl) ExtendedKeyFingerprint(key *ExtendedPublicKey) (string, error) {
+	return c.keyFingerprint(key.key)
+}
+
+func (c *CryptoServiceImpl) keyFingerprint(key *hdkeychain.ExtendedKey) (string, error) {
+	pubKey, err := key.ECPubKey()
 	if err != nil {
 		return "", err
 	}
 
-	ecdsaKey := privKey.ToECDSA()
-	address := crypto.PubkeyToAddress(ecdsaKey.PublicKey)
+	mac := hmac.New(sha256.New, c.fingerprintKey)
+	mac.Write(pubKey.SerializeCompressed())
+	mac.Write(key.ChainCode())
 
-	return address.Hex(), nil
+	return hex.EncodeToString(mac.Sum(nil)), nil
 }
 
 // =======================
 // INTERNAL
 // =======================
 
-func deriveKey(passphrase, walletId string) ([]byte, error) {
-	return scrypt.Key(
-		[]byte(passphrase),
-		[]byte(walletId),
-		32768, // N
-		8,     // r
-		1,     // p
-		32,    // 256-bit
-	)
+// newMasterKey derives the BIP32 master key from the BIP39 seed. The seed
+// passphrase (the "25th word") is NFKD-normalized as BIP39 requires; it is
+// unrelated to the passphrase that encrypts the mnemonic at rest.
+func newMasterKey(mnemonic, seedPassphrase string) (*hdkeychain.ExtendedKey, error) {
+	seed := bip39.NewSeed(NormalizeMnemonic(mnemonic), norm.NFKD.String(seedPassphrase))
+	return hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+}
+
+// envelopeKey binds the DEK to the passphrase: opening the mnemonic needs
+// both the KEK (to unwrap the DEK) and the passphrase.
+func envelopeKey(dek, passKey []byte) []byte {
+	mac := hmac.New(sha256.New, dek)
+	mac.Write(passKey)
+	return mac.Sum(nil)
 }
 
 // =======================
 // CONSTRUCTOR
 // =======================
 
-func NewCryptoService() Service {
-	return &CryptoServiceImpl{}
+func NewCryptoService(cfg Config) (Service, error) {
+	if len(cfg.FingerprintKey) == 0 {
+		return nil, errors.New("wallet fingerprint key is not configured")
+	}
+	if cfg.KEK == nil || cfg.KEK.CurrentKeyID() == "" {
+		return nil, errors.New("wallet key encryption key is not configured")
+	}
+	return &CryptoServiceImpl{
+		fingerprintKey: cfg.FingerprintKey,
+		kek:            cfg.KEK,
+	}, nil
 }
@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprintIsKeyedAndSeedBound(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	other, err := NewCryptoService(Config{FingerprintKey: []byte("another-key"), KEK: svc.(*CryptoServiceImpl).kek})
+	require.NoError(t, err)
+
+	fingerprint, err := svc.Fingerprint(fixtureMnemonic, "")
+	require.NoError(t, err)
+	require.Len(t, fingerprint, 64)
+
+	// Stable across calls and phrase spacing
+	again, err := svc.Fingerprint("  "+fixtureMnemonic+" ", "")
+	require.NoError(t, err)
+	require.Equal(t, fingerprint, again)
+
+	// Bound to the seed passphrase and to the fingerprint key
+	withSeed, err := svc.Fingerprint(fixtureMnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.NotEqual(t, fingerprint, withSeed)
+
+	keyed, err := other.Fingerprint(fixtureMnemonic, "")
+	require.NoError(t, err)
+	require.NotEqual(t, fingerprint, keyed)
+}
+
+// The BIP39 test vector for fixtureMnemonic with the seed passphrase
+// "TREZOR".
+func TestSeedPassphraseDerivesAnotherMasterKey(t *testing.T) {
+	withSeed, err := newMasterKey(fixtureMnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, "xprv9s21ZrQH143K3h3fDYiay8mocZ3afhfULfb5GX8kCBdno77K4HiA15Tg23wpbeF1pLfs1c5SPmYHrEpTuuRhxMwvKDwqdKiGJS9XFKzUsAF", withSeed.String())
+
+	svc := fixtureCryptoService(t)
+	mainnet, err := LookupNetwork(ChainETH, NetworkMainnet)
+	require.NoError(t, err)
+	path := EthereumPath(0, 0, 0)
+
+	plain, err := svc.GenerateAddress(fixtureMnemonic, "", mainnet, path)
+	require.NoError(t, err)
+	seeded, err := svc.GenerateAddress(fixtureMnemonic, "TREZOR", mainnet, path)
+	require.NoError(t, err)
+	require.NotEqual(t, plain, seeded)
+}
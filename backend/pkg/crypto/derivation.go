@@ -0,0 +1,159 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+const (
+	// ChainETH identifies Ethereum addresses.
+	ChainETH = "ETH"
+
+	// ChainBTC identifies Bitcoin addresses.
+	ChainBTC = "BTC"
+
+	// PurposeBIP44 is the BIP44 purpose level (legacy P2PKH for Bitcoin).
+	PurposeBIP44 uint32 = 44
+
+	// PurposeBIP49 is the BIP49 purpose level (P2SH-wrapped SegWit).
+	PurposeBIP49 uint32 = 49
+
+	// PurposeBIP84 is the BIP84 purpose level (native SegWit).
+	PurposeBIP84 uint32 = 84
+
+	// CoinTypeBTC is the SLIP-44 coin type for Bitcoin.
+	CoinTypeBTC uint32 = 0
+
+	// CoinTypeETH is the SLIP-44 coin type for Ethereum.
+	CoinTypeETH uint32 = 60
+)
+
+// Bitcoin address types, each bound to its BIP purpose.
+const (
+	AddressTypeP2PKH      = "p2pkh"
+	AddressTypeP2SHP2WPKH = "p2sh-p2wpkh"
+	AddressTypeP2WPKH     = "p2wpkh"
+)
+
+var bitcoinPurposes = map[string]uint32{
+	AddressTypeP2PKH:      PurposeBIP44,
+	AddressTypeP2SHP2WPKH: PurposeBIP49,
+	AddressTypeP2WPKH:     PurposeBIP84,
+}
+
+// DerivationPath describes a BIP44 path m/purpose'/coin_type'/account'/change/index.
+type DerivationPath struct {
+	Purpose  uint32
+	CoinType uint32
+	Account  uint32
+	Change   uint32
+	Index    uint32
+}
+
+// EthereumPath returns the BIP44 Ethereum path m/44'/60'/account'/change/index.
+func EthereumPath(account, change, index uint32) DerivationPath {
+	return DerivationPath{
+		Purpose:  PurposeBIP44,
+		CoinType: CoinTypeETH,
+		Account:  account,
+		Change:   change,
+		Index:    index,
+	}
+}
+
+// BitcoinPath returns m/purpose'/coin_type'/account'/change/index, where the purpose
+// follows from the address type. An empty type defaults to BIP84 native SegWit.
+func BitcoinPath(addressType string, coinType, account, change, index uint32) (DerivationPath, error) {
+	if addressType == "" {
+		addressType = AddressTypeP2WPKH
+	}
+
+	purpose, ok := bitcoinPurposes[addressType]
+	if !ok {
+		return DerivationPath{}, fmt.Errorf("unsupported bitcoin address type %q", addressType)
+	}
+
+	return DerivationPath{
+		Purpose:  purpose,
+		CoinType: coinType,
+		Account:  account,
+		Change:   change,
+		Index:    index,
+	}, nil
+}
+
+// PathFor returns the derivation path for an address on the given network.
+// The address type is only meaningful for Bitcoin.
+func PathFor(network *Network, addressType string, account, change, index uint32) (DerivationPath, error) {
+	switch network.Chain {
+	case ChainETH:
+		return EthereumPath(account, change, index), nil
+	case ChainBTC:
+		return BitcoinPath(addressType, network.CoinType(), account, change, index)
+	default:
+		return DerivationPath{}, fmt.Errorf("unsupported chain %q", network.Chain)
+	}
+}
+
+// String formats the path in the usual m/44'/60'/0'/0/0 notation.
+func (p DerivationPath) String() string {
+	return fmt.Sprintf("m/%d'/%d'/%d'/%d/%d", p.Purpose, p.CoinType, p.Account, p.Change, p.Index)
+}
+
+// ParseDerivationPath parses the m/purpose'/coin_type'/account'/change/index
+// notation produced by [DerivationPath.String].
+func ParseDerivationPath(s string) (DerivationPath, error) {
+	var p DerivationPath
+	var rest string
+	n, _ := fmt.Sscanf(s, "m/%d'/%d'/%d'/%d/%d%s", &p.Purpose, &p.CoinType, &p.Account, &p.Change, &p.Index, &rest)
+	if n != 5 || p.String() != s {
+		return DerivationPath{}, fmt.Errorf("invalid derivation path %q", s)
+	}
+	if err := p.Validate(); err != nil {
+		return DerivationPath{}, err
+	}
+	return p, nil
+}
+
+// Validate checks that every level fits in its hardened / non-hardened range.
+func (p DerivationPath) Validate() error {
+	for _, hardened := range []uint32{p.Purpose, p.CoinType, p.Account} {
+		if hardened >= hdkeychain.HardenedKeyStart {
+			return fmt.Errorf("invalid derivation path %s: hardened level out of range", p)
+		}
+	}
+	if p.Change > 1 {
+		return fmt.Errorf("invalid derivation path %s: change must be 0 or 1", p)
+	}
+	if p.Index >= hdkeychain.HardenedKeyStart {
+		return fmt.Errorf("invalid derivation path %s: index out of range", p)
+	}
+	return nil
+}
+
+// derive walks the path from the master key down to the address key.
+func (p DerivationPath) derive(masterKey *hdkeychain.ExtendedKey) (*hdkeychain.ExtendedKey, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	levels := []uint32{
+		hdkeychain.HardenedKeyStart + p.Purpose,
+		hdkeychain.HardenedKeyStart + p.CoinType,
+		hdkeychain.HardenedKeyStart + p.Account,
+		p.Change,
+		p.Index,
+	}
+
+	key := masterKey
+	for _, level := range levels {
+		var err error
+		key, err = key.Derive(level)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
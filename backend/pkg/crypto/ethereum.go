@@ -0,0 +1,132 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrInvalidTransaction is returned when transaction fields are inconsistent.
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// EthereumTx holds the fields of an Ethereum transaction to sign.
+// GasPrice selects a legacy (EIP-155) transaction; MaxFeePerGas and
+// MaxPriorityFeePerGas select an EIP-1559 dynamic fee transaction.
+type EthereumTx struct {
+	ChainID              *big.Int
+	Nonce                uint64
+	To                   *common.Address // nil deploys a contract
+	Value                *big.Int
+	Gas                  uint64
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Data                 []byte
+}
+
+// SignedEthereumTx is a signed transaction ready to broadcast.
+type SignedEthereumTx struct {
+	From string
+	Hash string
+	Raw  string // 0x-prefixed binary encoding (RLP, typed envelope for EIP-1559)
+	Type string
+}
+
+// Ethereum transaction types as reported in [SignedEthereumTx].
+const (
+	EthereumTxLegacy  = "legacy"
+	EthereumTxEIP1559 = "eip1559"
+)
+
+// newTransaction builds the go-ethereum transaction for the given fee model.
+func (t *EthereumTx) newTransaction() (*types.Transaction, string, error) {
+	if t.ChainID == nil || t.ChainID.Sign() <= 0 {
+		return nil, "", fmt.Errorf("%w: chain id is required", ErrInvalidTransaction)
+	}
+	if t.Gas == 0 {
+		return nil, "", fmt.Errorf("%w: gas limit is required", ErrInvalidTransaction)
+	}
+
+	value := t.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	legacy := t.GasPrice != nil
+	dynamic := t.MaxFeePerGas != nil || t.MaxPriorityFeePerGas != nil
+
+	switch {
+	case legacy && dynamic:
+		return nil, "", fmt.Errorf("%w: gas price cannot be combined with EIP-1559 fees", ErrInvalidTransaction)
+
+	case legacy:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    t.Nonce,
+			GasPrice: t.GasPrice,
+			Gas:      t.Gas,
+			To:       t.To,
+			Value:    value,
+			Data:     t.Data,
+		}), EthereumTxLegacy, nil
+
+	case t.MaxFeePerGas == nil || t.MaxPriorityFeePerGas == nil:
+		return nil, "", fmt.Errorf("%w: EIP-1559 transactions need both max fee and max priority fee", ErrInvalidTransaction)
+
+	case t.MaxPriorityFeePerGas.Cmp(t.MaxFeePerGas) > 0:
+		return nil, "", fmt.Errorf("%w: max priority fee exceeds max fee", ErrInvalidTransaction)
+
+	default:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   t.ChainID,
+			Nonce:     t.Nonce,
+			GasTipCap: t.MaxPriorityFeePerGas,
+			GasFeeCap: t.MaxFeePerGas,
+			Gas:       t.Gas,
+			To:        t.To,
+			Value:     value,
+			Data:      t.Data,
+		}), EthereumTxEIP1559, nil
+	}
+}
+
+// SignEthereumTx signs tx with the key at path. The private key never
+// leaves this package and nothing is broadcast.
+func (c *CryptoServiceImpl) SignEthereumTx(
+	mnemonic string,
+	seedPassphrase string,
+	path DerivationPath,
+	tx *EthereumTx,
+) (*SignedEthereumTx, error) {
+
+	unsigned, txType, err := tx.newTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ethereumKey(mnemonic, seedPassphrase, path)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := types.SignTx(unsigned, types.LatestSignerForChainID(tx.ChainID), key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedEthereumTx{
+		From: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		Hash: signed.Hash().Hex(),
+		Raw:  hexutil.Encode(raw),
+		Type: txType,
+	}, nil
+}
@@ -0,0 +1,224 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrInvalidTypedData is returned when EIP-712 typed data cannot be hashed.
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// ErrInvalidSignature is returned when a signature is malformed.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// MessageSignature is a 65-byte [R || S || V] signature with V in {27, 28},
+// as returned by personal_sign and eth_signTypedData_v4.
+type MessageSignature struct {
+	Address   string
+	Hash      string
+	Signature string
+}
+
+// PersonalMessageHash is the EIP-191 version 0x45 hash of message:
+// keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
+func PersonalMessageHash(message []byte) []byte {
+	return accounts.TextHash(message)
+}
+
+// TypedDataHash parses EIP-712 typed data JSON and returns its signing hash
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)), along
+// with the domain chain id when the domain sets one.
+func TypedDataHash(typedDataJSON []byte) ([]byte, *big.Int, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(typedDataJSON, &typedData); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+
+	var chainID *big.Int
+	if typedData.Domain.ChainId != nil {
+		chainID = (*big.Int)(typedData.Domain.ChainId)
+	}
+
+	return hash, chainID, nil
+}
+
+// TypedDataPermit is a token allowance granted by an EIP-2612 or DAI-style
+// Permit signature: Spender may move Value of the token at Token. A DAI
+// permit that sets allowed grants the maximum uint256.
+type TypedDataPermit struct {
+	Token   common.Address
+	Spender common.Address
+	Value   *big.Int
+}
+
+// TypedDataParties are the accounts EIP-712 typed data is addressed to, as
+// far as they can be read: the contract that verifies it and the spender
+// named at the top of its message. Permit is set for Permit messages.
+type TypedDataParties struct {
+	VerifyingContract *common.Address
+	Spender           *common.Address
+	Permit            *TypedDataPermit
+}
+
+// ParseTypedDataParties reads the parties of EIP-712 typed data JSON. Fields
+// that are missing or malformed are left nil rather than failing, so
+// callers treat such messages as ones they cannot read.
+func ParseTypedDataParties(typedDataJSON []byte) (*TypedDataParties, error) {
+	var typedData struct {
+		PrimaryType string `json:"primaryType"`
+		Domain      struct {
+			VerifyingContract string `json:"verifyingContract"`
+		} `json:"domain"`
+		Message map[string]json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(typedDataJSON, &typedData); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTypedData, err)
+	}
+
+	parties := &TypedDataParties{
+		VerifyingContract: hexAddress(typedData.Domain.VerifyingContract),
+		Spender:           jsonAddress(typedData.Message["spender"]),
+	}
+	if typedData.PrimaryType != "Permit" || parties.VerifyingContract == nil || parties.Spender == nil {
+		return parties, nil
+	}
+
+	// EIP-2612 names the amount; DAI grants all or nothing
+	var value *big.Int
+	if raw, ok := typedData.Message["value"]; ok {
+		value = jsonUint256(raw)
+	} else if raw, ok := typedData.Message["allowed"]; ok {
+		var allowed bool
+		if json.Unmarshal(raw, &allowed) == nil {
+			value = new(big.Int)
+			if allowed {
+				value.Set(math.MaxBig256)
+			}
+		}
+	}
+	if value != nil {
+		parties.Permit = &TypedDataPermit{
+			Token:   *parties.VerifyingContract,
+			Spender: *parties.Spender,
+			Value:   value,
+		}
+	}
+
+	return parties, nil
+}
+
+// jsonAddress decodes a JSON string holding a hex address, or returns nil.
+func jsonAddress(raw json.RawMessage) *common.Address {
+	var text string
+	if json.Unmarshal(raw, &text) != nil {
+		return nil
+	}
+	return hexAddress(text)
+}
+
+func hexAddress(text string) *common.Address {
+	if !common.IsHexAddress(text) {
+		return nil
+	}
+	address := common.HexToAddress(text)
+	return &address
+}
+
+// jsonUint256 decodes a uint256 given as a JSON number or as a decimal or
+// 0x-hex string, or returns nil.
+func jsonUint256(raw json.RawMessage) *big.Int {
+	var text string
+	if json.Unmarshal(raw, &text) != nil {
+		text = string(raw)
+	}
+	var n math.HexOrDecimal256
+	if n.UnmarshalText([]byte(text)) != nil || (*big.Int)(&n).Sign() < 0 {
+		return nil
+	}
+	return (*big.Int)(&n)
+}
+
+// SignHash signs a 32-byte message hash with the key at path.
+func (c *CryptoServiceImpl) SignHash(
+	mnemonic string,
+	seedPassphrase string,
+	path DerivationPath,
+	hash []byte,
+) (*MessageSignature, error) {
+
+	key, err := ethereumKey(mnemonic, seedPassphrase, path)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+
+	return &MessageSignature{
+		Address:   crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		Hash:      hexutil.Encode(hash),
+		Signature: hexutil.Encode(sig),
+	}, nil
+}
+
+// RecoverSigner returns the address that produced signature over hash.
+// V may be given as 0/1 or 27/28.
+func RecoverSigner(hash []byte, signature string) (string, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return "", ErrInvalidSignature
+	}
+
+	sig = append([]byte{}, sig...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	if sig[crypto.RecoveryIDOffset] > 1 {
+		return "", ErrInvalidSignature
+	}
+
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	return crypto.PubkeyToAddress(*pubKey).Hex(), nil
+}
+
+// ethereumKey derives the secp256k1 private key at path.
+func ethereumKey(mnemonic, seedPassphrase string, path DerivationPath) (*ecdsa.PrivateKey, error) {
+	masterKey, err := newMasterKey(mnemonic, seedPassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	addressKey, err := path.derive(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := addressKey.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey.ToECDSA(), nil
+}
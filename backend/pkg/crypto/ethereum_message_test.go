@@ -0,0 +1,129 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/stretchr/testify/require"
+)
+
+// mailTypedData is the example message of EIP-712.
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func TestTypedDataHashMatchesEIP712(t *testing.T) {
+	hash, chainID, err := TypedDataHash([]byte(mailTypedData))
+	require.NoError(t, err)
+	require.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+	require.EqualValues(t, 1, chainID.Int64())
+
+	_, _, err = TypedDataHash([]byte(`{"primaryType": "Mail"}`))
+	require.ErrorIs(t, err, ErrInvalidTypedData)
+}
+
+func TestSignHashRecoversSigner(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	hash := PersonalMessageHash([]byte("hello"))
+
+	sig, err := svc.SignHash(fixtureMnemonic, "", EthereumPath(0, 0, 0), hash)
+	require.NoError(t, err)
+	require.Equal(t, fixtureEthAddress, sig.Address)
+
+	raw, err := hexutil.Decode(sig.Signature)
+	require.NoError(t, err)
+	require.Len(t, raw, 65)
+	require.Contains(t, []byte{27, 28}, raw[64])
+
+	// 1️⃣ V is accepted as 27/28 or 0/1
+	signer, err := RecoverSigner(hash, sig.Signature)
+	require.NoError(t, err)
+	require.Equal(t, fixtureEthAddress, signer)
+
+	raw[64] -= 27
+	signer, err = RecoverSigner(hash, hexutil.Encode(raw))
+	require.NoError(t, err)
+	require.Equal(t, fixtureEthAddress, signer)
+
+	// 2️⃣ Another message recovers someone else
+	signer, err = RecoverSigner(PersonalMessageHash([]byte("hello!")), sig.Signature)
+	require.NoError(t, err)
+	require.NotEqual(t, fixtureEthAddress, signer)
+
+	// 3️⃣ Malformed signatures are refused
+	raw[64] = 29
+	for _, malformed := range []string{"0x1234", "not hex", hexutil.Encode(raw)} {
+		_, err = RecoverSigner(hash, malformed)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	}
+}
+
+func TestParseTypedDataParties(t *testing.T) {
+	token := common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	spender := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+
+	tests := []struct {
+		name      string
+		typedData string
+		permit    *TypedDataPermit
+	}{
+		{
+			name:      "mail",
+			typedData: mailTypedData,
+		},
+		{
+			name: "eip2612 permit",
+			typedData: `{"primaryType": "Permit",
+				"domain": {"verifyingContract": "` + token.Hex() + `"},
+				"message": {"spender": "` + spender.Hex() + `", "value": "1000"}}`,
+			permit: &TypedDataPermit{Token: token, Spender: spender, Value: big.NewInt(1000)},
+		},
+		{
+			name: "dai permit",
+			typedData: `{"primaryType": "Permit",
+				"domain": {"verifyingContract": "` + token.Hex() + `"},
+				"message": {"spender": "` + spender.Hex() + `", "allowed": true}}`,
+			permit: &TypedDataPermit{Token: token, Spender: spender, Value: math.MaxBig256},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parties, err := ParseTypedDataParties([]byte(tt.typedData))
+			require.NoError(t, err)
+			require.NotNil(t, parties.VerifyingContract)
+			require.Equal(t, tt.permit, parties.Permit)
+		})
+	}
+}
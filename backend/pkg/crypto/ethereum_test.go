@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureEthAddress is m/44'/60'/0'/0/0 of fixtureMnemonic.
+const fixtureEthAddress = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+
+func TestSignEthereumTx(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+
+	tests := []struct {
+		name   string
+		tx     EthereumTx
+		txType string
+	}{
+		{
+			name: "legacy",
+			tx: EthereumTx{
+				ChainID:  big.NewInt(1),
+				Nonce:    7,
+				To:       &to,
+				Value:    big.NewInt(1000),
+				Gas:      21000,
+				GasPrice: big.NewInt(1_000_000_000),
+			},
+			txType: EthereumTxLegacy,
+		},
+		{
+			name: "eip1559",
+			tx: EthereumTx{
+				ChainID:              big.NewInt(11155111),
+				Nonce:                3,
+				To:                   &to,
+				Gas:                  50000,
+				MaxFeePerGas:         big.NewInt(30_000_000_000),
+				MaxPriorityFeePerGas: big.NewInt(1_000_000_000),
+				Data:                 []byte{0xde, 0xad},
+			},
+			txType: EthereumTxEIP1559,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := svc.SignEthereumTx(fixtureMnemonic, "", EthereumPath(0, 0, 0), &tt.tx)
+			require.NoError(t, err)
+			require.Equal(t, fixtureEthAddress, signed.From)
+			require.Equal(t, tt.txType, signed.Type)
+
+			// The raw transaction decodes to the request, signed by From
+			raw, err := hexutil.Decode(signed.Raw)
+			require.NoError(t, err)
+			var decoded types.Transaction
+			require.NoError(t, decoded.UnmarshalBinary(raw))
+
+			require.Equal(t, signed.Hash, decoded.Hash().Hex())
+			require.Equal(t, tt.tx.ChainID, decoded.ChainId())
+			require.Equal(t, tt.tx.Nonce, decoded.Nonce())
+			require.Equal(t, tt.tx.Gas, decoded.Gas())
+			require.Equal(t, to, *decoded.To())
+			require.Equal(t, hexutil.Encode(tt.tx.Data), hexutil.Encode(decoded.Data()))
+
+			sender, err := types.Sender(types.LatestSignerForChainID(tt.tx.ChainID), &decoded)
+			require.NoError(t, err)
+			require.Equal(t, fixtureEthAddress, sender.Hex())
+		})
+	}
+}
+
+func TestSignEthereumTxRejectsInconsistentFields(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	gwei := big.NewInt(1_000_000_000)
+
+	for name, tx := range map[string]EthereumTx{
+		"no chain id":       {Gas: 21000, GasPrice: gwei},
+		"no gas":            {ChainID: big.NewInt(1), GasPrice: gwei},
+		"mixed fee models":  {ChainID: big.NewInt(1), Gas: 21000, GasPrice: gwei, MaxFeePerGas: gwei},
+		"half eip1559 fees": {ChainID: big.NewInt(1), Gas: 21000, MaxFeePerGas: gwei},
+		"tip above max fee": {ChainID: big.NewInt(1), Gas: 21000, MaxFeePerGas: gwei, MaxPriorityFeePerGas: big.NewInt(2_000_000_000)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.SignEthereumTx(fixtureMnemonic, "", EthereumPath(0, 0, 0), &tx)
+			require.ErrorIs(t, err, ErrInvalidTransaction)
+		})
+	}
+}
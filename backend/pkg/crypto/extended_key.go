@@ -0,0 +1,129 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+)
+
+// ErrInvalidExtendedKey is returned for text that is not an account-level
+// extended public key usable on the expected network.
+var ErrInvalidExtendedKey = errors.New("invalid extended public key")
+
+// extendedKeyVersion is what the SLIP-132 version bytes of an extended
+// public key say about the addresses it derives.
+type extendedKeyVersion struct {
+	prefix  string
+	purpose uint32
+	mainnet bool
+}
+
+var extendedKeyVersions = map[uint32]extendedKeyVersion{
+	0x0488b21e: {"xpub", PurposeBIP44, true},
+	0x049d7cb2: {"ypub", PurposeBIP49, true},
+	0x04b24746: {"zpub", PurposeBIP84, true},
+	0x043587cf: {"tpub", PurposeBIP44, false},
+	0x044a5262: {"upub", PurposeBIP49, false},
+	0x045f1cf6: {"vpub", PurposeBIP84, false},
+}
+
+// ExtendedPublicKey is an imported account-level BIP32 public key,
+// m/purpose'/coin_type'/account'. It derives the receive and change
+// addresses of the account but can sign nothing.
+type ExtendedPublicKey struct {
+	key     *hdkeychain.ExtendedKey
+	network *Network
+
+	// Purpose follows from the key prefix: xpub/tpub are BIP44,
+	// ypub/upub BIP49 and zpub/vpub BIP84.
+	Purpose uint32
+
+	// Account is the hardened account index the key was exported at.
+	Account uint32
+}
+
+// ParseExtendedPublicKey decodes an xpub, ypub or zpub (tpub, upub or vpub
+// on Bitcoin test networks) for network. Ethereum accounts are BIP44, so
+// only xpub and tpub are accepted there. Private keys are refused, so no
+// key material reaches the server by mistake.
+func ParseExtendedPublicKey(network *Network, s string) (*ExtendedPublicKey, error) {
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExtendedKey, err)
+	}
+	if key.IsPrivate() {
+		return nil, fmt.Errorf("%w: private extended keys are not accepted", ErrInvalidExtendedKey)
+	}
+
+	version, ok := extendedKeyVersions[binary.BigEndian.Uint32(key.Version())]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown version bytes %x", ErrInvalidExtendedKey, key.Version())
+	}
+
+	switch network.Chain {
+	case ChainETH:
+		if version.purpose != PurposeBIP44 {
+			return nil, fmt.Errorf("%w: %s keys cannot derive %s addresses", ErrInvalidExtendedKey, version.prefix, network.Chain)
+		}
+	case ChainBTC:
+		if version.mainnet != (network.Name == NetworkMainnet) {
+			return nil, fmt.Errorf("%w: %s keys are not for %s %s", ErrInvalidExtendedKey, version.prefix, network.Chain, network.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported chain %q", network.Chain)
+	}
+
+	// Only an account key derives the addresses a wallet app shows
+	if key.Depth() != 3 || key.ChildIndex() < hdkeychain.HardenedKeyStart {
+		return nil, fmt.Errorf("%w: expected an account-level key (m/%d'/%d'/account')",
+			ErrInvalidExtendedKey, version.purpose, network.CoinType())
+	}
+
+	return &ExtendedPublicKey{
+		key:     key,
+		network: network,
+		Purpose: version.purpose,
+		Account: key.ChildIndex() - hdkeychain.HardenedKeyStart,
+	}, nil
+}
+
+// String returns the key in its original encoding.
+func (k *ExtendedPublicKey) String() string {
+	return k.key.String()
+}
+
+// Path returns the full derivation path of an address of the account.
+// The coin type is the network's; the key itself does not record it.
+func (k *ExtendedPublicKey) Path(change, index uint32) DerivationPath {
+	return DerivationPath{
+		Purpose:  k.Purpose,
+		CoinType: k.network.CoinType(),
+		Account:  k.Account,
+		Change:   change,
+		Index:    index,
+	}
+}
+
+// Address derives the address at path, which must lie in the account.
+func (k *ExtendedPublicKey) Address(path DerivationPath) (string, error) {
+	if err := path.Validate(); err != nil {
+		return "", err
+	}
+	if path.Purpose != k.Purpose || path.Account != k.Account {
+		return "", fmt.Errorf("derivation path %s is outside the account of the extended public key", path)
+	}
+
+	key := k.key
+	for _, level := range []uint32{path.Change, path.Index} {
+		var err error
+		key, err = key.Derive(level)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return encodeAddress(key, k.network, k.Purpose)
+}
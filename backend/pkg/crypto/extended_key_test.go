@@ -0,0 +1,170 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/stretchr/testify/require"
+)
+
+// An account key exported from fixtureMnemonic derives the same addresses
+// as the mnemonic itself.
+func TestExtendedPublicKeyDerivesMnemonicAddresses(t *testing.T) {
+	svc := fixtureCryptoService(t)
+
+	tests := []struct {
+		chain   string
+		network string
+		prefix  string
+		path    string
+	}{
+		{ChainETH, NetworkMainnet, "xpub", "m/44'/60'/0'/0/0"},
+		{ChainETH, NetworkSepolia, "xpub", "m/44'/60'/0'/0/3"},
+		{ChainBTC, NetworkMainnet, "xpub", "m/44'/0'/0'/0/0"},
+		{ChainBTC, NetworkMainnet, "ypub", "m/49'/0'/0'/0/0"},
+		{ChainBTC, NetworkMainnet, "zpub", "m/84'/0'/0'/1/0"},
+		{ChainBTC, NetworkMainnet, "zpub", "m/84'/0'/2'/0/5"},
+		{ChainBTC, NetworkTestnet, "tpub", "m/44'/1'/0'/0/0"},
+		{ChainBTC, NetworkTestnet, "upub", "m/49'/1'/0'/0/0"},
+		{ChainBTC, NetworkTestnet, "vpub", "m/84'/1'/1'/0/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network+" "+tt.prefix+" "+tt.path, func(t *testing.T) {
+			network, err := LookupNetwork(tt.chain, tt.network)
+			require.NoError(t, err)
+			path := mustPath(t, tt.path)
+
+			key, err := ParseExtendedPublicKey(network, fixtureAccountKey(t, tt.prefix, path))
+			require.NoError(t, err)
+			require.Equal(t, path.Purpose, key.Purpose)
+			require.Equal(t, path.Account, key.Account)
+			require.Equal(t, path, key.Path(path.Change, path.Index))
+
+			address, err := key.Address(path)
+			require.NoError(t, err)
+			want, err := svc.GenerateAddress(fixtureMnemonic, "", network, path)
+			require.NoError(t, err)
+			require.Equal(t, want, address)
+		})
+	}
+
+	// Spot-check against the published vectors as well
+	mainnet, err := LookupNetwork(ChainETH, NetworkMainnet)
+	require.NoError(t, err)
+	key, err := ParseExtendedPublicKey(mainnet, fixtureAccountKey(t, "xpub", mustPath(t, "m/44'/60'/0'/0/0")))
+	require.NoError(t, err)
+	address, err := key.Address(key.Path(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, fixtureEthAddress, address)
+}
+
+func TestParseExtendedPublicKeyRejects(t *testing.T) {
+	ethMainnet, err := LookupNetwork(ChainETH, NetworkMainnet)
+	require.NoError(t, err)
+	btcMainnet, err := LookupNetwork(ChainBTC, NetworkMainnet)
+	require.NoError(t, err)
+	btcTestnet, err := LookupNetwork(ChainBTC, NetworkTestnet)
+	require.NoError(t, err)
+
+	account := mustPath(t, "m/84'/0'/0'/0/0")
+	masterKey, err := newMasterKey(fixtureMnemonic, "")
+	require.NoError(t, err)
+	masterPub, err := masterKey.Neuter()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		network *Network
+		key     string
+	}{
+		{"not a key", btcMainnet, "zpub-not-a-key"},
+		{"private key", btcMainnet, masterKey.String()},
+		{"master key", btcMainnet, masterPub.String()},
+		{"address key", btcMainnet, fixtureAccountKey(t, "zpub", account, 0, 5)},
+		{"zpub for ethereum", ethMainnet, fixtureAccountKey(t, "zpub", account)},
+		{"ypub for ethereum", ethMainnet, fixtureAccountKey(t, "ypub", mustPath(t, "m/49'/60'/0'/0/0"))},
+		{"mainnet key on testnet", btcTestnet, fixtureAccountKey(t, "zpub", account)},
+		{"testnet key on mainnet", btcMainnet, fixtureAccountKey(t, "vpub", mustPath(t, "m/84'/1'/0'/0/0"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExtendedPublicKey(tt.network, tt.key)
+			require.ErrorIs(t, err, ErrInvalidExtendedKey)
+		})
+	}
+}
+
+func TestExtendedPublicKeyStaysInItsAccount(t *testing.T) {
+	mainnet, err := LookupNetwork(ChainBTC, NetworkMainnet)
+	require.NoError(t, err)
+	key, err := ParseExtendedPublicKey(mainnet, fixtureAccountKey(t, "zpub", mustPath(t, "m/84'/0'/1'/0/0")))
+	require.NoError(t, err)
+
+	for _, path := range []string{"m/84'/0'/0'/0/0", "m/49'/0'/1'/0/0"} {
+		_, err := key.Address(mustPath(t, path))
+		require.Error(t, err, path)
+	}
+
+	_, err = key.Address(DerivationPath{Purpose: PurposeBIP84, Account: 1, Change: 2})
+	require.Error(t, err)
+}
+
+func TestExtendedKeyFingerprintIdentifiesTheAccount(t *testing.T) {
+	svc := fixtureCryptoService(t)
+	mainnet, err := LookupNetwork(ChainBTC, NetworkMainnet)
+	require.NoError(t, err)
+	fingerprint := func(s string) string {
+		key, err := ParseExtendedPublicKey(mainnet, s)
+		require.NoError(t, err)
+		fingerprint, err := svc.ExtendedKeyFingerprint(key)
+		require.NoError(t, err)
+		return fingerprint
+	}
+
+	// The same account under another prefix is the same key; another
+	// account is not, and neither is the seed it comes from
+	zpub := fingerprint(fixtureAccountKey(t, "zpub", mustPath(t, "m/84'/0'/0'/0/0")))
+	require.Len(t, zpub, 64)
+	require.Equal(t, zpub, fingerprint(fixtureAccountKey(t, "xpub", mustPath(t, "m/84'/0'/0'/0/0"))))
+	require.NotEqual(t, zpub, fingerprint(fixtureAccountKey(t, "zpub", mustPath(t, "m/84'/0'/1'/0/0"))))
+
+	seed, err := svc.Fingerprint(fixtureMnemonic, "")
+	require.NoError(t, err)
+	require.NotEqual(t, seed, zpub)
+}
+
+// fixtureAccountKey exports the account of path from fixtureMnemonic,
+// or the key the given non-hardened levels below it, as a public key with
+// the version bytes of prefix.
+func fixtureAccountKey(t *testing.T, prefix string, path DerivationPath, levels ...uint32) string {
+	t.Helper()
+
+	key, err := newMasterKey(fixtureMnemonic, "")
+	require.NoError(t, err)
+	levels = append([]uint32{
+		hdkeychain.HardenedKeyStart + path.Purpose,
+		hdkeychain.HardenedKeyStart + path.CoinType,
+		hdkeychain.HardenedKeyStart + path.Account,
+	}, levels...)
+	for _, level := range levels {
+		key, err = key.Derive(level)
+		require.NoError(t, err)
+	}
+	key, err = key.Neuter()
+	require.NoError(t, err)
+
+	for bytes, version := range extendedKeyVersions {
+		if version.prefix == prefix {
+			version := make([]byte, 4)
+			binary.BigEndian.PutUint32(version, bytes)
+			key, err = key.CloneWithVersion(version)
+			require.NoError(t, err)
+			return key.String()
+		}
+	}
+	t.Fatalf("unknown prefix %q", prefix)
+	return ""
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ErrUnknownKEK is returned when a wrapped key names a KEK the provider
+// does not hold.
+var ErrUnknownKEK = errors.New("unknown key encryption key")
+
+// KEKProvider wraps and unwraps per-wallet data encryption keys (DEKs)
+// with a key encryption key (KEK) that never leaves the provider.
+type KEKProvider interface {
+	// CurrentKeyID returns the id of the KEK used for new wraps.
+	CurrentKeyID() string
+
+	// Wrap encrypts dek under the current KEK and returns its id.
+	Wrap(ctx context.Context, dek []byte) (keyId string, wrapped []byte, err error)
+
+	// Unwrap decrypts a DEK wrapped under the KEK keyId.
+	Unwrap(ctx context.Context, keyId string, wrapped []byte) ([]byte, error)
+}
+
+// KEKRotator is implemented by providers that can mint a new KEK
+// themselves. Previous KEKs stay available for unwrapping.
+type KEKRotator interface {
+	Rotate(ctx context.Context) (keyId string, err error)
+}
+
+// gcmNonceSize is the standard AES-GCM nonce length in bytes.
+const gcmNonceSize = 12
+
+// sealAESGCM encrypts plaintext as nonce || ciphertext.
+func sealAESGCM(key, plaintext, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openAESGCM reverses sealAESGCM.
+func openAESGCM(key, sealed, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce := sealed[:gcm.NonceSize()]
+	return gcm.Open(nil, nonce, sealed[gcm.NonceSize():], additionalData)
+}
@@ -0,0 +1,278 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// kekSize is the length of a KEK in bytes (AES-256).
+const kekSize = 32
+
+// Keyring is a [KEKProvider] backed by a local JSON file holding every
+// KEK ever issued. The primary key wraps new DEKs; older keys are kept
+// so existing wraps can still be opened until they are rotated away.
+type Keyring struct {
+	mu      sync.RWMutex
+	path    string
+	primary string
+	keys    map[string][]byte
+	created map[string]time.Time
+	modTime time.Time
+}
+
+type keyringFile struct {
+	Primary string           `json:"primary"`
+	Keys    []keyringFileKey `json:"keys"`
+}
+
+type keyringFileKey struct {
+	Id         string    `json:"id"`
+	Key        string    `json:"key"`
+	CreateDate time.Time `json:"createDate"`
+}
+
+// LoadKeyring reads the keyring at path. A missing file yields an empty
+// keyring, which can only be used after a first [Keyring.Rotate].
+func LoadKeyring(path string) (*Keyring, error) {
+	k := &Keyring{
+		path:    path,
+		keys:    make(map[string][]byte),
+		created: make(map[string]time.Time),
+	}
+
+	if err := k.load(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// load replaces the in-memory keys with the file contents.
+// Callers must hold the write lock or own k exclusively.
+func (k *Keyring) load() error {
+	info, err := os.Stat(k.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(k.path)
+	if err != nil {
+		return err
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return fmt.Errorf("keyring %s: %w", k.path, err)
+	}
+
+	keys := make(map[string][]byte, len(file.Keys))
+	created := make(map[string]time.Time, len(file.Keys))
+	for _, entry := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(entry.Key)
+		if err != nil || len(key) != kekSize {
+			return fmt.Errorf("keyring %s: invalid key %q", k.path, entry.Id)
+		}
+		keys[entry.Id] = key
+		created[entry.Id] = entry.CreateDate
+	}
+
+	if _, ok := keys[file.Primary]; file.Primary != "" && !ok {
+		return fmt.Errorf("keyring %s: primary key %q not found", k.path, file.Primary)
+	}
+
+	k.primary = file.Primary
+	k.keys = keys
+	k.created = created
+	k.modTime = info.ModTime()
+	return nil
+}
+
+// refresh reloads the file when another process, such as the kek-rotate
+// command, has rewritten it since it was last read.
+func (k *Keyring) refresh() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	k.mu.RLock()
+	stale := !info.ModTime().Equal(k.modTime)
+	k.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.load()
+}
+
+// CurrentKeyID implements [KEKProvider].
+func (k *Keyring) CurrentKeyID() string {
+	if err := k.refresh(); err != nil {
+		log.Printf("keyring %s: %v", k.path, err)
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.primary
+}
+
+// Wrap implements [KEKProvider].
+func (k *Keyring) Wrap(ctx context.Context, dek []byte) (string, []byte, error) {
+	if err := k.refresh(); err != nil {
+		return "", nil, err
+	}
+
+	k.mu.RLock()
+	keyId := k.primary
+	k.mu.RUnlock()
+
+	if keyId == "" {
+		return "", nil, errors.New("keyring has no primary key")
+	}
+
+	wrapped, err := k.encrypt(keyId, dek)
+	return keyId, wrapped, err
+}
+
+// Unwrap implements [KEKProvider].
+func (k *Keyring) Unwrap(ctx context.Context, keyId string, wrapped []byte) ([]byte, error) {
+	return k.decrypt(keyId, wrapped)
+}
+
+// Rotate implements [KEKRotator]. It adds a fresh key, makes it primary
+// and writes the keyring back to disk before returning.
+func (k *Keyring) Rotate(ctx context.Context) (string, error) {
+	key := make([]byte, kekSize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := io.ReadFull(rand.Reader, suffix); err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	keyId := fmt.Sprintf("kek-%s-%s", now.Format("20060102T150405"), hex.EncodeToString(suffix))
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Start from the file so keys added elsewhere are not dropped
+	if err := k.load(); err != nil {
+		return "", err
+	}
+
+	previous := k.primary
+	k.keys[keyId] = key
+	k.created[keyId] = now
+	k.primary = keyId
+
+	if err := k.save(); err != nil {
+		delete(k.keys, keyId)
+		delete(k.created, keyId)
+		k.primary = previous
+		return "", err
+	}
+
+	return keyId, nil
+}
+
+// encrypt seals plaintext under the KEK keyId, bound to that id.
+func (k *Keyring) encrypt(keyId string, plaintext []byte) ([]byte, error) {
+	key, err := k.key(keyId)
+	if err != nil {
+		return nil, err
+	}
+	return sealAESGCM(key, plaintext, []byte(keyId))
+}
+
+// decrypt opens ciphertext sealed by encrypt.
+func (k *Keyring) decrypt(keyId string, ciphertext []byte) ([]byte, error) {
+	key, err := k.key(keyId)
+	if err != nil {
+		return nil, err
+	}
+	return openAESGCM(key, ciphertext, []byte(keyId))
+}
+
+// key looks up a KEK, re-reading the file once if it is not known yet.
+func (k *Keyring) key(keyId string) ([]byte, error) {
+	k.mu.RLock()
+	key, ok := k.keys[keyId]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := k.refresh(); err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	key, ok = k.keys[keyId]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKEK
+	}
+	return key, nil
+}
+
+// save writes the keyring atomically with owner-only permissions.
+// Callers must hold the write lock.
+func (k *Keyring) save() error {
+	file := keyringFile{Primary: k.primary}
+	for id, key := range k.keys {
+		file.Keys = append(file.Keys, keyringFileKey{
+			Id:         id,
+			Key:        base64.StdEncoding.EncodeToString(key),
+			CreateDate: k.created[id],
+		})
+	}
+
+	sort.Slice(file.Keys, func(i, j int) bool {
+		return file.Keys[i].CreateDate.Before(file.Keys[j].CreateDate)
+	})
+
+	raw, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := k.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, k.path); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+	k.modTime = info.ModTime()
+	return nil
+}
@@ -0,0 +1,230 @@
+package crypto
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rotatedKeyring returns a keyring file holding two KEKs, and the ids of
+// the previous and the current one.
+func rotatedKeyring(t *testing.T) (*Keyring, string, string) {
+	t.Helper()
+
+	keyring, err := LoadKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	previous, err := keyring.Rotate(ctx)
+	require.NoError(t, err)
+	current, err := keyring.Rotate(ctx)
+	require.NoError(t, err)
+	require.NotEqual(t, previous, current)
+
+	return keyring, previous, current
+}
+
+func TestKEKProvidersWrapAndUnwrap(t *testing.T) {
+	ctx := context.Background()
+	keyring, previous, current := rotatedKeyring(t)
+
+	kms, err := NewKMSProvider(NewLocalKMS(keyring), current)
+	require.NoError(t, err)
+	previousKMS, err := NewKMSProvider(NewLocalKMS(keyring), previous)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		wrapper  KEKProvider
+		wrapId   string
+		provider KEKProvider
+	}{
+		{"keyring, current key", keyring, current, keyring},
+		{"kms, current key", kms, current, kms},
+		{"kms, previous key", previousKMS, previous, kms},
+		{"kms wrap, keyring unwrap", kms, current, keyring},
+		{"keyring wrap, kms unwrap", keyring, current, previousKMS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dek := bytes.Repeat([]byte{0x42}, dekSize)
+
+			keyId, wrapped, err := tt.wrapper.Wrap(ctx, dek)
+			require.NoError(t, err)
+			require.Equal(t, tt.wrapId, keyId)
+			require.NotContains(t, string(wrapped), string(dek))
+
+			unwrapped, err := tt.provider.Unwrap(ctx, keyId, wrapped)
+			require.NoError(t, err)
+			require.Equal(t, dek, unwrapped)
+		})
+	}
+}
+
+func TestKeyringUnwrapsWithPreviousKEK(t *testing.T) {
+	ctx := context.Background()
+	keyring, err := LoadKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+	require.NoError(t, err)
+
+	first, err := keyring.Rotate(ctx)
+	require.NoError(t, err)
+
+	dek := bytes.Repeat([]byte{0x07}, dekSize)
+	keyId, wrapped, err := keyring.Wrap(ctx, dek)
+	require.NoError(t, err)
+	require.Equal(t, first, keyId)
+
+	second, err := keyring.Rotate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, second, keyring.CurrentKeyID())
+
+	unwrapped, err := keyring.Unwrap(ctx, first, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, dek, unwrapped)
+}
+
+func TestKeyringUnwrapErrors(t *testing.T) {
+	ctx := context.Background()
+	keyring, previous, current := rotatedKeyring(t)
+
+	dek := bytes.Repeat([]byte{0x01}, dekSize)
+	_, wrapped, err := keyring.Wrap(ctx, dek)
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, wrapped...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		name    string
+		keyId   string
+		wrapped []byte
+		unknown bool
+	}{
+		{"unknown key id", "kek-missing", wrapped, true},
+		{"wrapped under another key", previous, wrapped, false},
+		{"tampered wrap", current, tampered, false},
+		{"truncated wrap", current, wrapped[:gcmNonceSize-1], false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := keyring.Unwrap(ctx, tt.keyId, tt.wrapped)
+			require.Error(t, err)
+			if tt.unknown {
+				require.ErrorIs(t, err, ErrUnknownKEK)
+			}
+		})
+	}
+}
+
+func TestKeyringSharesRotationsThroughFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	server, err := LoadKeyring(path)
+	require.NoError(t, err)
+	require.Empty(t, server.CurrentKeyID())
+	_, _, err = server.Wrap(ctx, make([]byte, dekSize))
+	require.Error(t, err)
+
+	// The rotate command writes the file; the running keyring follows it
+	rotator, err := LoadKeyring(path)
+	require.NoError(t, err)
+	first, err := rotator.Rotate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first, server.CurrentKeyID())
+
+	dek := bytes.Repeat([]byte{0x09}, dekSize)
+	_, wrapped, err := server.Wrap(ctx, dek)
+	require.NoError(t, err)
+
+	second, err := rotator.Rotate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, second, server.CurrentKeyID())
+
+	// A restart reads both keys back
+	reloaded, err := LoadKeyring(path)
+	require.NoError(t, err)
+	require.Equal(t, second, reloaded.CurrentKeyID())
+	unwrapped, err := reloaded.Unwrap(ctx, first, wrapped)
+	require.NoError(t, err)
+	require.Equal(t, dek, unwrapped)
+}
+
+func TestRewrapMnemonic(t *testing.T) {
+	ctx := context.Background()
+	const (
+		mnemonic   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		passphrase = "correct horse"
+		walletId   = "wallet-rewrap"
+	)
+
+	tests := []struct {
+		name     string
+		provider func(keyring *Keyring, current string) KEKProvider
+	}{
+		{"keyring", func(keyring *Keyring, current string) KEKProvider { return keyring }},
+		{"kms", func(keyring *Keyring, current string) KEKProvider {
+			kms, err := NewKMSProvider(NewLocalKMS(keyring), current)
+			require.NoError(t, err)
+			return kms
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := LoadKeyring(filepath.Join(t.TempDir(), "keyring.json"))
+			require.NoError(t, err)
+			previous, err := keyring.Rotate(ctx)
+			require.NoError(t, err)
+
+			svc := newTestCryptoService(t, tt.provider(keyring, previous))
+			sealed, err := svc.EncryptMnemonic(ctx, mnemonic, passphrase, walletId)
+			require.NoError(t, err)
+			require.Equal(t, previous, sealed.KeyId)
+
+			// 1️⃣ Rotate, then re-wrap under the new KEK
+			current, err := keyring.Rotate(ctx)
+			require.NoError(t, err)
+			svc = newTestCryptoService(t, tt.provider(keyring, current))
+			require.Equal(t, current, svc.CurrentKEKId())
+
+			rewrapped, err := svc.RewrapMnemonic(ctx, sealed)
+			require.NoError(t, err)
+			require.Equal(t, current, rewrapped.KeyId)
+			require.Equal(t, sealed.Ciphertext, rewrapped.Ciphertext)
+			require.NotEqual(t, sealed.WrappedKey, rewrapped.WrappedKey)
+
+			// 2️⃣ Both wraps still open the same mnemonic
+			for _, record := range []*EncryptedMnemonic{sealed, rewrapped} {
+				plain, err := svc.DecryptMnemonic(ctx, record, passphrase, walletId)
+				require.NoError(t, err)
+				require.Equal(t, mnemonic, plain)
+			}
+
+			// 3️⃣ Re-wrapping again keeps the current KEK
+			again, err := svc.RewrapMnemonic(ctx, rewrapped)
+			require.NoError(t, err)
+			require.Equal(t, current, again.KeyId)
+		})
+	}
+}
+
+func TestRewrapMnemonicRefusesLegacyRecords(t *testing.T) {
+	keyring, _, _ := rotatedKeyring(t)
+	svc := newTestCryptoService(t, keyring)
+
+	_, err := svc.RewrapMnemonic(context.Background(), &EncryptedMnemonic{Ciphertext: "bm90IGVudmVsb3BlZA=="})
+	require.Error(t, err)
+}
+
+func newTestCryptoService(t *testing.T, kek KEKProvider) Service {
+	t.Helper()
+	svc, err := NewCryptoService(Config{FingerprintKey: []byte("fingerprint-key"), KEK: kek})
+	require.NoError(t, err)
+	return svc
+}
@@ -0,0 +1,220 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Supported BIP39 wordlist languages.
+const (
+	LanguageEnglish            = "english"
+	LanguageJapanese           = "japanese"
+	LanguageSpanish            = "spanish"
+	LanguageFrench             = "french"
+	LanguageItalian            = "italian"
+	LanguageKorean             = "korean"
+	LanguageChineseSimplified  = "chinese_simplified"
+	LanguageChineseTraditional = "chinese_traditional"
+	LanguageCzech              = "czech"
+)
+
+// DefaultWordCount is the mnemonic length used when none is requested.
+const DefaultWordCount = 12
+
+// ErrInvalidMnemonic is returned when a phrase is not a valid BIP39 mnemonic.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// wordlist is a BIP39 wordlist with a reverse index on NFKD-normalized words.
+type wordlist struct {
+	words     []string
+	index     map[string]int
+	separator string
+}
+
+func newWordlist(words []string, separator string) *wordlist {
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[norm.NFKD.String(word)] = i
+	}
+	return &wordlist{words: words, index: index, separator: separator}
+}
+
+// languageOrder is the order in which languages are tried during detection.
+// A few words are shared between lists (e.g. English and French, or the two
+// Chinese lists), so the checksum decides and earlier languages win ties.
+var languageOrder = []string{
+	LanguageEnglish,
+	LanguageJapanese,
+	LanguageSpanish,
+	LanguageFrench,
+	LanguageItalian,
+	LanguageKorean,
+	LanguageChineseSimplified,
+	LanguageChineseTraditional,
+	LanguageCzech,
+}
+
+var wordlistsByLanguage = map[string]*wordlist{
+	LanguageEnglish:            newWordlist(wordlists.English, " "),
+	LanguageJapanese:           newWordlist(wordlists.Japanese, "　"),
+	LanguageSpanish:            newWordlist(wordlists.Spanish, " "),
+	LanguageFrench:             newWordlist(wordlists.French, " "),
+	LanguageItalian:            newWordlist(wordlists.Italian, " "),
+	LanguageKorean:             newWordlist(wordlists.Korean, " "),
+	LanguageChineseSimplified:  newWordlist(wordlists.ChineseSimplified, " "),
+	LanguageChineseTraditional: newWordlist(wordlists.ChineseTraditional, " "),
+	LanguageCzech:              newWordlist(wordlists.Czech, " "),
+}
+
+// entropyBits maps each allowed mnemonic length to its entropy size.
+var entropyBits = map[int]int{
+	12: 128,
+	15: 160,
+	18: 192,
+	21: 224,
+	24: 256,
+}
+
+// NormalizeMnemonic applies NFKD, collapses whitespace and lowercases,
+// so a phrase typed with stray spaces or capitals still matches the
+// wordlist. BIP39 requires NFKD before seed derivation; every wordlist
+// entry is lowercase, so lowercasing never changes a valid mnemonic.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.ToLower(strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " "))
+}
+
+// newMnemonic draws fresh entropy and encodes it with the given wordlist.
+func newMnemonic(wordCount int, language string) (string, error) {
+	if wordCount == 0 {
+		wordCount = DefaultWordCount
+	}
+	if language == "" {
+		language = LanguageEnglish
+	}
+
+	bits, ok := entropyBits[wordCount]
+	if !ok {
+		return "", fmt.Errorf("unsupported mnemonic word count %d", wordCount)
+	}
+
+	list, ok := wordlistsByLanguage[language]
+	if !ok {
+		return "", fmt.Errorf("unsupported mnemonic language %q", language)
+	}
+
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return list.encode(entropy), nil
+}
+
+// detectLanguage returns the language whose wordlist contains every word
+// of the mnemonic and whose checksum matches. Otherwise it returns a
+// [*MnemonicError] against the wordlist that knows the most words.
+func detectLanguage(mnemonic string) (string, error) {
+	words := strings.Fields(NormalizeMnemonic(mnemonic))
+	if _, ok := entropyBits[len(words)]; !ok {
+		return "", &MnemonicError{Code: MnemonicErrWordCount, WordCount: len(words)}
+	}
+
+	for _, language := range languageOrder {
+		if _, err := wordlistsByLanguage[language].decode(words); err == nil {
+			return language, nil
+		}
+	}
+
+	language := closestLanguage(words)
+	unknown := wordlistsByLanguage[language].unknownWords(words)
+	if len(unknown) > 0 {
+		return "", &MnemonicError{
+			Code:         MnemonicErrUnknownWord,
+			WordCount:    len(words),
+			Language:     language,
+			UnknownWords: unknown,
+		}
+	}
+
+	return "", &MnemonicError{
+		Code:      MnemonicErrChecksum,
+		WordCount: len(words),
+		Language:  language,
+	}
+}
+
+// closestLanguage picks the wordlist containing the most of the words,
+// preferring earlier languages on ties.
+func closestLanguage(words []string) string {
+	best, bestKnown := LanguageEnglish, 0
+	for _, language := range languageOrder {
+		known := 0
+		for _, word := range words {
+			if _, ok := wordlistsByLanguage[language].index[word]; ok {
+				known++
+			}
+		}
+		if known > bestKnown {
+			best, bestKnown = language, known
+		}
+	}
+	return best
+}
+
+// encode turns entropy into words: ENT bits followed by ENT/32 checksum
+// bits of SHA-256(entropy), split into 11-bit word indexes.
+func (w *wordlist) encode(entropy []byte) string {
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+
+	wordCount := len(entropy) * 8 * 33 / 32 / 11
+	words := make([]string, wordCount)
+
+	for i := range words {
+		index := 0
+		for b := 0; b < 11; b++ {
+			bit := i*11 + b
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = w.words[index]
+	}
+
+	return strings.Join(words, w.separator)
+}
+
+// decode maps NFKD-normalized words back to entropy and verifies the checksum.
+func (w *wordlist) decode(words []string) ([]byte, error) {
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	entropyBytes := (totalBits - checksumBits) / 8
+
+	data := make([]byte, (totalBits+7)/8)
+	for i, word := range words {
+		index, ok := w.index[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		for b := 0; b < 11; b++ {
+			if index>>(10-b)&1 == 1 {
+				bit := i*11 + b
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := data[:entropyBytes]
+	hash := sha256.Sum256(entropy)
+
+	shift := 8 - checksumBits
+	if data[entropyBytes]>>shift != hash[0]>>shift {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return entropy, nil
+}
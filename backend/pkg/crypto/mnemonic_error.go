@@ -0,0 +1,147 @@
+package crypto
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Mnemonic validation error codes.
+const (
+	MnemonicErrWordCount   = "invalid_word_count"
+	MnemonicErrUnknownWord = "unknown_word"
+	MnemonicErrChecksum    = "invalid_checksum"
+)
+
+// maxSuggestions caps the replacement words offered for a single unknown word.
+const maxSuggestions = 3
+
+// maxSuggestionDistance is the largest edit distance still worth suggesting.
+const maxSuggestionDistance = 2
+
+// MnemonicError describes why a phrase is not a valid BIP39 mnemonic.
+// It matches [ErrInvalidMnemonic] with errors.Is.
+type MnemonicError struct {
+	Code         string        `json:"code"`
+	WordCount    int           `json:"wordCount"`
+	Language     string        `json:"language,omitempty"`
+	UnknownWords []UnknownWord `json:"unknownWords,omitempty"`
+}
+
+// UnknownWord is a word that is not in the detected wordlist,
+// with its 1-based position and the closest wordlist entries.
+type UnknownWord struct {
+	Position    int      `json:"position"`
+	Word        string   `json:"word"`
+	Suggestions []string `json:"suggestions"`
+}
+
+func (e *MnemonicError) Error() string {
+	switch e.Code {
+	case MnemonicErrWordCount:
+		return fmt.Sprintf("mnemonic has %d words, expected 12, 15, 18, 21 or 24", e.WordCount)
+	case MnemonicErrUnknownWord:
+		return fmt.Sprintf("mnemonic has %d word(s) not in the %s wordlist", len(e.UnknownWords), e.Language)
+	default:
+		return "mnemonic checksum does not match"
+	}
+}
+
+func (e *MnemonicError) Is(target error) bool {
+	return target == ErrInvalidMnemonic
+}
+
+// unknownWords lists the words missing from w, with suggestions.
+func (w *wordlist) unknownWords(words []string) []UnknownWord {
+	unknown := make([]UnknownWord, 0)
+	for i, word := range words {
+		if _, ok := w.index[word]; ok {
+			continue
+		}
+		unknown = append(unknown, UnknownWord{
+			Position:    i + 1,
+			Word:        word,
+			Suggestions: w.suggest(word),
+		})
+	}
+	return unknown
+}
+
+// suggest returns the wordlist entries closest to word by edit distance.
+// The Latin-script lists are unique in their first four letters, so a word
+// sharing that prefix is always offered first.
+func (w *wordlist) suggest(word string) []string {
+	type candidate struct {
+		index    int
+		distance int
+	}
+
+	prefix := []rune(word)
+	if len(prefix) > 4 {
+		prefix = prefix[:4]
+	}
+
+	candidates := make([]candidate, 0)
+	for normalized, i := range w.index {
+		distance := levenshtein(word, normalized)
+		if len(prefix) == 4 && hasRunePrefix(normalized, prefix) {
+			distance = 0
+		}
+		if distance <= maxSuggestionDistance {
+			candidates = append(candidates, candidate{index: i, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(a, b int) bool {
+		if candidates[a].distance != candidates[b].distance {
+			return candidates[a].distance < candidates[b].distance
+		}
+		return candidates[a].index < candidates[b].index
+	})
+
+	suggestions := make([]string, 0, maxSuggestions)
+	for _, c := range candidates {
+		if len(suggestions) == maxSuggestions {
+			break
+		}
+		suggestions = append(suggestions, w.words[c.index])
+	}
+	return suggestions
+}
+
+func hasRunePrefix(s string, prefix []rune) bool {
+	runes := []rune(s)
+	if len(runes) < len(prefix) {
+		return false
+	}
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
+
+// levenshtein is the edit distance between a and b, counted in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
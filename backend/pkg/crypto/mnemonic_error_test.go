@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLanguageReportsMnemonicErrors(t *testing.T) {
+	words := strings.Fields(fixtureMnemonic)
+
+	tests := []struct {
+		name     string
+		mnemonic string
+		want     *MnemonicError
+	}{
+		{
+			name:     "word count",
+			mnemonic: strings.Join(words[:11], " "),
+			want:     &MnemonicError{Code: MnemonicErrWordCount, WordCount: 11},
+		},
+		{
+			name:     "unknown words",
+			mnemonic: "abandn abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abuot",
+			want: &MnemonicError{
+				Code:      MnemonicErrUnknownWord,
+				WordCount: 12,
+				Language:  LanguageEnglish,
+				UnknownWords: []UnknownWord{
+					{Position: 1, Word: "abandn", Suggestions: []string{"abandon"}},
+					{Position: 12, Word: "abuot", Suggestions: []string{"about", "abuse", "adult"}},
+				},
+			},
+		},
+		{
+			name:     "checksum",
+			mnemonic: strings.Repeat("abandon ", 12),
+			want:     &MnemonicError{Code: MnemonicErrChecksum, WordCount: 12, Language: LanguageEnglish},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := detectLanguage(tt.mnemonic)
+			require.ErrorIs(t, err, ErrInvalidMnemonic)
+
+			var mnemonicErr *MnemonicError
+			require.True(t, errors.As(err, &mnemonicErr))
+			require.Equal(t, tt.want, mnemonicErr)
+		})
+	}
+}
+
+func TestNormalizeMnemonicForgivesSpacingAndCase(t *testing.T) {
+	typed := "  Abandon abandon\tABANDON abandon abandon abandon\n abandon abandon abandon abandon abandon About "
+	require.Equal(t, fixtureMnemonic, NormalizeMnemonic(typed))
+
+	language, err := detectLanguage(typed)
+	require.NoError(t, err)
+	require.Equal(t, LanguageEnglish, language)
+}
+
+func TestLevenshtein(t *testing.T) {
+	require.Equal(t, 0, levenshtein("about", "about"))
+	require.Equal(t, 2, levenshtein("abuot", "about"))
+	require.Equal(t, 1, levenshtein("abandn", "abandon"))
+	require.Equal(t, 3, levenshtein("", "abc"))
+}
@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func TestNewMnemonicLengths(t *testing.T) {
+	for wordCount := range entropyBits {
+		mnemonic, err := newMnemonic(wordCount, "")
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), wordCount)
+
+		language, err := detectLanguage(mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, LanguageEnglish, language)
+	}
+
+	mnemonic, err := newMnemonic(0, "")
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), DefaultWordCount)
+
+	_, err = newMnemonic(13, "")
+	require.Error(t, err)
+	_, err = newMnemonic(12, "klingon")
+	require.Error(t, err)
+}
+
+func TestNewMnemonicLanguages(t *testing.T) {
+	for _, language := range languageOrder {
+		t.Run(language, func(t *testing.T) {
+			mnemonic, err := newMnemonic(24, language)
+			require.NoError(t, err)
+
+			// Japanese phrases are joined by ideographic spaces
+			separator := " "
+			if language == LanguageJapanese {
+				separator = "　"
+			}
+			require.Len(t, strings.Split(mnemonic, separator), 24)
+
+			detected, err := detectLanguage(mnemonic)
+			require.NoError(t, err)
+			if language == LanguageChineseTraditional {
+				// Most characters are in both Chinese lists, and the
+				// simplified one is tried first
+				require.Contains(t, []string{LanguageChineseSimplified, LanguageChineseTraditional}, detected)
+				return
+			}
+			require.Equal(t, language, detected)
+		})
+	}
+}
+
+func TestWordlistEncodesLikeBIP39(t *testing.T) {
+	english := wordlistsByLanguage[LanguageEnglish]
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		entropy, err := bip39.NewEntropy(bits)
+		require.NoError(t, err)
+
+		want, err := bip39.NewMnemonic(entropy)
+		require.NoError(t, err)
+		require.Equal(t, want, english.encode(entropy))
+
+		decoded, err := english.decode(strings.Fields(want))
+		require.NoError(t, err)
+		require.Equal(t, entropy, decoded)
+	}
+
+	require.Equal(t, fixtureMnemonic, english.encode(make([]byte, 16)))
+}
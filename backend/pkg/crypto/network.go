@@ -0,0 +1,99 @@
+package crypto
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Network names accepted per chain.
+const (
+	NetworkMainnet = "mainnet"
+
+	// Ethereum testnets.
+	NetworkSepolia = "sepolia"
+	NetworkHolesky = "holesky"
+
+	// Local Ethereum development chain, such as the simulated backend.
+	NetworkDev = "dev"
+
+	// Bitcoin test networks.
+	NetworkTestnet = "testnet"
+	NetworkSignet  = "signet"
+	NetworkRegtest = "regtest"
+)
+
+// CoinTypeTestnet is the SLIP-44 coin type shared by all Bitcoin test networks.
+const CoinTypeTestnet uint32 = 1
+
+// Network describes the chain parameters a wallet's addresses are bound to.
+type Network struct {
+	Chain string
+	Name  string
+
+	// ChainID is the EIP-155 chain ID (Ethereum only).
+	ChainID *big.Int
+
+	// Params holds address encoding parameters (Bitcoin only).
+	Params *chaincfg.Params
+}
+
+var networks = map[string]map[string]*Network{
+	ChainETH: {
+		NetworkMainnet: {Chain: ChainETH, Name: NetworkMainnet, ChainID: big.NewInt(1)},
+		NetworkSepolia: {Chain: ChainETH, Name: NetworkSepolia, ChainID: big.NewInt(11155111)},
+		NetworkHolesky: {Chain: ChainETH, Name: NetworkHolesky, ChainID: big.NewInt(17000)},
+		NetworkDev:     {Chain: ChainETH, Name: NetworkDev, ChainID: big.NewInt(1337)},
+	},
+	ChainBTC: {
+		NetworkMainnet: {Chain: ChainBTC, Name: NetworkMainnet, Params: &chaincfg.MainNetParams},
+		NetworkTestnet: {Chain: ChainBTC, Name: NetworkTestnet, Params: &chaincfg.TestNet3Params},
+		NetworkSignet:  {Chain: ChainBTC, Name: NetworkSignet, Params: &chaincfg.SigNetParams},
+		NetworkRegtest: {Chain: ChainBTC, Name: NetworkRegtest, Params: &chaincfg.RegressionNetParams},
+	},
+}
+
+// LookupNetwork returns the network with the given name on the given chain.
+// An empty name selects mainnet.
+func LookupNetwork(chain, name string) (*Network, error) {
+	if name == "" {
+		name = NetworkMainnet
+	}
+
+	byName, ok := networks[chain]
+	if !ok {
+		return nil, fmt.Errorf("unsupported chain %q", chain)
+	}
+
+	network, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported %s network %q", chain, name)
+	}
+
+	return network, nil
+}
+
+// EthereumNetworkByChainID returns the Ethereum network with the given
+// EIP-155 chain ID.
+func EthereumNetworkByChainID(chainID *big.Int) (*Network, error) {
+	for _, network := range networks[ChainETH] {
+		if network.ChainID.Cmp(chainID) == 0 {
+			return network, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported %s chain id %s", ChainETH, chainID)
+}
+
+// CoinType returns the SLIP-44 coin type used in derivation paths.
+// Ethereum keeps coin type 60 on every network, as common wallets do.
+func (n *Network) CoinType() uint32 {
+	switch {
+	case n.Chain == ChainETH:
+		return CoinTypeETH
+	case n.Name == NetworkMainnet:
+		return CoinTypeBTC
+	default:
+		return CoinTypeTestnet
+	}
+}
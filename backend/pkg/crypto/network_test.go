@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupNetwork(t *testing.T) {
+	network, err := LookupNetwork(ChainETH, "")
+	require.NoError(t, err)
+	require.Equal(t, NetworkMainnet, network.Name)
+	require.EqualValues(t, 1, network.ChainID.Int64())
+
+	network, err = LookupNetwork(ChainETH, NetworkSepolia)
+	require.NoError(t, err)
+	require.EqualValues(t, 11155111, network.ChainID.Int64())
+
+	byId, err := EthereumNetworkByChainID(big.NewInt(17000))
+	require.NoError(t, err)
+	require.Equal(t, NetworkHolesky, byId.Name)
+
+	for _, tt := range []struct{ chain, name string }{
+		{ChainETH, NetworkTestnet},
+		{ChainBTC, NetworkSepolia},
+		{"DOGE", NetworkMainnet},
+	} {
+		_, err := LookupNetwork(tt.chain, tt.name)
+		require.Error(t, err, tt.chain+" "+tt.name)
+	}
+	_, err = EthereumNetworkByChainID(big.NewInt(56))
+	require.Error(t, err)
+}
+
+// Test network addresses of fixtureMnemonic from the BIP84, BIP49 and
+// BIP44 test vectors. Every Bitcoin test network uses coin type 1.
+func TestGenerateTestnetAddress(t *testing.T) {
+	svc := fixtureCryptoService(t)
+
+	tests := []struct {
+		network     string
+		addressType string
+		path        string
+		address     string
+	}{
+		{NetworkTestnet, AddressTypeP2WPKH, "m/84'/1'/0'/0/0", "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl"},
+		{NetworkTestnet, AddressTypeP2SHP2WPKH, "m/49'/1'/0'/0/0", "2Mww8dCYPUpKHofjgcXcBCEGmniw9CoaiD2"},
+		{NetworkTestnet, AddressTypeP2PKH, "m/44'/1'/0'/0/0", "mkpZhYtJu2r87Js3pDiWJDmPte2NRZ8bJV"},
+		{NetworkSignet, AddressTypeP2WPKH, "m/84'/1'/0'/0/0", "tb1q6rz28mcfaxtmd6v789l9rrlrusdprr9pqcpvkl"},
+		{NetworkRegtest, AddressTypeP2WPKH, "m/84'/1'/0'/0/0", "bcrt1q6rz28mcfaxtmd6v789l9rrlrusdprr9pz3cppk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network+" "+tt.path, func(t *testing.T) {
+			network, err := LookupNetwork(ChainBTC, tt.network)
+			require.NoError(t, err)
+			path, err := PathFor(network, tt.addressType, 0, 0, 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.path, path.String())
+
+			address, err := svc.GenerateAddress(fixtureMnemonic, "", network, path)
+			require.NoError(t, err)
+			require.Equal(t, tt.address, address)
+		})
+	}
+
+	// Ethereum testnets share mainnet's coin type and addresses
+	sepolia, err := LookupNetwork(ChainETH, NetworkSepolia)
+	require.NoError(t, err)
+	path, err := PathFor(sepolia, "", 0, 0, 0)
+	require.NoError(t, err)
+	address, err := svc.GenerateAddress(fixtureMnemonic, "", sepolia, path)
+	require.NoError(t, err)
+	require.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", address)
+}
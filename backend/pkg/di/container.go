@@ -3,14 +3,17 @@ package di
 import (
 	"context"
 	"os"
+	"time"
 
 	"github.com/create-go-app/fiber-go-template/app/controllers"
 	apprepos "github.com/create-go-app/fiber-go-template/app/interfaces/repositories"
 	"github.com/create-go-app/fiber-go-template/app/interfaces/services"
 	"github.com/create-go-app/fiber-go-template/app/repository"
 	serviceimpl "github.com/create-go-app/fiber-go-template/app/services"
+	"github.com/create-go-app/fiber-go-template/pkg/configs"
 	"github.com/create-go-app/fiber-go-template/pkg/crypto"
 	"github.com/create-go-app/fiber-go-template/pkg/middleware"
+	"github.com/create-go-app/fiber-go-template/platform/blockchain"
 	"github.com/create-go-app/fiber-go-template/platform/cache"
 	"github.com/create-go-app/fiber-go-template/platform/database"
 	"github.com/gofiber/fiber/v2"
@@ -18,16 +21,27 @@ import (
 )
 
 type Container struct {
-	DB               *gorm.DB
-	Cache            *cache.CacheService
-	UserRepo         apprepos.UserRepository
-	AuthService      services.AuthService
-	TokenService     services.TokenService
-	AuthController   *controllers.AuthController
-	TokenController  *controllers.TokenController
-	WalletService    services.WalletService
-	WalletController *controllers.WalletController
-	JWTMiddleware    func(*fiber.Ctx) error
+	DB                    *gorm.DB
+	Cache                 *cache.CacheService
+	UserRepo              apprepos.UserRepository
+	AuthService           services.AuthService
+	TokenService          services.TokenService
+	AuthController        *controllers.AuthController
+	TokenController       *controllers.TokenController
+	WalletService         services.WalletService
+	WalletController      *controllers.WalletController
+	TransactionService    services.TransactionService
+	TransactionController *controllers.TransactionController
+	EthClient             blockchain.ChainClient
+	BalanceService        services.BalanceService
+	BalanceController     *controllers.BalanceController
+	DepositWatcher        *serviceimpl.DepositWatcher
+	DepositWorker         *cache.Worker
+	Erc20TokenService     services.Erc20TokenService
+	Erc20TokenController  *controllers.Erc20TokenController
+	SpendingPolicyService services.SpendingPolicyService
+	PolicyController      *controllers.SpendingPolicyController
+	JWTMiddleware         func(*fiber.Ctx) error
 }
 
 func NewContainer(ctx context.Context) (*Container, error) {
@@ -55,28 +69,155 @@ func NewContainer(ctx context.Context) (*Container, error) {
 	}
 	jwtMiddleware := middleware.NewJWTProtected(jwtConfig)
 	// Wallet
-	cryptoService := crypto.NewCryptoService()
+	kek, err := configs.KEKProvider()
+	if err != nil {
+		return nil, err
+	}
+	cryptoService, err := crypto.NewCryptoService(crypto.Config{
+		FingerprintKey: []byte(os.Getenv("WALLET_FINGERPRINT_KEY")),
+		KEK:            kek,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// On-chain state
+	ethClient, err := configs.EthChainClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ethNetwork *crypto.Network
+	var nonceManager *blockchain.NonceManager
+	if ethClient != nil {
+		chainID, err := ethClient.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ethNetwork, err = crypto.EthereumNetworkByChainID(chainID); err != nil {
+			return nil, err
+		}
+		lease, err := configs.NonceLease()
+		if err != nil {
+			return nil, err
+		}
+		nonceManager = blockchain.NewNonceManager(cacheService, ethClient, ethNetwork.Name, lease)
+	}
+
+	// Owner notifications
+	notificationQueue, err := cache.NewMessageQueue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	notifier := serviceimpl.NewQueueNotifier(notificationQueue, userRepo)
+
+	allowlistDelay, err := configs.WithdrawalAddressDelay()
+	if err != nil {
+		return nil, err
+	}
+	approvalConfig, err := configs.WithdrawalApprovalConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	walletRepo := repository.NewWalletRepository(gormDB)
 	addressRepo := repository.NewBlockchainAddressRepository(gormDB)
+	auditRepo := repository.NewAuditEventRepository(gormDB)
+	tokenRepo := repository.NewErc20TokenRepository(gormDB)
+	withdrawalRepo := repository.NewWithdrawalAddressRepository(gormDB)
+	withdrawalRequestRepo := repository.NewWithdrawalRequestRepository(gormDB)
+	policyRepo := repository.NewSpendingPolicyRepository(gormDB)
 
 	walletService := serviceimpl.NewWalletService(
 		walletRepo,
 		addressRepo,
+		auditRepo,
+		tokenRepo,
+		withdrawalRepo,
+		withdrawalRequestRepo,
 		cryptoService,
 		txManager,
+		nonceManager,
+		notifier,
+		allowlistDelay,
+		approvalConfig,
+		policyRepo,
+		serviceimpl.NewSpendingLimiter(cacheService),
 	)
 
 	walletController := controllers.NewWalletController(walletService)
+
+	// Transaction ledger
+	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionService := serviceimpl.NewTransactionService(walletRepo, transactionRepo, tokenRepo)
+	transactionController := controllers.NewTransactionController(transactionService)
+
+	// Balances
+	balanceService := serviceimpl.NewBalanceService(walletRepo, tokenRepo, ethClient, ethNetwork, cacheService)
+	balanceController := controllers.NewBalanceController(balanceService)
+
+	// ERC-20 tokens
+	erc20TokenService := serviceimpl.NewErc20TokenService(tokenRepo)
+	erc20TokenController := controllers.NewErc20TokenController(erc20TokenService)
+
+	// Spending policies
+	policyService := serviceimpl.NewSpendingPolicyService(policyRepo, walletRepo, userRepo, auditRepo, txManager, notifier)
+	policyController := controllers.NewSpendingPolicyController(policyService)
+
+	// Deposits
+	var depositWatcher *serviceimpl.DepositWatcher
+	var depositWorker *cache.Worker
+	if ethClient != nil && configs.DepositWatcherEnabled() {
+		watcherConfig, err := configs.DepositWatcherConfig()
+		if err != nil {
+			return nil, err
+		}
+		depositQueue, err := cache.NewMessageQueue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		depositWatcher = serviceimpl.NewDepositWatcher(
+			ethClient,
+			ethNetwork,
+			addressRepo,
+			transactionRepo,
+			repository.NewScannedBlockRepository(gormDB),
+			tokenRepo,
+			txManager,
+			depositQueue,
+			cacheService,
+			watcherConfig,
+		)
+
+		// Polls are due every few seconds, so delayed ones are moved to
+		// the queue every second rather than the default 30.
+		if depositWorker, err = cache.NewWorker(ctx, serviceimpl.DepositQueueName(ethNetwork.Name), 1); err != nil {
+			return nil, err
+		}
+		depositWorker.SetDelayInterval(time.Second)
+		depositWorker.RegisterHandler(serviceimpl.DepositPollMsg, depositWatcher.HandlePoll)
+	}
+
 	return &Container{
-		DB:               gormDB,
-		Cache:            cacheService,
-		UserRepo:         userRepo,
-		AuthService:      authService,
-		TokenService:     tokenService,
-		AuthController:   authCtrl,
-		TokenController:  tokenCtrl,
-		JWTMiddleware:    jwtMiddleware,
-		WalletService:    walletService,
-		WalletController: walletController,
+		DB:                    gormDB,
+		Cache:                 cacheService,
+		UserRepo:              userRepo,
+		AuthService:           authService,
+		TokenService:          tokenService,
+		AuthController:        authCtrl,
+		TokenController:       tokenCtrl,
+		JWTMiddleware:         jwtMiddleware,
+		WalletService:         walletService,
+		WalletController:      walletController,
+		TransactionService:    transactionService,
+		TransactionController: transactionController,
+		EthClient:             ethClient,
+		BalanceService:        balanceService,
+		BalanceController:     balanceController,
+		DepositWatcher:        depositWatcher,
+		DepositWorker:         depositWorker,
+		Erc20TokenService:     erc20TokenService,
+		Erc20TokenController:  erc20TokenController,
+		SpendingPolicyService: policyService,
+		PolicyController:      policyController,
 	}, nil
 }
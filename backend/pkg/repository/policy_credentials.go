@@ -0,0 +1,6 @@
+package repository
+
+const (
+	// PolicyManageCredential const for managing users' spending policies.
+	PolicyManageCredential string = "policy:manage"
+)
@@ -0,0 +1,6 @@
+package repository
+
+const (
+	// TokenManageCredential const for adding tokens to the ERC-20 registry.
+	TokenManageCredential string = "token:manage"
+)
@@ -0,0 +1,7 @@
+package repository
+
+const (
+	// WithdrawalApproveCredential const for approving or rejecting other
+	// users' withdrawal requests.
+	WithdrawalApproveCredential string = "withdrawal:approve"
+)
@@ -2,11 +2,13 @@ package routes
 
 import (
 	"github.com/create-go-app/fiber-go-template/app/controllers"
+	"github.com/create-go-app/fiber-go-template/pkg/middleware"
+	"github.com/create-go-app/fiber-go-template/pkg/repository"
 	"github.com/gofiber/fiber/v2"
 )
 
 // PrivateRoutes func for describe group of private routes.
-func PrivateRoutes(a *fiber.App, jwtMiddleware func(*fiber.Ctx) error, auth *controllers.AuthController, token *controllers.TokenController, walletController *controllers.WalletController) {
+func PrivateRoutes(a *fiber.App, jwtMiddleware func(*fiber.Ctx) error, auth *controllers.AuthController, token *controllers.TokenController, walletController *controllers.WalletController, transactionController *controllers.TransactionController, balanceController *controllers.BalanceController, erc20TokenController *controllers.Erc20TokenController, policyController *controllers.SpendingPolicyController) {
 	// Create routes group.
 	route := a.Group("/api/v1")
 
@@ -14,6 +16,54 @@ func PrivateRoutes(a *fiber.App, jwtMiddleware func(*fiber.Ctx) error, auth *con
 	route.Post("/user/sign/out", jwtMiddleware, auth.UserSignOut)
 	route.Post("/token/renew", jwtMiddleware, token.RenewTokens)
 
+	// Routes for Wallet management:
+	route.Post("/wallet", jwtMiddleware, walletController.CreateWallet)
+	route.Post("/wallet/restore", jwtMiddleware, walletController.RestoreWallet)
+	route.Post("/wallet/watch-only", jwtMiddleware, walletController.ImportWatchOnlyWallet)
+	route.Get("/wallets", jwtMiddleware, walletController.GetWallets)
+	route.Get("/wallets/:id", jwtMiddleware, walletController.GetWallet)
+	route.Post("/wallets/:id/addresses", jwtMiddleware, walletController.DeriveAddress)
+	route.Put("/wallets/:id/passphrase", jwtMiddleware, walletController.ChangePassphrase)
+	route.Post("/wallets/:id/sign/transaction", jwtMiddleware, walletController.SignEthTransaction)
+	route.Post("/wallets/:id/sign/token-transfer", jwtMiddleware, walletController.SignTokenTransfer)
+	route.Post("/wallets/:id/eth/nonces/release", jwtMiddleware, walletController.ReleaseNonce)
+	route.Post("/wallets/:id/eth/nonces/commit", jwtMiddleware, walletController.CommitNonce)
+	route.Post("/wallets/:id/eth/nonces/resync", jwtMiddleware, walletController.ResyncNonce)
+	route.Post("/wallets/:id/sign/message", jwtMiddleware, walletController.SignMessage)
+	route.Post("/wallets/:id/sign/typed-data", jwtMiddleware, walletController.SignTypedData)
+	route.Post("/wallets/:id/btc/psbt/sign", jwtMiddleware, walletController.SignBtcPSBT)
+	route.Get("/wallets/:id/balances", jwtMiddleware, balanceController.GetBalances)
+	route.Get("/wallets/:id/allowlist", jwtMiddleware, walletController.GetAllowlist)
+	route.Post("/wallets/:id/allowlist", jwtMiddleware, walletController.AddWithdrawalAddress)
+	route.Put("/wallets/:id/allowlist/mode", jwtMiddleware, walletController.SetAllowlistMode)
+	route.Delete("/wallets/:id/allowlist/:addressId", jwtMiddleware, walletController.RemoveWithdrawalAddress)
+	route.Get("/wallets/:id/withdrawals", jwtMiddleware, walletController.ListWithdrawals)
+	route.Post("/wallets/:id/withdrawals", jwtMiddleware, walletController.CreateWithdrawal)
+	route.Get("/wallets/:id/withdrawals/:withdrawalId", jwtMiddleware, walletController.GetWithdrawal)
+	route.Post("/wallets/:id/withdrawals/:withdrawalId/execute", jwtMiddleware, walletController.ExecuteWithdrawal)
+	route.Get("/wallets/:id/policies", jwtMiddleware, policyController.ListWalletPolicies)
+
+	// Routes for withdrawal approvers:
+	route.Get("/withdrawals", jwtMiddleware, middleware.RequireCredentials(repository.WithdrawalApproveCredential), walletController.ListPendingWithdrawals)
+	route.Post("/withdrawals/:withdrawalId/approve", jwtMiddleware, middleware.RequireCredentials(repository.WithdrawalApproveCredential), walletController.ApproveWithdrawal)
+	route.Post("/withdrawals/:withdrawalId/reject", jwtMiddleware, middleware.RequireCredentials(repository.WithdrawalApproveCredential), walletController.RejectWithdrawal)
+
+	// Routes for the transaction ledger:
+	route.Post("/wallets/:id/transactions", jwtMiddleware, transactionController.RecordTransaction)
+	route.Get("/wallets/:id/transactions", jwtMiddleware, transactionController.ListTransactions)
+	route.Get("/wallets/:id/transactions/:txId", jwtMiddleware, transactionController.GetTransaction)
+	route.Put("/wallets/:id/transactions/:txId/status", jwtMiddleware, transactionController.UpdateTransactionStatus)
+
+	// Routes for the ERC-20 token registry:
+	route.Get("/tokens", jwtMiddleware, erc20TokenController.ListTokens)
+	route.Post("/tokens", jwtMiddleware, middleware.RequireCredentials(repository.TokenManageCredential), erc20TokenController.CreateToken)
+
+	// Routes for spending policy administrators:
+	route.Get("/policies", jwtMiddleware, middleware.RequireCredentials(repository.PolicyManageCredential), policyController.ListPolicies)
+	route.Post("/policies", jwtMiddleware, middleware.RequireCredentials(repository.PolicyManageCredential), policyController.CreatePolicy)
+	route.Put("/policies/:policyId", jwtMiddleware, middleware.RequireCredentials(repository.PolicyManageCredential), policyController.UpdatePolicy)
+	route.Delete("/policies/:policyId", jwtMiddleware, middleware.RequireCredentials(repository.PolicyManageCredential), policyController.DeletePolicy)
+
 	// Routes for Task management:
 	// route.Post("/task", jwtMiddleware, mw.RequireCredentials(repository.TaskCreateCredential), task.CreateTask)
 	// route.Put("/task/:id", jwtMiddleware, mw.RequireCredentials(repository.TaskUpdateCredential), task.UpdateTask)
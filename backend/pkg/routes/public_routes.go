@@ -6,14 +6,13 @@ import (
 )
 
 // PublicRoutes func for describe group of public routes.
-func PublicRoutes(a *fiber.App, auth *controllers.AuthController, wallet *controllers.WalletController) {
+func PublicRoutes(a *fiber.App, auth *controllers.AuthController, walletController *controllers.WalletController) {
 	// Create routes group.
 	route := a.Group("/api/v1")
 
 	// Routes for POST method:
 	route.Post("/user/sign/up", auth.UserSignUp)
 	route.Post("/user/sign/in", auth.UserSignIn)
-	route.Post("/wallet", wallet.CreateWallet)
-	route.Post("/wallet/restore", wallet.RestoreWallet)
+	route.Post("/signatures/verify", walletController.VerifySignature)
 
 }
@@ -0,0 +1,77 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/create-go-app/fiber-go-template/pkg/repository"
+	"github.com/create-go-app/fiber-go-template/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletRoutesNeedJWT(t *testing.T) {
+	app := fiber.New()
+	denied := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusUnauthorized)
+	}
+	PublicRoutes(app, nil, nil)
+	PrivateRoutes(app, denied, nil, nil, nil, nil, nil, nil, nil)
+
+	for _, route := range []struct{ method, path string }{
+		{fiber.MethodPost, "/api/v1/wallet"},
+		{fiber.MethodPost, "/api/v1/wallet/restore"},
+		{fiber.MethodGet, "/api/v1/wallets"},
+		{fiber.MethodGet, "/api/v1/wallets/wallet-1"},
+	} {
+		res, err := app.Test(httptest.NewRequest(route.method, route.path, nil))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusUnauthorized, res.StatusCode, route.method+" "+route.path)
+	}
+}
+
+func TestApproverRoutesNeedWithdrawalApproveCredential(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	t.Setenv("JWT_SECRET_KEY_EXPIRE_MINUTES_COUNT", "15")
+
+	app := fiber.New()
+	passed := func(c *fiber.Ctx) error {
+		return c.Next()
+	}
+	PrivateRoutes(app, passed, nil, nil, nil, nil, nil, nil, nil)
+
+	// Users cannot approve, moderators and admins can
+	for role, allowed := range map[string]bool{
+		repository.UserRoleName:      false,
+		repository.ModeratorRoleName: true,
+		repository.AdminRoleName:     true,
+	} {
+		credentials, err := utils.GetCredentialsByRole(role)
+		require.NoError(t, err)
+		require.Equal(t, allowed, slices.Contains(credentials, repository.WithdrawalApproveCredential), role)
+	}
+
+	credentials, err := utils.GetCredentialsByRole(repository.UserRoleName)
+	require.NoError(t, err)
+	tokens, err := utils.GenerateNewTokens(uuid.NewString(), credentials)
+	require.NoError(t, err)
+
+	for _, route := range []struct{ method, path string }{
+		{fiber.MethodGet, "/api/v1/withdrawals"},
+		{fiber.MethodPost, "/api/v1/withdrawals/withdrawal-1/approve"},
+		{fiber.MethodPost, "/api/v1/withdrawals/withdrawal-1/reject"},
+	} {
+		req := httptest.NewRequest(route.method, route.path, nil)
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusUnauthorized, res.StatusCode, route.method+" "+route.path)
+
+		req = httptest.NewRequest(route.method, route.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tokens.Access)
+		res, err = app.Test(req)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusForbidden, res.StatusCode, route.method+" "+route.path)
+	}
+}
@@ -22,12 +22,16 @@ func GetCredentialsByRole(role string) ([]string, error) {
 			repository.TaskViewCredential,
 			repository.HistoryCreateCredential,
 			repository.HistoryViewCredential,
+			repository.TokenManageCredential,
+			repository.WithdrawalApproveCredential,
+			repository.PolicyManageCredential,
 		}
 	case repository.ModeratorRoleName:
 		credentials = []string{
 			repository.TaskCreateCredential,
 			repository.TaskUpdateCredential,
 			repository.TaskViewCredential,
+			repository.WithdrawalApproveCredential,
 		}
 	case repository.UserRoleName:
 		credentials = []string{
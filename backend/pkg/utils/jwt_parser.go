@@ -37,13 +37,16 @@ func ExtractTokenMetadata(c *fiber.Ctx) (*TokenMetadata, error) {
 
 		// User credentials.
 		credentials := map[string]bool{
-			"book:create": getClaimBool(claims, "book:create"),
-			"book:update": getClaimBool(claims, "book:update"),
-			"book:delete": getClaimBool(claims, "book:delete"),
-			"task:create": getClaimBool(claims, "task:create"),
-			"task:update": getClaimBool(claims, "task:update"),
-			"task:delete": getClaimBool(claims, "task:delete"),
-			"task:view":   getClaimBool(claims, "task:view"),
+			"book:create":        getClaimBool(claims, "book:create"),
+			"book:update":        getClaimBool(claims, "book:update"),
+			"book:delete":        getClaimBool(claims, "book:delete"),
+			"task:create":        getClaimBool(claims, "task:create"),
+			"task:update":        getClaimBool(claims, "task:update"),
+			"task:delete":        getClaimBool(claims, "task:delete"),
+			"task:view":          getClaimBool(claims, "task:view"),
+			"token:manage":       getClaimBool(claims, "token:manage"),
+			"withdrawal:approve": getClaimBool(claims, "withdrawal:approve"),
+			"policy:manage":      getClaimBool(claims, "policy:manage"),
 		}
 
 		return &TokenMetadata{
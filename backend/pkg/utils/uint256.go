@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var errInvalidUint256 = errors.New("not an unsigned 256-bit integer")
+
+// ParseUint256 parses a decimal or 0x-prefixed hex string into a big.Int
+// in [0, 2^256). Leading zeros are read as decimal, never as octal.
+func ParseUint256(s string) (*big.Int, error) {
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	if s == "" || strings.ContainsAny(s, "+-_") {
+		return nil, errInvalidUint256
+	}
+
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok || n.BitLen() > 256 {
+		return nil, errInvalidUint256
+	}
+	return n, nil
+}
@@ -19,6 +19,13 @@ func NewValidator() *validator.Validate {
 		return false
 	})
 
+	// Custom validation for unsigned 256-bit integers given as decimal or
+	// 0x-prefixed hex strings (wei amounts, gas prices).
+	_ = validate.RegisterValidation("uint256", func(fl validator.FieldLevel) bool {
+		_, err := ParseUint256(fl.Field().String())
+		return err == nil
+	})
+
 	return validate
 }
 
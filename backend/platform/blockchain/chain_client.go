@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ChainClient is the view of an Ethereum node the wallet needs: blocks,
+// account state, fee data, calls and transaction submission. A nil block
+// number means the latest block.
+type ChainClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+
+	// SendRawTransaction submits a signed, RLP or EIP-2718 encoded
+	// transaction and returns its hash.
+	SendRawTransaction(ctx context.Context, raw []byte) (common.Hash, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+
+	Close()
+}
+
+// backend is the part of go-ethereum's client API shared by
+// [ethclient.Client] and the simulated backend's client.
+type backend interface {
+	ethereum.ChainIDReader
+	ethereum.BlockNumberReader
+	ethereum.ChainStateReader
+	ethereum.PendingStateReader
+	ethereum.GasPricer
+	ethereum.GasPricer1559
+	ethereum.FeeHistoryReader
+	ethereum.GasEstimator
+	ethereum.ContractCaller
+	ethereum.TransactionSender
+	ethereum.TransactionReader
+	ethereum.LogFilterer
+
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// client implements [ChainClient] over a go-ethereum client.
+type client struct {
+	backend
+}
+
+// SendRawTransaction implements [ChainClient].
+func (c client) SendRawTransaction(ctx context.Context, raw []byte) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+	if err := c.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
@@ -0,0 +1,176 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNotERC20Transfer is returned for a log or calldata that is not an
+// ERC-20 transfer.
+var ErrNotERC20Transfer = errors.New("not an erc20 transfer log")
+
+// erc20ABIJSON is the part of the ERC-20 interface the wallet uses.
+const erc20ABIJSON = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view",
+	 "inputs":[{"name":"owner","type":"address"}],
+	 "outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable",
+	 "inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable",
+	 "inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable",
+	 "inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"increaseAllowance","stateMutability":"nonpayable",
+	 "inputs":[{"name":"spender","type":"address"},{"name":"addedValue","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,
+	 "inputs":[{"name":"from","type":"address","indexed":true},
+	           {"name":"to","type":"address","indexed":true},
+	           {"name":"value","type":"uint256","indexed":false}]}
+]`
+
+var erc20ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(erc20ABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ERC20TransferTopic is the topic of Transfer(address,address,uint256).
+var ERC20TransferTopic = erc20ABI.Events["Transfer"].ID
+
+// ERC-20 calls that move tokens or let another account move them.
+const (
+	ERC20MethodTransfer          = "transfer"
+	ERC20MethodTransferFrom      = "transferFrom"
+	ERC20MethodApprove           = "approve"
+	ERC20MethodIncreaseAllowance = "increaseAllowance"
+)
+
+// ERC20Call is decoded calldata of one of the ERC-20 methods above.
+// Recipient is who receives the tokens, or the spender of an allowance;
+// From is only set by transferFrom.
+type ERC20Call struct {
+	Method    string
+	From      *common.Address
+	Recipient common.Address
+	Value     *big.Int
+}
+
+// ERC20Transfer is a decoded Transfer event.
+type ERC20Transfer struct {
+	Token common.Address
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+// PackERC20Transfer returns the calldata of transfer(to, value).
+func PackERC20Transfer(to common.Address, value *big.Int) ([]byte, error) {
+	return erc20ABI.Pack("transfer", to, value)
+}
+
+// UnpackERC20Transfer decodes the recipient and value of transfer(to, value)
+// calldata. Other calls yield [ErrNotERC20Transfer].
+func UnpackERC20Transfer(data []byte) (common.Address, *big.Int, error) {
+	method := erc20ABI.Methods["transfer"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return common.Address{}, nil, ErrNotERC20Transfer
+	}
+
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("%w: %v", ErrNotERC20Transfer, err)
+	}
+	return values[0].(common.Address), values[1].(*big.Int), nil
+}
+
+// UnpackERC20Call decodes transfer, transferFrom, approve or
+// increaseAllowance calldata. Other calls yield [ErrNotERC20Transfer].
+func UnpackERC20Call(data []byte) (*ERC20Call, error) {
+	if len(data) < 4 {
+		return nil, ErrNotERC20Transfer
+	}
+
+	for _, name := range []string{
+		ERC20MethodTransfer,
+		ERC20MethodTransferFrom,
+		ERC20MethodApprove,
+		ERC20MethodIncreaseAllowance,
+	} {
+		method := erc20ABI.Methods[name]
+		if !bytes.Equal(data[:4], method.ID) {
+			continue
+		}
+
+		values, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotERC20Transfer, err)
+		}
+
+		call := &ERC20Call{Method: name}
+		if name == ERC20MethodTransferFrom {
+			from := values[0].(common.Address)
+			call.From = &from
+			values = values[1:]
+		}
+		call.Recipient = values[0].(common.Address)
+		call.Value = values[1].(*big.Int)
+		return call, nil
+	}
+
+	return nil, ErrNotERC20Transfer
+}
+
+// ERC20BalanceOf reads balanceOf(owner) of token at the latest block.
+func ERC20BalanceOf(ctx context.Context, client ChainClient, token, owner common.Address) (*big.Int, error) {
+	data, err := erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := erc20ABI.Unpack("balanceOf", out)
+	if err != nil {
+		return nil, fmt.Errorf("decode balanceOf of %s: %w", token.Hex(), err)
+	}
+	return values[0].(*big.Int), nil
+}
+
+// ParseERC20Transfer decodes a Transfer log. ERC-721 transfers share the
+// event signature but index the token id, so they are rejected by their
+// topic count.
+func ParseERC20Transfer(log types.Log) (*ERC20Transfer, error) {
+	if len(log.Topics) != 3 || log.Topics[0] != ERC20TransferTopic {
+		return nil, ErrNotERC20Transfer
+	}
+
+	values, err := erc20ABI.Unpack("Transfer", log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotERC20Transfer, err)
+	}
+
+	return &ERC20Transfer{
+		Token: log.Address,
+		From:  common.BytesToAddress(log.Topics[1].Bytes()),
+		To:    common.BytesToAddress(log.Topics[2].Bytes()),
+		Value: values[0].(*big.Int),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	erc20Token   = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	erc20Holder  = common.HexToAddress("0x00000000000000000000000000000000000A11cE")
+	erc20Payee   = common.HexToAddress("0x0000000000000000000000000000000000000B0b")
+	erc20Spender = common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+)
+
+func TestPackERC20Transfer(t *testing.T) {
+	data, err := PackERC20Transfer(erc20Payee, big.NewInt(1_500_000))
+	require.NoError(t, err)
+	require.Equal(t, "0xa9059cbb"+
+		"0000000000000000000000000000000000000000000000000000000000000b0b"+
+		"000000000000000000000000000000000000000000000000000000000016e360", hexutil.Encode(data))
+
+	to, value, err := UnpackERC20Transfer(data)
+	require.NoError(t, err)
+	require.Equal(t, erc20Payee, to)
+	require.EqualValues(t, 1_500_000, value.Int64())
+
+	_, _, err = UnpackERC20Transfer(data[:4])
+	require.ErrorIs(t, err, ErrNotERC20Transfer)
+}
+
+func TestUnpackERC20Call(t *testing.T) {
+	tests := []struct {
+		method string
+		args   []any
+		want   ERC20Call
+	}{
+		{ERC20MethodTransfer, []any{erc20Payee, big.NewInt(5)}, ERC20Call{Method: ERC20MethodTransfer, Recipient: erc20Payee, Value: big.NewInt(5)}},
+		{ERC20MethodTransferFrom, []any{erc20Holder, erc20Payee, big.NewInt(6)}, ERC20Call{Method: ERC20MethodTransferFrom, From: &erc20Holder, Recipient: erc20Payee, Value: big.NewInt(6)}},
+		{ERC20MethodApprove, []any{erc20Spender, math.MaxBig256}, ERC20Call{Method: ERC20MethodApprove, Recipient: erc20Spender, Value: math.MaxBig256}},
+		{ERC20MethodIncreaseAllowance, []any{erc20Spender, big.NewInt(7)}, ERC20Call{Method: ERC20MethodIncreaseAllowance, Recipient: erc20Spender, Value: big.NewInt(7)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			data, err := erc20ABI.Pack(tt.method, tt.args...)
+			require.NoError(t, err)
+
+			call, err := UnpackERC20Call(data)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, *call)
+		})
+	}
+
+	// Other calls, and ERC-20 calls cut short, are not token movements
+	balanceOf, err := erc20ABI.Pack("balanceOf", erc20Holder)
+	require.NoError(t, err)
+	transfer, err := PackERC20Transfer(erc20Payee, big.NewInt(1))
+	require.NoError(t, err)
+	for _, data := range [][]byte{nil, {0xa9, 0x05}, balanceOf, transfer[:36]} {
+		_, err := UnpackERC20Call(data)
+		require.ErrorIs(t, err, ErrNotERC20Transfer)
+	}
+}
+
+func TestParseERC20Transfer(t *testing.T) {
+	value := common.BigToHash(big.NewInt(1_000_000))
+	log := types.Log{
+		Address: erc20Token,
+		Topics:  []common.Hash{ERC20TransferTopic, common.BytesToHash(erc20Holder.Bytes()), common.BytesToHash(erc20Payee.Bytes())},
+		Data:    value.Bytes(),
+	}
+	require.Equal(t, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", ERC20TransferTopic.Hex())
+
+	transfer, err := ParseERC20Transfer(log)
+	require.NoError(t, err)
+	require.Equal(t, ERC20Transfer{Token: erc20Token, From: erc20Holder, To: erc20Payee, Value: big.NewInt(1_000_000)}, *transfer)
+
+	// ERC-721 indexes the token id as a fourth topic
+	nft := log
+	nft.Topics = append(append([]common.Hash{}, log.Topics...), value)
+	nft.Data = nil
+	_, err = ParseERC20Transfer(nft)
+	require.ErrorIs(t, err, ErrNotERC20Transfer)
+
+	other := log
+	other.Topics = append([]common.Hash{{1}}, log.Topics[1:]...)
+	_, err = ParseERC20Transfer(other)
+	require.ErrorIs(t, err, ErrNotERC20Transfer)
+}
+
+func TestERC20BalanceOf(t *testing.T) {
+	node := &erc20Node{balance: big.NewInt(42)}
+
+	balance, err := ERC20BalanceOf(context.Background(), node, erc20Token, erc20Holder)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, balance.Int64())
+
+	// The call goes to the token, asking for the holder's balance
+	require.Equal(t, erc20Token, *node.call.To)
+	want, err := erc20ABI.Pack("balanceOf", erc20Holder)
+	require.NoError(t, err)
+	require.Equal(t, want, node.call.Data)
+
+	// A contract without balanceOf returns nothing to decode
+	node.balance = nil
+	_, err = ERC20BalanceOf(context.Background(), node, erc20Token, erc20Holder)
+	require.Error(t, err)
+}
+
+// erc20Node answers balanceOf calls with balance, or with empty output
+// when it is nil.
+type erc20Node struct {
+	ChainClient
+
+	balance *big.Int
+	call    ethereum.CallMsg
+}
+
+func (n *erc20Node) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	n.call = msg
+	if n.balance == nil {
+		return nil, nil
+	}
+	return common.BigToHash(n.balance).Bytes(), nil
+}
@@ -0,0 +1,27 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// EthClient is a [ChainClient] talking JSON-RPC to an Ethereum node.
+type EthClient struct {
+	client
+	rpc *ethclient.Client
+}
+
+// DialEthClient connects to the node at rawURL (http, https, ws or ipc).
+func DialEthClient(ctx context.Context, rawURL string) (*EthClient, error) {
+	rpc, err := ethclient.DialContext(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &EthClient{client: client{rpc}, rpc: rpc}, nil
+}
+
+// Close implements [ChainClient].
+func (c *EthClient) Close() {
+	c.rpc.Close()
+}
@@ -0,0 +1,258 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNonceNotReserved is returned when a nonce is committed or released
+// without a live reservation, e.g. after its lease expired.
+var ErrNonceNotReserved = errors.New("nonce is not reserved")
+
+// maxReserveAttempts bounds the resyncs of a single Reserve call.
+const maxReserveAttempts = 3
+
+// nonceStateTTL drops the state of idle addresses; the next reservation
+// then starts again from the chain.
+const nonceStateTTL = 24 * time.Hour
+
+var nonceKeys = cache.NewCacheBuilder("nonce")
+
+// Per address the scripts keep three keys in one hash slot:
+//
+//   - next: the lowest nonce never handed out.
+//   - free: released nonces below next, to be handed out first (sorted
+//     set scored by nonce).
+//   - leases: reserved nonces, scored by lease deadline in Unix ms.
+//
+// reserve hands out the lowest free nonce, or next, after freeing expired
+// leases. It returns nil when next is unknown and a resync is needed.
+var reserveNonceScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+for _, n in ipairs(redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', now)) do
+	redis.call('ZREM', KEYS[3], n)
+	redis.call('ZADD', KEYS[2], n, n)
+end
+
+local nonce
+local free = redis.call('ZPOPMIN', KEYS[2])
+if #free > 0 then
+	nonce = free[1]
+else
+	nonce = redis.call('GET', KEYS[1])
+	if not nonce then
+		return false
+	end
+	redis.call('INCR', KEYS[1])
+end
+
+redis.call('ZADD', KEYS[3], now + tonumber(ARGV[2]), nonce)
+for i = 1, 3 do
+	redis.call('EXPIRE', KEYS[i], ARGV[3])
+end
+return tonumber(nonce)
+`)
+
+// commit ends the lease of a nonce that was broadcast.
+var commitNonceScript = redis.NewScript(`
+return redis.call('ZREM', KEYS[3], ARGV[1])
+`)
+
+// release returns a leased nonce to the pool. Free nonces directly below
+// next are folded back into it, so the pool only holds real gaps.
+var releaseNonceScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[3], ARGV[1]) == 0 then
+	return 0
+end
+
+local nonce = tonumber(ARGV[1])
+local next = tonumber(redis.call('GET', KEYS[1]))
+if next and nonce == next - 1 then
+	next = nonce
+	while redis.call('ZSCORE', KEYS[2], tostring(next - 1)) do
+		redis.call('ZREM', KEYS[2], tostring(next - 1))
+		next = next - 1
+	end
+	redis.call('SET', KEYS[1], next)
+else
+	redis.call('ZADD', KEYS[2], nonce, nonce)
+end
+return 1
+`)
+
+// resync aligns the state with the chain's pending nonce ARGV[1]: nonces
+// below it are used, so they leave the pool and their leases end. With
+// ARGV[2] set and no lease outstanding, next drops back to the pending
+// nonce, refilling the nonces that were handed out but never reached the
+// node.
+var resyncNonceScript = redis.NewScript(`
+local pending = tonumber(ARGV[1])
+redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', '(' .. pending)
+for _, n in ipairs(redis.call('ZRANGE', KEYS[3], 0, -1)) do
+	if tonumber(n) < pending then
+		redis.call('ZREM', KEYS[3], n)
+	end
+end
+
+local next = tonumber(redis.call('GET', KEYS[1]))
+if not next or next < pending then
+	next = pending
+elseif ARGV[2] == '1' and redis.call('ZCARD', KEYS[3]) == 0 then
+	next = pending
+	redis.call('DEL', KEYS[2])
+end
+
+redis.call('SET', KEYS[1], next)
+for i = 1, 3 do
+	redis.call('EXPIRE', KEYS[i], ARGV[3])
+end
+return next
+`)
+
+// NonceManager hands out the nonces of Ethereum accounts to concurrent
+// signers across API replicas. Each reservation is leased: commit it once
+// the transaction is broadcast, or release it when the transaction is
+// abandoned so the nonce is reused instead of leaving a gap. Leases that
+// run out are released automatically.
+//
+// State lives in Redis and is changed only by Lua scripts, so it works in
+// standalone, Sentinel and cluster mode alike. The chain's pending nonce
+// stays the source of truth: no nonce below it is ever handed out.
+type NonceManager struct {
+	cacheService *cache.CacheService
+	ethClient    ChainClient
+	network      string
+	lease        time.Duration
+}
+
+// NewNonceManager allocates nonces for accounts on network, whose node is
+// ethClient. lease is how long a reservation may stay uncommitted.
+func NewNonceManager(
+	cacheService *cache.CacheService,
+	ethClient ChainClient,
+	network string,
+	lease time.Duration,
+) *NonceManager {
+	return &NonceManager{
+		cacheService: cacheService,
+		ethClient:    ethClient,
+		network:      network,
+		lease:        lease,
+	}
+}
+
+// Network returns the name of the network the nonces are for.
+func (m *NonceManager) Network() string {
+	return m.network
+}
+
+// Reserve leases the next nonce of account.
+func (m *NonceManager) Reserve(ctx context.Context, account common.Address) (uint64, error) {
+	keys := m.keys(account)
+
+	for attempt := 0; attempt < maxReserveAttempts; attempt++ {
+		nonce, err := m.cacheService.RunScript(
+			ctx, reserveNonceScript, keys,
+			time.Now().UnixMilli(), m.lease.Milliseconds(), int(nonceStateTTL.Seconds()),
+		).Uint64()
+		if errors.Is(err, redis.Nil) {
+			if _, err := m.resync(ctx, account, false); err != nil {
+				return 0, err
+			}
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("reserve nonce: %w", err)
+		}
+
+		pending, err := m.ethClient.PendingNonceAt(ctx, account)
+		if err != nil {
+			return 0, fmt.Errorf("read pending nonce: %w", err)
+		}
+		if nonce >= pending {
+			return nonce, nil
+		}
+
+		// Used on chain meanwhile, e.g. by a lease that expired after its
+		// transaction was broadcast. Drop it and everything below.
+		if _, err := m.syncTo(ctx, account, pending, false); err != nil {
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("reserve nonce: state of %s keeps changing", account.Hex())
+}
+
+// Commit ends the lease of a nonce whose transaction was broadcast.
+func (m *NonceManager) Commit(ctx context.Context, account common.Address, nonce uint64) error {
+	removed, err := m.cacheService.RunScript(ctx, commitNonceScript, m.keys(account), nonce).Int()
+	if err != nil {
+		return fmt.Errorf("commit nonce: %w", err)
+	}
+	if removed == 0 {
+		return ErrNonceNotReserved
+	}
+	return nil
+}
+
+// Release gives back a nonce whose transaction will not be broadcast.
+// The nonce is handed out again before any higher one.
+func (m *NonceManager) Release(ctx context.Context, account common.Address, nonce uint64) error {
+	released, err := m.cacheService.RunScript(ctx, releaseNonceScript, m.keys(account), nonce).Int()
+	if err != nil {
+		return fmt.Errorf("release nonce: %w", err)
+	}
+	if released == 0 {
+		return ErrNonceNotReserved
+	}
+	return nil
+}
+
+// Resync realigns account with the chain's pending nonce and returns the
+// next nonce to hand out. When no reservation is outstanding, nonces that
+// were handed out but never reached the node are handed out again.
+func (m *NonceManager) Resync(ctx context.Context, account common.Address) (uint64, error) {
+	return m.resync(ctx, account, true)
+}
+
+func (m *NonceManager) resync(ctx context.Context, account common.Address, fillGaps bool) (uint64, error) {
+	pending, err := m.ethClient.PendingNonceAt(ctx, account)
+	if err != nil {
+		return 0, fmt.Errorf("read pending nonce: %w", err)
+	}
+	return m.syncTo(ctx, account, pending, fillGaps)
+}
+
+func (m *NonceManager) syncTo(ctx context.Context, account common.Address, pending uint64, fillGaps bool) (uint64, error) {
+	fill := "0"
+	if fillGaps {
+		fill = "1"
+	}
+
+	next, err := m.cacheService.RunScript(
+		ctx, resyncNonceScript, m.keys(account),
+		pending, fill, int(nonceStateTTL.Seconds()),
+	).Uint64()
+	if err != nil {
+		return 0, fmt.Errorf("resync nonce: %w", err)
+	}
+	return next, nil
+}
+
+// keys returns the next, free and leases keys of account. The braces make
+// Redis Cluster place all three in the same slot.
+func (m *NonceManager) keys(account common.Address) []string {
+	tag := "{" + m.network + ":" + strings.ToLower(account.Hex()) + "}"
+	return []string{
+		nonceKeys.Key(tag, "next"),
+		nonceKeys.Key(tag, "free"),
+		nonceKeys.Key(tag, "leases"),
+	}
+}
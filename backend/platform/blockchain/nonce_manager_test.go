@@ -0,0 +1,187 @@
+package blockchain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/create-go-app/fiber-go-template/platform/cache"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManagerReserveReleaseRefill(t *testing.T) {
+	ctx := context.Background()
+	chain := newNonceChain(t)
+	manager := chain.manager(t, time.Minute)
+
+	// 1️⃣ Nonces start at the chain's pending nonce and go up
+	for want := uint64(0); want < 3; want++ {
+		nonce, err := manager.Reserve(ctx, chain.account)
+		require.NoError(t, err)
+		require.Equal(t, want, nonce)
+	}
+
+	// 2️⃣ A released nonce in the middle is handed out before new ones
+	require.NoError(t, manager.Release(ctx, chain.account, 1))
+	requireReserve(t, manager, chain.account, 1)
+	requireReserve(t, manager, chain.account, 3)
+
+	// 3️⃣ Released nonces at the top fold back into the counter
+	require.NoError(t, manager.Release(ctx, chain.account, 3))
+	require.NoError(t, manager.Release(ctx, chain.account, 2))
+	requireReserve(t, manager, chain.account, 2)
+	requireReserve(t, manager, chain.account, 3)
+
+	// 4️⃣ Only live leases can be committed or released, once
+	require.NoError(t, manager.Commit(ctx, chain.account, 0))
+	require.ErrorIs(t, manager.Commit(ctx, chain.account, 0), ErrNonceNotReserved)
+	require.ErrorIs(t, manager.Release(ctx, chain.account, 0), ErrNonceNotReserved)
+	require.ErrorIs(t, manager.Release(ctx, chain.account, 9), ErrNonceNotReserved)
+}
+
+func TestNonceManagerExpiredLeaseIsReused(t *testing.T) {
+	ctx := context.Background()
+	chain := newNonceChain(t)
+	manager := chain.manager(t, time.Millisecond)
+
+	// A lease that runs out uncommitted frees its nonce for the next
+	// reservation
+	requireReserve(t, manager, chain.account, 0)
+	time.Sleep(5 * time.Millisecond)
+	requireReserve(t, manager, chain.account, 0)
+
+	require.NoError(t, manager.Commit(ctx, chain.account, 0))
+	requireReserve(t, manager, chain.account, 1)
+}
+
+func TestNonceManagerResync(t *testing.T) {
+	ctx := context.Background()
+	chain := newNonceChain(t)
+	manager := chain.manager(t, time.Minute)
+
+	// 1️⃣ Committed nonces that never reached the node are refilled once
+	// no lease is outstanding
+	for want := uint64(0); want < 2; want++ {
+		requireReserve(t, manager, chain.account, want)
+		require.NoError(t, manager.Commit(ctx, chain.account, want))
+	}
+	requireReserve(t, manager, chain.account, 2)
+
+	next, err := manager.Resync(ctx, chain.account)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, next, "an outstanding lease keeps the counter")
+
+	require.NoError(t, manager.Commit(ctx, chain.account, 2))
+	next, err = manager.Resync(ctx, chain.account)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, next)
+
+	// 2️⃣ Nonces used on chain are never handed out again, even when the
+	// allocator did not see them go
+	chain.sendWithNonce(t, 0)
+	chain.sendWithNonce(t, 1)
+	chain.Commit()
+
+	requireReserve(t, manager, chain.account, 2)
+
+	next, err = manager.Resync(ctx, chain.account)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, next)
+}
+
+func TestNonceManagerSeparatesNetworks(t *testing.T) {
+	ctx := context.Background()
+	chain := newNonceChain(t)
+	manager := chain.manager(t, time.Minute)
+	other := NewNonceManager(manager.cacheService, chain, "other", time.Minute)
+
+	requireReserve(t, manager, chain.account, 0)
+	requireReserve(t, other, chain.account, 0)
+	require.Equal(t, "other", other.Network())
+	require.ErrorIs(t, other.Commit(ctx, chain.account, 1), ErrNonceNotReserved)
+}
+
+func TestNonceManagerStopsWithTheRequest(t *testing.T) {
+	chain := newNonceChain(t)
+	manager := chain.manager(t, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := manager.Reserve(ctx, chain.account)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, manager.Release(ctx, chain.account, 0), context.Canceled)
+}
+
+// nonceChain is a simulated chain with a funded account whose nonces are
+// managed.
+type nonceChain struct {
+	*SimulatedClient
+	key     *ecdsa.PrivateKey
+	account common.Address
+}
+
+func newNonceChain(t *testing.T) *nonceChain {
+	t.Helper()
+
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	account := ethcrypto.PubkeyToAddress(key.PublicKey)
+
+	client := NewSimulatedClient(types.GenesisAlloc{
+		account: {Balance: big.NewInt(params.Ether)},
+	})
+	t.Cleanup(client.Close)
+
+	return &nonceChain{SimulatedClient: client, key: key, account: account}
+}
+
+// manager returns a nonce manager over a fresh in-memory Redis.
+func (c *nonceChain) manager(t *testing.T, lease time.Duration) *NonceManager {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewNonceManager(cache.NewCacheServiceWithClient(context.Background(), client), c, "simulated", lease)
+}
+
+// sendWithNonce submits a transfer from the account, bypassing the
+// manager as another wallet holding the same key would.
+func (c *nonceChain) sendWithNonce(t *testing.T, nonce uint64) {
+	t.Helper()
+
+	chainID := big.NewInt(SimulatedChainID)
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx, err := types.SignNewTx(c.key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       params.TxGas,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+	require.NoError(t, err)
+
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	_, err = c.SendRawTransaction(context.Background(), raw)
+	require.NoError(t, err)
+}
+
+func requireReserve(t *testing.T, manager *NonceManager, account common.Address, want uint64) {
+	t.Helper()
+
+	nonce, err := manager.Reserve(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, want, nonce)
+}
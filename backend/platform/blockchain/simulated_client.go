@@ -0,0 +1,39 @@
+package blockchain
+
+import (
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+// SimulatedChainID is the chain ID of every simulated backend.
+const SimulatedChainID = 1337
+
+// SimulatedClient is a [ChainClient] over an in-memory chain, so code
+// that reads balances or sends transactions can run without a node.
+// Blocks are only mined on [SimulatedClient.Commit].
+type SimulatedClient struct {
+	client
+	Backend *simulated.Backend
+}
+
+// NewSimulatedClient starts an in-memory chain whose genesis funds the
+// accounts in alloc.
+func NewSimulatedClient(alloc types.GenesisAlloc) *SimulatedClient {
+	backend := simulated.NewBackend(alloc)
+	return &SimulatedClient{client: client{backend.Client()}, Backend: backend}
+}
+
+// Commit mines the pending transactions into a new block.
+func (c *SimulatedClient) Commit() common.Hash {
+	return c.Backend.Commit()
+}
+
+// Close implements [ChainClient].
+func (c *SimulatedClient) Close() {
+	if err := c.Backend.Close(); err != nil {
+		log.Printf("simulated backend: %v", err)
+	}
+}
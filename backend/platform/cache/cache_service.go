@@ -28,6 +28,15 @@ func NewCacheService(ctx context.Context) (*CacheService, error) {
 	}, nil
 }
 
+// NewCacheServiceWithClient wraps an existing Redis client, such as one
+// connected to an in-memory server in tests.
+func NewCacheServiceWithClient(ctx context.Context, client redis.UniversalClient) *CacheService {
+	return &CacheService{
+		client: &RedisClient{Client: client, connectType: "standalone"},
+		ctx:    ctx,
+	}
+}
+
 // Set stores a value in cache with expiration
 func (cs *CacheService) Set(key string, value interface{}, expiration time.Duration) error {
 	data, err := json.Marshal(value)
@@ -247,6 +256,14 @@ func (cs *CacheService) Transaction(fn func(*redis.Tx) error, keys ...string) er
 	return fmt.Errorf("transactions not supported in cluster mode")
 }
 
+// RunScript runs a Lua script atomically, loading it on first use. Unlike
+// Transaction it works in every Redis mode; in cluster mode all keys must
+// share a hash slot, e.g. through a common "{tag}". The script runs under
+// the caller's ctx, so it ends with the request that started it.
+func (cs *CacheService) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) *redis.Cmd {
+	return script.Run(ctx, cs.client.Client, keys, args...)
+}
+
 // GetClient returns the underlying Redis client for advanced operations
 func (cs *CacheService) GetClient() *RedisClient {
 	return cs.client
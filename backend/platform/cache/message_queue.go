@@ -37,6 +37,15 @@ func NewMessageQueue(ctx context.Context) (*MessageQueue, error) {
 	}, nil
 }
 
+// NewMessageQueueWithClient creates a message queue over an existing
+// Redis client, such as one connected to an in-memory server in tests.
+func NewMessageQueueWithClient(ctx context.Context, client redis.UniversalClient) *MessageQueue {
+	return &MessageQueue{
+		client: &RedisClient{Client: client, connectType: "standalone"},
+		ctx:    ctx,
+	}
+}
+
 // Message represents a queue message
 type Message struct {
 	ID        string                 `json:"id"`
@@ -86,6 +95,36 @@ func (mq *MessageQueue) Enqueue(queueName string, msgType string, payload map[st
 	return mq.client.Client.LPush(mq.ctx, queueName, data).Err()
 }
 
+// EnqueueDelayed adds a message to the queue once delay has passed. It is
+// moved to the queue by the next [MessageQueue.ProcessDelayedMessages].
+func (mq *MessageQueue) EnqueueDelayed(queueName string, msgType string, payload map[string]interface{}, delay time.Duration, opts *QueueOptions) error {
+	if opts == nil {
+		opts = DefaultQueueOptions()
+	}
+
+	message := Message{
+		ID:        generateID(),
+		Type:      msgType,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+		Attempts:  0,
+		MaxRetry:  opts.MaxRetry,
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	delayedQueueName := fmt.Sprintf("%s:delayed", queueName)
+	score := float64(time.Now().Add(delay).Unix())
+
+	return mq.client.Client.ZAdd(mq.ctx, delayedQueueName, redis.Z{
+		Score:  score,
+		Member: data,
+	}).Err()
+}
+
 // Dequeue retrieves a message from the queue
 func (mq *MessageQueue) Dequeue(queueName string) (*Message, error) {
 	// Use blocking pop operation
@@ -262,12 +301,13 @@ func (ps *PubSub) SubscribeWithHandler(handler MessageHandler, channels ...strin
 
 // Worker represents a queue worker
 type Worker struct {
-	mq          *MessageQueue
-	queueName   string
-	concurrency int
-	handlers    map[string]MessageHandler
-	quit        chan struct{}
-	wg          sync.WaitGroup
+	mq            *MessageQueue
+	queueName     string
+	concurrency   int
+	handlers      map[string]MessageHandler
+	delayInterval time.Duration
+	quit          chan struct{}
+	wg            sync.WaitGroup
 }
 
 // NewWorker creates a new queue worker
@@ -278,11 +318,12 @@ func NewWorker(ctx context.Context, queueName string, concurrency int) (*Worker,
 	}
 
 	return &Worker{
-		mq:          mq,
-		queueName:   queueName,
-		concurrency: concurrency,
-		handlers:    make(map[string]MessageHandler),
-		quit:        make(chan struct{}),
+		mq:            mq,
+		queueName:     queueName,
+		concurrency:   concurrency,
+		handlers:      make(map[string]MessageHandler),
+		delayInterval: 30 * time.Second,
+		quit:          make(chan struct{}),
 	}, nil
 }
 
@@ -291,6 +332,12 @@ func (w *Worker) RegisterHandler(msgType string, handler MessageHandler) {
 	w.handlers[msgType] = handler
 }
 
+// SetDelayInterval sets how often delayed messages are moved to the
+// queue, 30 seconds by default. It must be called before Start.
+func (w *Worker) SetDelayInterval(interval time.Duration) {
+	w.delayInterval = interval
+}
+
 // Start starts the worker
 func (w *Worker) Start() {
 	for i := 0; i < w.concurrency; i++ {
@@ -347,7 +394,7 @@ func (w *Worker) processMessages() {
 func (w *Worker) processDelayedMessages() {
 	defer w.wg.Done()
 
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(w.delayInterval)
 	defer ticker.Stop()
 
 	for {
@@ -26,7 +26,10 @@ func GORMMysqlConnection() (*gorm.DB, error) {
 	}
 
 	// Define database connection for MySQL with GORM.
-	db, err := gorm.Open(mysql.Open(mysqlConnURL), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(mysqlConnURL), &gorm.Config{
+		// Surface unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
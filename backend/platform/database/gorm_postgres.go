@@ -26,7 +26,10 @@ func GORMPostgreSQLConnection() (*gorm.DB, error) {
 	}
 
 	// Define database connection for PostgreSQL with GORM.
-	db, err := gorm.Open(postgres.Open(postgresConnURL), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(postgresConnURL), &gorm.Config{
+		// Surface unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error, not connected to database, %w", err)
 	}
@@ -0,0 +1,7 @@
+-- Dropping the column forgets every owner, claimed ones included.
+-- Migrating up again leaves all wallets unowned, to be claimed anew.
+DROP INDEX IF EXISTS "idx_Wallets_UserId";
+
+ALTER TABLE "Wallets" DROP CONSTRAINT IF EXISTS "fk_Users_Wallets";
+
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "UserId";
@@ -0,0 +1,14 @@
+-- Wallets created before this migration were made anonymously and there
+-- is no record of who made them, so their "UserId" stays NULL rather than
+-- being guessed. Owner scoped queries never return such a wallet: it is
+-- unreachable through the API until an operator hands it to its user with
+--
+--   make wallets.unclaimed                       # list them
+--   make wallets.claim wallet=<id> user=<userId>
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "UserId" varchar(128);
+
+ALTER TABLE "Wallets"
+    ADD CONSTRAINT "fk_Users_Wallets" FOREIGN KEY ("UserId")
+    REFERENCES "Users" ("UserId") ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS "idx_Wallets_UserId" ON "Wallets" ("UserId");
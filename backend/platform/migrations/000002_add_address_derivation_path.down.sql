@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_BlockchainAddresses_WalletId_DerivationPath";
+
+ALTER TABLE "BlockchainAddresses"
+    DROP COLUMN IF EXISTS "AddressIndex",
+    DROP COLUMN IF EXISTS "Change",
+    DROP COLUMN IF EXISTS "Account",
+    DROP COLUMN IF EXISTS "DerivationPath",
+    DROP COLUMN IF EXISTS "Chain";
@@ -0,0 +1,14 @@
+ALTER TABLE "BlockchainAddresses"
+    ADD COLUMN IF NOT EXISTS "Chain" varchar(16) NOT NULL DEFAULT 'ETH',
+    ADD COLUMN IF NOT EXISTS "DerivationPath" varchar(64) NOT NULL DEFAULT 'm/44''/60''/0''/0/0',
+    ADD COLUMN IF NOT EXISTS "Account" int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "Change" int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS "AddressIndex" int NOT NULL DEFAULT 0;
+
+-- Every address created so far was derived from m/44'/60'/0'/0/0.
+ALTER TABLE "BlockchainAddresses"
+    ALTER COLUMN "Chain" DROP DEFAULT,
+    ALTER COLUMN "DerivationPath" DROP DEFAULT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_BlockchainAddresses_WalletId_DerivationPath"
+    ON "BlockchainAddresses" ("WalletId", "DerivationPath");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_Wallets_UserId_Fingerprint";
+
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "Fingerprint";
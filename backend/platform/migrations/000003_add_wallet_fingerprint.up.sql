@@ -0,0 +1,6 @@
+-- Wallets created before this migration keep a NULL fingerprint. It is
+-- filled in the first time the wallet is unlocked with its secret phrase.
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "Fingerprint" varchar(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Wallets_UserId_Fingerprint"
+    ON "Wallets" ("UserId", "Fingerprint");
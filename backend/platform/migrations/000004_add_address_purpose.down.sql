@@ -0,0 +1 @@
+ALTER TABLE "BlockchainAddresses" DROP COLUMN IF EXISTS "Purpose";
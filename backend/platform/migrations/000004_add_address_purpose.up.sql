@@ -0,0 +1,4 @@
+-- Addresses created so far are all BIP44 Ethereum addresses.
+ALTER TABLE "BlockchainAddresses" ADD COLUMN IF NOT EXISTS "Purpose" int NOT NULL DEFAULT 44;
+
+ALTER TABLE "BlockchainAddresses" ALTER COLUMN "Purpose" DROP DEFAULT;
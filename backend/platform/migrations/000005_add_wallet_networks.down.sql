@@ -0,0 +1,3 @@
+ALTER TABLE "Wallets"
+    DROP COLUMN IF EXISTS "BtcNetwork",
+    DROP COLUMN IF EXISTS "EthNetwork";
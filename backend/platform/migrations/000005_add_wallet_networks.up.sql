@@ -0,0 +1,8 @@
+-- Wallets created so far derived mainnet addresses only.
+ALTER TABLE "Wallets"
+    ADD COLUMN IF NOT EXISTS "EthNetwork" varchar(16) NOT NULL DEFAULT 'mainnet',
+    ADD COLUMN IF NOT EXISTS "BtcNetwork" varchar(16) NOT NULL DEFAULT 'mainnet';
+
+ALTER TABLE "Wallets"
+    ALTER COLUMN "EthNetwork" DROP DEFAULT,
+    ALTER COLUMN "BtcNetwork" DROP DEFAULT;
@@ -0,0 +1 @@
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "HasSeedPassphrase";
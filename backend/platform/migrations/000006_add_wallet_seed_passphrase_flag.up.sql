@@ -0,0 +1 @@
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "HasSeedPassphrase" boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_Wallets_KekId";
+
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "KekId";
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "WrappedDek";
@@ -0,0 +1,6 @@
+-- Envelope encryption: the mnemonic DEK wrapped by a key encryption key.
+-- NULL for wallets sealed with the passphrase-only scheme.
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "WrappedDek" text;
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "KekId" varchar(64);
+
+CREATE INDEX IF NOT EXISTS "idx_Wallets_KekId" ON "Wallets" ("KekId");
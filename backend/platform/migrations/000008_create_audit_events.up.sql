@@ -0,0 +1,14 @@
+-- Append-only record of security-relevant actions on a user's wallets.
+CREATE TABLE IF NOT EXISTS "AuditEvents" (
+    "AuditEventId" varchar(128) PRIMARY KEY,
+    "UserId" varchar(128) NOT NULL,
+    "WalletId" varchar(128),
+    "Action" varchar(64) NOT NULL,
+    "IpAddress" varchar(64),
+    "UserAgent" varchar(512),
+    "Metadata" text,
+    "CreateDate" timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS "idx_AuditEvents_UserId" ON "AuditEvents" ("UserId");
+CREATE INDEX IF NOT EXISTS "idx_AuditEvents_WalletId" ON "AuditEvents" ("WalletId");
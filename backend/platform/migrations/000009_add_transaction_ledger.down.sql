@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "idx_Transactions_TxHash";
+DROP INDEX IF EXISTS "idx_Transactions_WalletId_TransactionDate";
+
+ALTER TABLE "Transactions"
+    DROP COLUMN IF EXISTS "UpdateDate",
+    DROP COLUMN IF EXISTS "CreateDate",
+    DROP COLUMN IF EXISTS "TxHash",
+    DROP COLUMN IF EXISTS "Direction",
+    DROP COLUMN IF EXISTS "Chain";
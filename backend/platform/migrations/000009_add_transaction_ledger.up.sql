@@ -0,0 +1,16 @@
+-- Transactions recorded so far were outgoing Ethereum transfers.
+ALTER TABLE "Transactions"
+    ADD COLUMN IF NOT EXISTS "Chain" varchar(16) NOT NULL DEFAULT 'ETH',
+    ADD COLUMN IF NOT EXISTS "Direction" varchar(16) NOT NULL DEFAULT 'outgoing',
+    ADD COLUMN IF NOT EXISTS "TxHash" varchar(128),
+    ADD COLUMN IF NOT EXISTS "CreateDate" timestamptz,
+    ADD COLUMN IF NOT EXISTS "UpdateDate" timestamptz;
+
+ALTER TABLE "Transactions"
+    ALTER COLUMN "Chain" DROP DEFAULT,
+    ALTER COLUMN "Direction" DROP DEFAULT;
+
+-- Keyset pagination walks a wallet's transactions newest first.
+CREATE INDEX IF NOT EXISTS "idx_Transactions_WalletId_TransactionDate"
+    ON "Transactions" ("WalletId", "TransactionDate", "TransactionId");
+CREATE INDEX IF NOT EXISTS "idx_Transactions_TxHash" ON "Transactions" ("TxHash");
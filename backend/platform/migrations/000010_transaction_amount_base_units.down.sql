@@ -0,0 +1,8 @@
+-- Amounts beyond decimal(18,8) cannot be restored and make this fail.
+ALTER TABLE "Transactions"
+    ALTER COLUMN "Amount" TYPE decimal(18,8)
+        USING "Amount" / power(10::numeric, "Decimals");
+
+ALTER TABLE "Transactions"
+    DROP COLUMN IF EXISTS "Decimals",
+    DROP COLUMN IF EXISTS "Asset";
@@ -0,0 +1,15 @@
+-- Amounts move from rounded whole units to exact integers in base units
+-- (wei, satoshi). numeric(78,0) holds any uint256.
+ALTER TABLE "Transactions"
+    ADD COLUMN IF NOT EXISTS "Asset" varchar(32),
+    ADD COLUMN IF NOT EXISTS "Decimals" smallint;
+
+UPDATE "Transactions"
+SET "Asset" = "Chain",
+    "Decimals" = CASE "Chain" WHEN 'BTC' THEN 8 ELSE 18 END;
+
+ALTER TABLE "Transactions"
+    ALTER COLUMN "Asset" SET NOT NULL,
+    ALTER COLUMN "Decimals" SET NOT NULL,
+    ALTER COLUMN "Amount" TYPE numeric(78,0)
+        USING round("Amount" * power(10::numeric, "Decimals"));
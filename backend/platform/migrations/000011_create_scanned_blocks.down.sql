@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS "idx_Transactions_Chain_BlockNumber";
+
+ALTER TABLE "Transactions"
+    DROP COLUMN IF EXISTS "Confirmations",
+    DROP COLUMN IF EXISTS "BlockHash",
+    DROP COLUMN IF EXISTS "BlockNumber";
+
+DROP TABLE IF EXISTS "ScannedBlocks";
@@ -0,0 +1,20 @@
+-- Blocks the deposit watcher has processed, newest per chain and network
+-- first. Kept for a window of recent blocks to detect reorgs.
+CREATE TABLE IF NOT EXISTS "ScannedBlocks" (
+    "Chain" varchar(16) NOT NULL,
+    "Network" varchar(16) NOT NULL,
+    "BlockNumber" bigint NOT NULL,
+    "BlockHash" varchar(66) NOT NULL,
+    "ParentHash" varchar(66) NOT NULL,
+    "CreateDate" timestamptz,
+    PRIMARY KEY ("Chain", "Network", "BlockNumber")
+);
+
+-- Deposits found on chain remember their block so reorgs can undo them.
+ALTER TABLE "Transactions"
+    ADD COLUMN IF NOT EXISTS "BlockNumber" bigint,
+    ADD COLUMN IF NOT EXISTS "BlockHash" varchar(66),
+    ADD COLUMN IF NOT EXISTS "Confirmations" bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS "idx_Transactions_Chain_BlockNumber"
+    ON "Transactions" ("Chain", "BlockNumber");
@@ -0,0 +1,4 @@
+ALTER TABLE "Transactions"
+    DROP COLUMN IF EXISTS "ContractAddress";
+
+DROP TABLE IF EXISTS "Erc20Tokens";
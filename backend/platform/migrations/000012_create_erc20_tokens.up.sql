@@ -0,0 +1,29 @@
+-- Registry of ERC-20 contracts, seeded with the mainnet stablecoins.
+CREATE TABLE IF NOT EXISTS "Erc20Tokens" (
+    "TokenId" varchar(128) NOT NULL PRIMARY KEY,
+    "Chain" varchar(16) NOT NULL,
+    "Network" varchar(16) NOT NULL,
+    "ContractAddress" varchar(42) NOT NULL,
+    "Symbol" varchar(32) NOT NULL,
+    "Name" varchar(128) NOT NULL,
+    "Decimals" smallint NOT NULL,
+    "CreateDate" timestamptz,
+    "UpdateDate" timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Erc20Tokens_Chain_Network_ContractAddress"
+    ON "Erc20Tokens" ("Chain", "Network", "ContractAddress");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_Erc20Tokens_Chain_Network_Symbol"
+    ON "Erc20Tokens" ("Chain", "Network", "Symbol");
+
+INSERT INTO "Erc20Tokens"
+    ("TokenId", "Chain", "Network", "ContractAddress", "Symbol", "Name", "Decimals", "CreateDate", "UpdateDate")
+VALUES
+    ('eth-mainnet-usdt', 'ETH', 'mainnet', '0xdAC17F958D2ee523a2206206994597C13D831ec7', 'USDT', 'Tether USD', 6, now(), now()),
+    ('eth-mainnet-usdc', 'ETH', 'mainnet', '0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48', 'USDC', 'USD Coin', 6, now(), now())
+ON CONFLICT DO NOTHING;
+
+-- Token transfers name their contract; native ones leave it empty.
+ALTER TABLE "Transactions"
+    ADD COLUMN IF NOT EXISTS "ContractAddress" varchar(42);
@@ -0,0 +1,5 @@
+ALTER TABLE "Wallets"
+    DROP COLUMN IF EXISTS "AllowlistDisableDate",
+    DROP COLUMN IF EXISTS "AllowlistEnabled";
+
+DROP TABLE IF EXISTS "WithdrawalAddresses";
@@ -0,0 +1,19 @@
+-- Per-wallet address book of approved withdrawal destinations.
+CREATE TABLE IF NOT EXISTS "WithdrawalAddresses" (
+    "WithdrawalAddressId" varchar(128) NOT NULL PRIMARY KEY,
+    "WalletId" varchar(128) NOT NULL
+        REFERENCES "Wallets" ("WalletId") ON UPDATE CASCADE ON DELETE CASCADE,
+    "Chain" varchar(16) NOT NULL,
+    "Address" varchar(128) NOT NULL,
+    "Label" varchar(128) NOT NULL,
+    "ActiveDate" timestamptz NOT NULL,
+    "CreateDate" timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_WithdrawalAddresses_WalletId_Chain_Address"
+    ON "WithdrawalAddresses" ("WalletId", "Chain", "Address");
+
+-- Allowlist mode; a pending switch-off carries the date it takes effect.
+ALTER TABLE "Wallets"
+    ADD COLUMN IF NOT EXISTS "AllowlistEnabled" boolean NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS "AllowlistDisableDate" timestamptz;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "WithdrawalApprovals";
+DROP TABLE IF EXISTS "WithdrawalRequests";
@@ -0,0 +1,39 @@
+-- Outgoing transfers waiting for approvals, and the approvers' decisions.
+CREATE TABLE IF NOT EXISTS "WithdrawalRequests" (
+    "WithdrawalRequestId" varchar(128) NOT NULL PRIMARY KEY,
+    "WalletId" varchar(128) NOT NULL
+        REFERENCES "Wallets" ("WalletId") ON UPDATE CASCADE ON DELETE CASCADE,
+    "RequesterId" varchar(128) NOT NULL,
+    "Kind" varchar(32) NOT NULL,
+    "Chain" varchar(16) NOT NULL,
+    "Asset" varchar(32) NOT NULL,
+    "Decimals" smallint NOT NULL,
+    "Amount" numeric(78,0) NOT NULL,
+    "Destination" text NOT NULL,
+    "Payload" text NOT NULL,
+    "Status" varchar(16) NOT NULL,
+    "RequiredApprovals" int NOT NULL,
+    "ExpireDate" timestamptz NOT NULL,
+    "TxHash" varchar(128),
+    "CreateDate" timestamptz,
+    "UpdateDate" timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS "idx_WithdrawalRequests_WalletId"
+    ON "WithdrawalRequests" ("WalletId");
+
+CREATE INDEX IF NOT EXISTS "idx_WithdrawalRequests_Status"
+    ON "WithdrawalRequests" ("Status");
+
+CREATE TABLE IF NOT EXISTS "WithdrawalApprovals" (
+    "WithdrawalApprovalId" varchar(128) NOT NULL PRIMARY KEY,
+    "WithdrawalRequestId" varchar(128) NOT NULL
+        REFERENCES "WithdrawalRequests" ("WithdrawalRequestId") ON UPDATE CASCADE ON DELETE CASCADE,
+    "ApproverId" varchar(128) NOT NULL,
+    "Decision" varchar(16) NOT NULL,
+    "Comment" varchar(512),
+    "CreateDate" timestamptz
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_WithdrawalApprovals_WithdrawalRequestId_ApproverId"
+    ON "WithdrawalApprovals" ("WithdrawalRequestId", "ApproverId");
@@ -0,0 +1,29 @@
+-- Per-user and per-wallet limits on what can be signed.
+CREATE TABLE IF NOT EXISTS "SpendingPolicies" (
+    "SpendingPolicyId" varchar(128) NOT NULL PRIMARY KEY,
+    "UserId" varchar(128) NOT NULL
+        REFERENCES "Users" ("UserId") ON UPDATE CASCADE ON DELETE CASCADE,
+    "WalletId" varchar(128)
+        REFERENCES "Wallets" ("WalletId") ON UPDATE CASCADE ON DELETE CASCADE,
+    "Asset" varchar(32) NOT NULL,
+    "MaxPerTransaction" numeric(78,0),
+    "DailyLimit" numeric(78,0),
+    "WeeklyLimit" numeric(78,0),
+    "AllowedFromHour" smallint CHECK ("AllowedFromHour" BETWEEN 0 AND 23),
+    "AllowedToHour" smallint CHECK ("AllowedToHour" BETWEEN 0 AND 23),
+    "Timezone" varchar(64) NOT NULL DEFAULT 'UTC',
+    "CreateDate" timestamptz,
+    "UpdateDate" timestamptz
+);
+
+-- One policy per asset for each wallet, and one for the user as a whole.
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_SpendingPolicies_UserId_WalletId_Asset"
+    ON "SpendingPolicies" ("UserId", "WalletId", "Asset")
+    WHERE "WalletId" IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_SpendingPolicies_UserId_Asset"
+    ON "SpendingPolicies" ("UserId", "Asset")
+    WHERE "WalletId" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "idx_SpendingPolicies_WalletId"
+    ON "SpendingPolicies" ("WalletId");
@@ -0,0 +1,4 @@
+DELETE FROM "Wallets" WHERE "ExtendedPublicKey" IS NOT NULL;
+
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "WatchOnlyChain";
+ALTER TABLE "Wallets" DROP COLUMN IF EXISTS "ExtendedPublicKey";
@@ -0,0 +1,4 @@
+-- Watch-only wallets hold an account-level extended public key of one
+-- chain instead of a sealed mnemonic. NULL for wallets with a mnemonic.
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "ExtendedPublicKey" text;
+ALTER TABLE "Wallets" ADD COLUMN IF NOT EXISTS "WatchOnlyChain" varchar(8);